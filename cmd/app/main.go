@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"backend-task/internal/app"
+	"backend-task/internal/cli"
 	"backend-task/internal/config"
 	"backend-task/internal/utils"
 
@@ -29,10 +30,23 @@ func main() {
 	// Load Values From .env File.
 	config.LoadEnv()
 
+	// Subcommands ( e.g., "import" ) Run Instead Of The Server :
+	if len(os.Args) > 1 {
+
+		os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr))
+	}
+
 	// Initialize Container :
 	container := app.InitializeContainer()
 	server := container.Server
 
+	// Start Background Workers ( Stopped On Shutdown ) :
+	workerContext, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	go container.Relay.Run(workerContext)
+	go container.WebhookWorker.Run(workerContext)
+	go container.JobWorker.Run(workerContext)
+
 	// Configurable Port :
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -59,7 +73,11 @@ func main() {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
-	log.Fatal("Shutting Down Server")
+	utils.Info("Shutting Down Server")
+
+	// Stop Background Workers And Release Long-Lived Event Streams :
+	stopWorkers()
+	container.Broker.Close()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
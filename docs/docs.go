@@ -94,7 +94,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request. Possible reasons: email already exists, invalid email format, name is required, date_of_birth must be yyyy-mm-dd, or date_of_birth cannot be in the future.",
+                        "description": "Invalid request. Possible reasons: invalid email format, name is required, date_of_birth must be yyyy-mm-dd, or date_of_birth cannot be in the future.",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Email already exists",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -192,7 +198,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request. Possible reasons: invalid ID, email already exists, name cannot be empty, or invalid email format.",
+                        "description": "Invalid request. Possible reasons: invalid ID, name cannot be empty, or invalid email format.",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -203,6 +209,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Email already exists",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -239,11 +251,56 @@ const docTemplate = `{
         "models.ErrorResponse": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer"
+                "detail": {
+                    "type": "string",
+                    "example": "email already exists"
+                },
+                "error_code": {
+                    "type": "string",
+                    "example": "USER_EMAIL_TAKEN"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FieldError"
+                    }
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/api/v1/users"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 409
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Conflict"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "urn:backend-task:problem:user-email-taken"
+                }
+            }
+        },
+        "models.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "[0].email"
                 },
                 "message": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "invalid email format"
+                },
+                "rule": {
+                    "type": "string",
+                    "example": "email"
                 }
             }
         },
@@ -280,8 +337,14 @@ const docTemplate = `{
                     "example": "1990-05-15"
                 },
                 "email": {
-                    "description": "Email Address ( Unique, Valid format ).\n@Required",
+                    "description": "Email Address As Entered ( Unique Per Tenant By Its Canonical Form; IDN And Quoted Local Parts Accepted ).\n@Required",
+                    "type": "string",
+                    "example": "John.Doe@example.com"
+                },
+                "email_canonical": {
+                    "description": "Form Compared For Duplicates: Lower-Case, Punycode Domain, Provider Aliases Folded.",
                     "type": "string",
+                    "readOnly": true,
                     "example": "john.doe@example.com"
                 },
                 "group": {
@@ -1,15 +1,32 @@
 package app
 
 import (
+	"fmt"
+	"net/http"
+
+	"backend-task/internal/config"
+	"backend-task/internal/constants"
 	"backend-task/internal/db"
+	"backend-task/internal/events/publisher"
+	eventRepository "backend-task/internal/events/repository"
+	eventServices "backend-task/internal/events/services"
+	jobRepository "backend-task/internal/job/repository"
+	jobServices "backend-task/internal/job/services"
 	"backend-task/internal/router"
+	"backend-task/internal/utils"
+	webhookRepository "backend-task/internal/webhook/repository"
+	webhookServices "backend-task/internal/webhook/services"
 
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
 type Container struct {
-	Server *router.Server
+	Server        *router.Server
+	Relay         *eventServices.Relay
+	WebhookWorker *webhookServices.Worker
+	JobWorker     *jobServices.Worker
+	Broker        *eventServices.Broker
 }
 
 // InitializeContainer Builds And Wires Dependencies But Does NOT Start The Server.
@@ -18,11 +35,58 @@ func InitializeContainer() *Container {
 	// Initialize DB
 	connection := db.InitDB()
 
-	// Setup Routers :
-	route := router.SetupRouters(connection)
+	// Live Event Stream Broker ( Fed By The Relay ) :
+	broker := eventServices.NewBroker(config.GetEnvInt(constants.SSE_REPLAY_BUFFER, constants.DefaultSSEReplayBuffer))
+
+	// Setup Services & Routers :
+	services := router.NewServices(connection)
+	route := router.SetupRouters(services, broker)
 
 	// Add Swagger Endpoint :
 	route.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	return &Container{Server: route}
+	// Outbox Relay & Webhook Worker ( Started By The Caller ) :
+	webhookRepo := webhookRepository.NewWebhookRepository(connection)
+	eventPublisher := publisher.NewMultiPublisher(newPublisher(), webhookServices.NewDispatcher(webhookRepo, nil), broker)
+
+	relay := eventServices.NewRelay(
+		eventRepository.NewOutboxRepository(connection), eventPublisher,
+		config.GetEnvDuration(constants.OUTBOX_POLL_INTERVAL, constants.DefaultOutboxPollInterval),
+		config.GetEnvInt(constants.OUTBOX_BATCH_SIZE, constants.DefaultOutboxBatchSize),
+	)
+
+	webhookWorker := webhookServices.NewWorker(webhookRepo, webhookServices.WorkerConfig{
+		MaxAttempts:  config.GetEnvInt(constants.WEBHOOK_MAX_ATTEMPTS, constants.DefaultWebhookMaxAttempts),
+		BaseBackoff:  config.GetEnvDuration(constants.WEBHOOK_BASE_BACKOFF, constants.DefaultWebhookBaseBackoff),
+		MaxBackoff:   config.GetEnvDuration(constants.WEBHOOK_MAX_BACKOFF, constants.DefaultWebhookMaxBackoff),
+		PollInterval: config.GetEnvDuration(constants.WEBHOOK_POLL_INTERVAL, constants.DefaultWebhookPollInterval),
+		Client:       &http.Client{Timeout: config.GetEnvDuration(constants.WEBHOOK_TIMEOUT, constants.DefaultWebhookTimeout)},
+	})
+
+	jobWorker := jobServices.NewWorker(jobRepository.NewJobRepository(connection), services.Users, jobServices.WorkerConfig{
+		Workers:      config.GetEnvInt(constants.JOB_WORKERS, constants.DefaultJobWorkers),
+		PollInterval: config.GetEnvDuration(constants.JOB_POLL_INTERVAL, constants.DefaultJobPollInterval),
+		ChunkSize:    config.GetEnvInt(constants.JOB_CHUNK_SIZE, constants.DefaultJobChunkSize),
+	})
+
+	return &Container{Server: route, Relay: relay, WebhookWorker: webhookWorker, JobWorker: jobWorker, Broker: broker}
+}
+
+// newPublisher Selects The Event Publisher Configured Via EVENT_PUBLISHER :
+func newPublisher() publisher.Publisher {
+
+	switch kind := config.GetEnv(constants.EVENT_PUBLISHER, constants.EventPublisherLog); kind {
+	case constants.EventPublisherFile:
+		return publisher.NewFilePublisher(config.GetEnv(constants.EVENT_SINK_FILE, constants.DefaultEventSinkFile))
+
+	case constants.EventPublisherMemory:
+		return publisher.NewInMemoryPublisher()
+
+	case constants.EventPublisherLog:
+		return publisher.NewLogPublisher()
+
+	default:
+		utils.Error(fmt.Sprintf("%s: %s", utils.ErrUnsupportedEventPublisher, kind))
+		return publisher.NewLogPublisher()
+	}
 }
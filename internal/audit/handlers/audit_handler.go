@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"backend-task/internal/audit/models"
+	AuditServiceInterface "backend-task/internal/audit/services/interface"
+	constants "backend-task/internal/constants"
+	"backend-task/internal/utils"
+)
+
+type AuditHandler struct {
+	Service AuditServiceInterface.AuditService
+}
+
+func NewAuditHandler(s AuditServiceInterface.AuditService) *AuditHandler {
+
+	return &AuditHandler{Service: s}
+}
+
+// QueryAudit godoc
+// @Summary Query the audit log.
+// @Description Returns audit entries ( newest first ) for mutating API calls, optionally filtered.
+// @Tags audit
+// @Produce json
+// @Param actor_id query string false "Actor ID"
+// @Param action query string false "Action (create, update, delete)"
+// @Param target_type query string false "Target type (e.g., user)"
+// @Param target_id query string false "Target ID"
+// @Param request_id query string false "Request ID"
+// @Param from query string false "Lower bound (RFC 3339)"
+// @Param to query string false "Upper bound (RFC 3339)"
+// @Param page query int false "Page number (default 1)"
+// @Param page_size query int false "Page size (default 50, max 500)"
+// @Success 200 {object} models.AuditPage
+// @Failure 400 {object} models.ErrorResponse "Invalid time range"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /audit [get]
+func (auditHandler *AuditHandler) QueryAudit(context *gin.Context) {
+
+	page, pageSize := utils.ParsePagination(context)
+
+	filter := models.AuditFilter{
+		ActorID:    context.Query("actor_id"),
+		Action:     context.Query("action"),
+		TargetType: context.Query("target_type"),
+		TargetID:   context.Query("target_id"),
+		RequestID:  context.Query("request_id"),
+		Page:       page,
+		PageSize:   pageSize,
+	}
+
+	var err error
+	if filter.From, err = parseTimeQuery(context, "from"); err != nil {
+
+		utils.RespondError(context, utils.NewBadRequest(utils.ErrInvalidTimeRange))
+		return
+	}
+
+	if filter.To, err = parseTimeQuery(context, "to"); err != nil {
+
+		utils.RespondError(context, utils.NewBadRequest(utils.ErrInvalidTimeRange))
+		return
+	}
+
+	result, err := auditHandler.Service.QueryEntries(context.Request.Context(), filter)
+	if err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	context.JSON(constants.StatusOK, result)
+}
+
+func parseTimeQuery(context *gin.Context, key string) (*time.Time, error) {
+
+	value := context.Query(key)
+	if value == "" {
+
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+
+		return nil, err
+	}
+
+	return &parsed, nil
+}
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Field Change Describes A Single Field Difference Between Two Snapshots :
+type FieldChange struct {
+	Field  string `json:"field" example:"email"`
+	Before any    `json:"before,omitempty"`
+	After  any    `json:"after,omitempty"`
+}
+
+// Field Changes Is Stored As A JSON Document In A Single Column :
+type FieldChanges []FieldChange
+
+// Value Implements driver.Valuer :
+func (changes FieldChanges) Value() (driver.Value, error) {
+
+	if changes == nil {
+
+		return "[]", nil
+	}
+
+	raw, err := json.Marshal(changes)
+	if err != nil {
+
+		return nil, err
+	}
+
+	return string(raw), nil
+}
+
+// Scan Implements sql.Scanner :
+func (changes *FieldChanges) Scan(value any) error {
+
+	switch raw := value.(type) {
+	case nil:
+		*changes = FieldChanges{}
+		return nil
+
+	case string:
+		return json.Unmarshal([]byte(raw), changes)
+
+	case []byte:
+		return json.Unmarshal(raw, changes)
+
+	default:
+		return fmt.Errorf("unsupported audit changes type: %T", value)
+	}
+}
+
+// Audit Entry Represents One Mutating Action Recorded For Compliance.
+//
+// @Description Durable Audit Trail Entry ( Who Did What, To Which Record, From Where ).
+type AuditEntry struct {
+
+	// Entry Unique Identifier ( UUID ).
+	ID uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000" gorm:"type:uuid;primaryKey"`
+
+	// Tenant The Entry Belongs To ( Set From The Request ).
+	TenantID string `json:"tenant_id" example:"default" gorm:"not null;default:default;index;size:64"`
+
+	// Identity Of The Caller ( "anonymous" When Unauthenticated ).
+	ActorID string `json:"actor_id" example:"anonymous" gorm:"not null;index;size:128"`
+
+	// Request ID That Produced The Change.
+	RequestID string `json:"request_id" example:"2f1c4c1e-1b7a-4d0e-9a55-1d1f3f0e8b4a" gorm:"index;size:64"`
+
+	// Client IP Address.
+	ClientIP string `json:"client_ip" example:"10.0.0.1" gorm:"size:64"`
+
+	// Action Performed ( create, update, delete, ... ).
+	Action string `json:"action" example:"update" gorm:"not null;index;size:64"`
+
+	// Kind Of Record Affected ( user, group, ... ).
+	TargetType string `json:"target_type" example:"user" gorm:"not null;index;size:64"`
+
+	// Identifier Of The Affected Record.
+	TargetID string `json:"target_id" example:"550e8400-e29b-41d4-a716-446655440000" gorm:"not null;index;size:64"`
+
+	// Field Level Before / After Diff ( PII Masked ).
+	Changes FieldChanges `json:"changes" gorm:"type:text"`
+
+	// Timestamp When The Entry Was Recorded.
+	CreatedAt time.Time `json:"created_at" example:"2025-09-01T12:00:00Z" gorm:"index"`
+}
+
+// Before Create Ensures UUID Is Set Automatically :
+func (entry *AuditEntry) BeforeCreate(tx *gorm.DB) (err error) {
+
+	if entry.ID == uuid.Nil {
+
+		entry.ID = uuid.New()
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Audit Filter Holds The Optional Criteria For Querying Audit Entries :
+type AuditFilter struct {
+	ActorID    string
+	Action     string
+	TargetType string
+	TargetID   string
+	RequestID  string
+	From       *time.Time
+	To         *time.Time
+	Page       int
+	PageSize   int
+}
+
+// Audit Page Is A Paginated Slice Of Audit Entries.
+//
+// @Description Paginated Audit Query Result.
+type AuditPage struct {
+	Items    []*AuditEntry `json:"items"`
+	Total    int64         `json:"total" example:"42"`
+	Page     int           `json:"page" example:"1"`
+	PageSize int           `json:"page_size" example:"50"`
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"backend-task/internal/audit/models"
+
+	"gorm.io/gorm"
+)
+
+// Audit Repository Interface :
+type AuditRepository interface {
+	CreateEntryTx(gormDB *gorm.DB, entry *models.AuditEntry) error
+	QueryEntries(context context.Context, filter models.AuditFilter) ([]*models.AuditEntry, int64, error)
+	ReassignTargetTx(gormDB *gorm.DB, targetType, fromID, toID string) error
+}
+
+// AuditRepositoryDB Implementation :
+type AuditRepositoryDB struct {
+	gormDB *gorm.DB
+}
+
+// Constructor :
+func NewAuditRepository(db *gorm.DB) AuditRepository {
+
+	return &AuditRepositoryDB{gormDB: db}
+}
+
+// CreateEntryTx Persists The Entry Using The Caller's Transaction So It Commits ( Or Rolls Back ) With The Change :
+func (auditRepositoryDB *AuditRepositoryDB) CreateEntryTx(gormDB *gorm.DB, entry *models.AuditEntry) error {
+
+	return gormDB.Create(entry).Error
+}
+
+// ReassignTargetTx Points Every Entry About One Record At Another ( e.g., A Merged User's Survivor ) :
+func (auditRepositoryDB *AuditRepositoryDB) ReassignTargetTx(gormDB *gorm.DB, targetType, fromID, toID string) error {
+
+	return gormDB.Model(&models.AuditEntry{}).
+		Where("target_type = ? AND target_id = ?", targetType, fromID).
+		Update("target_id", toID).Error
+}
+
+func (auditRepositoryDB *AuditRepositoryDB) QueryEntries(context context.Context, filter models.AuditFilter) ([]*models.AuditEntry, int64, error) {
+
+	gormDB := auditRepositoryDB.gormDB.WithContext(context).Model(&models.AuditEntry{})
+
+	if filter.ActorID != "" {
+
+		gormDB = gormDB.Where("actor_id = ?", filter.ActorID)
+	}
+
+	if filter.Action != "" {
+
+		gormDB = gormDB.Where("action = ?", filter.Action)
+	}
+
+	if filter.TargetType != "" {
+
+		gormDB = gormDB.Where("target_type = ?", filter.TargetType)
+	}
+
+	if filter.TargetID != "" {
+
+		gormDB = gormDB.Where("target_id = ?", filter.TargetID)
+	}
+
+	if filter.RequestID != "" {
+
+		gormDB = gormDB.Where("request_id = ?", filter.RequestID)
+	}
+
+	if filter.From != nil {
+
+		gormDB = gormDB.Where("created_at >= ?", *filter.From)
+	}
+
+	if filter.To != nil {
+
+		gormDB = gormDB.Where("created_at <= ?", *filter.To)
+	}
+
+	var total int64
+	if err := gormDB.Count(&total).Error; err != nil {
+
+		return nil, 0, fmt.Errorf("failed to count audit entries: %w", err)
+	}
+
+	var entries []*models.AuditEntry
+	if err := gormDB.Order("created_at desc").
+		Offset((filter.Page - 1) * filter.PageSize).
+		Limit(filter.PageSize).
+		Find(&entries).Error; err != nil {
+
+		return nil, 0, fmt.Errorf("failed to query audit entries: %w", err)
+	}
+
+	return entries, total, nil
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"backend-task/internal/audit/models"
+	"backend-task/internal/constants"
+)
+
+// Fields That Change On Every Write And Carry No Audit Value :
+var ignoredDiffFields = map[string]bool{
+	"created_at": true,
+	"updated_at": true,
+}
+
+// ---------------- PII Masking ----------------
+
+// Masker Hides The Values Of Configured ( PII ) Fields In Audit Diffs :
+type Masker struct {
+	fields map[string]bool
+}
+
+// NewMasker Builds A Masker From A Comma Separated List Of JSON Field Names :
+func NewMasker(fields string) *Masker {
+
+	masker := &Masker{fields: map[string]bool{}}
+	for _, field := range strings.Split(fields, ",") {
+
+		if field = strings.TrimSpace(field); field != "" {
+
+			masker.fields[field] = true
+		}
+	}
+
+	return masker
+}
+
+// Mask Returns The Value To Store For The Field ( Emails Keep Their First Character And Domain ) :
+func (masker *Masker) Mask(field string, value any) any {
+
+	if masker == nil || value == nil || !masker.fields[field] {
+
+		return value
+	}
+
+	if text, ok := value.(string); ok {
+
+		if at := strings.LastIndex(text, "@"); at > 0 {
+
+			return text[:1] + constants.AuditMaskValue + text[at:]
+		}
+	}
+
+	return constants.AuditMaskValue
+}
+
+// ---------------- Diff ----------------
+
+// ComputeDiff Compares Two Snapshots Field By Field ( By Their JSON Representation ).
+// Either Side May Be nil, In Which Case Every Field Of The Other Side Is Reported.
+func ComputeDiff(before, after any, masker *Masker) (models.FieldChanges, error) {
+
+	beforeFields, err := toFieldMap(before)
+	if err != nil {
+
+		return nil, err
+	}
+
+	afterFields, err := toFieldMap(after)
+	if err != nil {
+
+		return nil, err
+	}
+
+	keys := make(map[string]bool, len(beforeFields)+len(afterFields))
+	for key := range beforeFields {
+
+		keys[key] = true
+	}
+
+	for key := range afterFields {
+
+		keys[key] = true
+	}
+
+	names := make([]string, 0, len(keys))
+	for key := range keys {
+
+		if !ignoredDiffFields[key] {
+
+			names = append(names, key)
+		}
+	}
+	sort.Strings(names)
+
+	changes := models.FieldChanges{}
+	for _, name := range names {
+
+		oldValue, newValue := beforeFields[name], afterFields[name]
+		if reflect.DeepEqual(oldValue, newValue) {
+
+			continue
+		}
+
+		changes = append(changes, models.FieldChange{
+			Field:  name,
+			Before: masker.Mask(name, oldValue),
+			After:  masker.Mask(name, newValue),
+		})
+	}
+
+	return changes, nil
+}
+
+func toFieldMap(snapshot any) (map[string]any, error) {
+
+	fields := map[string]any{}
+	if snapshot == nil || reflect.ValueOf(snapshot).Kind() == reflect.Ptr && reflect.ValueOf(snapshot).IsNil() {
+
+		return fields, nil
+	}
+
+	raw, err := json.Marshal(snapshot)
+	if err != nil {
+
+		return nil, err
+	}
+
+	if err := json.Unmarshal(raw, &fields); err != nil {
+
+		return nil, err
+	}
+
+	return fields, nil
+}
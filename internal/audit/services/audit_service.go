@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+
+	"backend-task/internal/audit/models"
+	"backend-task/internal/audit/repository"
+	auditServiceInterface "backend-task/internal/audit/services/interface"
+	"backend-task/internal/constants"
+	"backend-task/internal/tenant"
+	"backend-task/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+type AuditService struct {
+	entries repository.AuditRepository
+	masker  *Masker
+}
+
+func NewAuditService(entries repository.AuditRepository, masker *Masker) auditServiceInterface.AuditService {
+
+	return &AuditService{entries: entries, masker: masker}
+}
+
+// ---------------- Record ----------------
+
+func (auditService *AuditService) RecordTx(context context.Context, gormDB *gorm.DB, action, targetType, targetID string, before, after any) error {
+
+	changes, err := ComputeDiff(before, after, auditService.masker)
+	if err != nil {
+
+		return err
+	}
+
+	meta := utils.RequestMetaFromContext(context)
+	actorID := meta.ActorID
+	if actorID == "" {
+
+		actorID = constants.AuditAnonymousActor
+	}
+
+	entry := &models.AuditEntry{
+		TenantID:   tenant.ID(context),
+		ActorID:    actorID,
+		RequestID:  meta.RequestID,
+		ClientIP:   meta.ClientIP,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Changes:    changes,
+	}
+
+	return auditService.entries.CreateEntryTx(gormDB, entry)
+}
+
+// ReassignTargetTx Keeps The Trail Of A Record That Was Folded Into Another ( User Merge ) Under The Surviving Record :
+func (auditService *AuditService) ReassignTargetTx(context context.Context, gormDB *gorm.DB, targetType, fromID, toID string) error {
+
+	return auditService.entries.ReassignTargetTx(gormDB, targetType, fromID, toID)
+}
+
+// ---------------- Query ----------------
+
+func (auditService *AuditService) QueryEntries(context context.Context, filter models.AuditFilter) (*models.AuditPage, error) {
+
+	if filter.Page < 1 {
+
+		filter.Page = constants.DefaultPage
+	}
+
+	if filter.PageSize < 1 || filter.PageSize > constants.MaxPageSize {
+
+		filter.PageSize = constants.DefaultPageSize
+	}
+
+	entries, total, err := auditService.entries.QueryEntries(context, filter)
+	if err != nil {
+
+		return nil, err
+	}
+
+	if entries == nil {
+
+		entries = []*models.AuditEntry{}
+	}
+
+	return &models.AuditPage{Items: entries, Total: total, Page: filter.Page, PageSize: filter.PageSize}, nil
+}
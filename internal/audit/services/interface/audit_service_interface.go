@@ -0,0 +1,23 @@
+package serviceInterface
+
+import (
+	"context"
+
+	"backend-task/internal/audit/models"
+
+	"gorm.io/gorm"
+)
+
+// Audit Service Defines All Operations The Audit Trail Must Provide :
+type AuditService interface {
+
+	// RecordTx Writes An Audit Entry Inside The Caller's Transaction.
+	// Before / After Are Snapshots Of The Target ( nil For Create / Delete Respectively ).
+	RecordTx(context context.Context, gormDB *gorm.DB, action, targetType, targetID string, before, after any) error
+
+	// ReassignTargetTx Moves Every Entry About One Record Onto Another Inside The Caller's Transaction.
+	ReassignTargetTx(context context.Context, gormDB *gorm.DB, targetType, fromID, toID string) error
+
+	// QueryEntries Lists Audit Entries Matching The Filter, Newest First.
+	QueryEntries(context context.Context, filter models.AuditFilter) (*models.AuditPage, error)
+}
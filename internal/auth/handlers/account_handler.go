@@ -0,0 +1,368 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	models "backend-task/internal/auth/models"
+	AccountServiceInterface "backend-task/internal/auth/services/interface"
+	constants "backend-task/internal/constants"
+	"backend-task/internal/i18n"
+	"backend-task/internal/utils"
+)
+
+type AccountHandler struct {
+	Service AccountServiceInterface.AccountService
+}
+
+func NewAccountHandler(s AccountServiceInterface.AccountService) *AccountHandler {
+
+	return &AccountHandler{Service: s}
+}
+
+// Register godoc
+// @Summary Register a self-service account.
+// @Description Creates a user ( allocated to a group like any other; minors need a guardian and wait for consent ) with a password of 10 to 128 characters, and emails a verification link. A taken email gets the same 202 and its owner is emailed instead, so the endpoint does not reveal which emails have accounts.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param account body models.RegisterReq true "Account info"
+// @Success 202 {object} models.RegisterResp
+// @Failure 400 {object} models.ErrorResponse "Invalid request. Possible reasons: weak password, invalid email, invalid date of birth, or guardian missing for a minor."
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /auth/register [post]
+func (accountHandler *AccountHandler) Register(context *gin.Context) {
+
+	var body models.RegisterReq
+	if err := context.ShouldBindJSON(&body); err != nil {
+
+		utils.RespondError(context, utils.NewBindingError(err))
+		return
+	}
+
+	if _, err := accountHandler.Service.Register(context.Request.Context(), body); err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	language := utils.RequestMetaFromContext(context.Request.Context()).Language
+	context.JSON(constants.StatusAccepted, models.RegisterResp{Message: i18n.Text(language, constants.MessageRegistrationAccepted)})
+}
+
+// Login godoc
+// @Summary Log in with email and password.
+// @Description Returns a short-lived access token and a single-use refresh token. Repeated failures lock the account with increasing delays; every failure returns the same 401.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param credentials body models.LoginReq true "Credentials"
+// @Success 200 {object} models.TokenPair
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 401 {object} models.ErrorResponse "Invalid email or password"
+// @Failure 403 {object} models.ErrorResponse "User is suspended or deactivated"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /auth/login [post]
+func (accountHandler *AccountHandler) Login(context *gin.Context) {
+
+	var body models.LoginReq
+	if err := context.ShouldBindJSON(&body); err != nil {
+
+		utils.RespondError(context, utils.NewBindingError(err))
+		return
+	}
+
+	tokens, err := accountHandler.Service.Login(context.Request.Context(), body)
+	if err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	context.JSON(constants.StatusOK, tokens)
+}
+
+// Refresh godoc
+// @Summary Refresh tokens.
+// @Description Exchanges a refresh token for a new token pair. Each refresh token works once; presenting a used one revokes the whole session.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param token body models.RefreshReq true "Refresh token"
+// @Success 200 {object} models.TokenPair
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 401 {object} models.ErrorResponse "Invalid or expired refresh token"
+// @Failure 403 {object} models.ErrorResponse "User is suspended or deactivated"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /auth/refresh [post]
+func (accountHandler *AccountHandler) Refresh(context *gin.Context) {
+
+	var body models.RefreshReq
+	if err := context.ShouldBindJSON(&body); err != nil {
+
+		utils.RespondError(context, utils.NewBindingError(err))
+		return
+	}
+
+	tokens, err := accountHandler.Service.Refresh(context.Request.Context(), body.RefreshToken)
+	if err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	context.JSON(constants.StatusOK, tokens)
+}
+
+// Logout godoc
+// @Summary Log out.
+// @Description Revokes the session of the refresh token; its access tokens stop working immediately.
+// @Tags auth
+// @Accept json
+// @Param token body models.RefreshReq true "Refresh token"
+// @Success 204
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /auth/logout [post]
+func (accountHandler *AccountHandler) Logout(context *gin.Context) {
+
+	var body models.RefreshReq
+	if err := context.ShouldBindJSON(&body); err != nil {
+
+		utils.RespondError(context, utils.NewBindingError(err))
+		return
+	}
+
+	if err := accountHandler.Service.Logout(context.Request.Context(), body.RefreshToken); err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	context.Status(constants.StatusNoContent)
+}
+
+// ChangePassword godoc
+// @Summary Change the caller's password.
+// @Description Requires the current password. All sessions of the caller are revoked.
+// @Tags auth
+// @Accept json
+// @Param passwords body models.ChangePasswordReq true "Current and new password"
+// @Success 204
+// @Failure 400 {object} models.ErrorResponse "Invalid request. Possible reasons: current password is incorrect, or weak password."
+// @Failure 403 {object} models.ErrorResponse "Caller is not a user account"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /auth/password [put]
+func (accountHandler *AccountHandler) ChangePassword(context *gin.Context) {
+
+	principal, ok := selfAccount(context)
+	if !ok {
+
+		return
+	}
+
+	var body models.ChangePasswordReq
+	if err := context.ShouldBindJSON(&body); err != nil {
+
+		utils.RespondError(context, utils.NewBindingError(err))
+		return
+	}
+
+	if err := accountHandler.Service.ChangePassword(context.Request.Context(), principal.Subject, body); err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	context.Status(constants.StatusNoContent)
+}
+
+// SetRoles godoc
+// @Summary Set the roles of an account.
+// @Description Replaces the roles of a password account. Its sessions are revoked so the new roles apply on the next login. Requires a token with an MFA claim.
+// @Tags auth
+// @Accept json
+// @Param id path string true "User ID"
+// @Param roles body models.SetRolesReq true "Roles"
+// @Success 204
+// @Failure 400 {object} models.ErrorResponse "Invalid request. Possible reasons: invalid ID, or empty role names."
+// @Failure 401 {object} models.ErrorResponse "Multi-factor authentication required"
+// @Failure 403 {object} models.ErrorResponse "Permission denied"
+// @Failure 404 {object} models.ErrorResponse "Account not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /auth/accounts/{id}/roles [put]
+func (accountHandler *AccountHandler) SetRoles(context *gin.Context) {
+
+	var body models.SetRolesReq
+	if err := context.ShouldBindJSON(&body); err != nil {
+
+		utils.RespondError(context, utils.NewBindingError(err))
+		return
+	}
+
+	if err := accountHandler.Service.SetRoles(context.Request.Context(), context.Param("id"), body.Roles); err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	context.Status(constants.StatusNoContent)
+}
+
+// EnrollMFA godoc
+// @Summary Start TOTP enrolment.
+// @Description Generates a TOTP secret for the caller and returns it with an otpauth URI ( for a QR code ). The factor is inactive until confirmed with a code.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} models.MFAEnrollment
+// @Failure 403 {object} models.ErrorResponse "Caller is not a password account"
+// @Failure 409 {object} models.ErrorResponse "MFA is already enabled"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /auth/mfa/enroll [post]
+func (accountHandler *AccountHandler) EnrollMFA(context *gin.Context) {
+
+	principal, ok := selfAccount(context)
+	if !ok {
+
+		return
+	}
+
+	enrollment, err := accountHandler.Service.EnrollMFA(context.Request.Context(), principal.Subject)
+	if err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	context.JSON(constants.StatusOK, enrollment)
+}
+
+// ActivateMFA godoc
+// @Summary Activate TOTP.
+// @Description Confirms enrolment with a code from the authenticator app and returns ten one-time recovery codes. They are only shown once.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param code body models.MFACodeReq true "TOTP code"
+// @Success 200 {object} models.RecoveryCodes
+// @Failure 400 {object} models.ErrorResponse "Invalid request. Possible reasons: enrolment not started, or invalid mfa code."
+// @Failure 403 {object} models.ErrorResponse "Caller is not a password account"
+// @Failure 409 {object} models.ErrorResponse "MFA is already enabled"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /auth/mfa/activate [post]
+func (accountHandler *AccountHandler) ActivateMFA(context *gin.Context) {
+
+	principal, ok := selfAccount(context)
+	if !ok {
+
+		return
+	}
+
+	var body models.MFACodeReq
+	if err := context.ShouldBindJSON(&body); err != nil || body.Code == "" {
+
+		utils.RespondError(context, utils.NewBadRequest(utils.ErrInvalidRequestBody))
+		return
+	}
+
+	codes, err := accountHandler.Service.ActivateMFA(context.Request.Context(), principal.Subject, body.Code)
+	if err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	context.JSON(constants.StatusOK, codes)
+}
+
+// VerifyMFA godoc
+// @Summary Complete a login with the second factor.
+// @Description Exchanges the mfa_token returned by login plus a TOTP code ( or a recovery code, usable once ) for a token pair with an MFA claim. A challenge allows 5 attempts and expires after 5 minutes; failures count towards the account lockout.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param challenge body models.MFAVerifyReq true "Challenge and code"
+// @Success 200 {object} models.TokenPair
+// @Failure 400 {object} models.ErrorResponse "Invalid request. Possible reasons: invalid body, or neither code nor recovery_code given."
+// @Failure 401 {object} models.ErrorResponse "Invalid mfa code, or invalid or expired challenge"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /auth/mfa/verify [post]
+func (accountHandler *AccountHandler) VerifyMFA(context *gin.Context) {
+
+	var body models.MFAVerifyReq
+	if err := context.ShouldBindJSON(&body); err != nil {
+
+		utils.RespondError(context, utils.NewBindingError(err))
+		return
+	}
+
+	tokens, err := accountHandler.Service.VerifyMFA(context.Request.Context(), body)
+	if err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	context.JSON(constants.StatusOK, tokens)
+}
+
+// StepUp godoc
+// @Summary Step up the current session.
+// @Description Adds the second factor to the caller's session and returns a new access token with an MFA claim; later refreshes keep it.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param code body models.MFACodeReq true "TOTP or recovery code"
+// @Success 200 {object} models.TokenPair
+// @Failure 400 {object} models.ErrorResponse "Invalid request. Possible reasons: invalid body, missing code, or invalid mfa code."
+// @Failure 401 {object} models.ErrorResponse "Session has been revoked"
+// @Failure 403 {object} models.ErrorResponse "Caller is not a password account"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /auth/mfa/step-up [post]
+func (accountHandler *AccountHandler) StepUp(context *gin.Context) {
+
+	principal, ok := selfAccount(context)
+	if !ok {
+
+		return
+	}
+
+	// Only Tokens From Password Login Carry A Session To Upgrade :
+	if principal.SessionID == "" {
+
+		utils.RespondError(context, utils.NewForbidden(utils.ErrNotAnAccount))
+		return
+	}
+
+	var body models.MFACodeReq
+	if err := context.ShouldBindJSON(&body); err != nil {
+
+		utils.RespondError(context, utils.NewBindingError(err))
+		return
+	}
+
+	tokens, err := accountHandler.Service.StepUp(context.Request.Context(), principal.Subject, principal.SessionID, body)
+	if err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	context.JSON(constants.StatusOK, tokens)
+}
+
+// ---------------- Helper ----------------
+
+// selfAccount Returns The Caller When It May Manage Its Own Account, Otherwise Writes The 403 :
+func selfAccount(context *gin.Context) (*models.Principal, bool) {
+
+	principal := models.PrincipalFromContext(context.Request.Context())
+	if !principal.Can(constants.PermissionUsersUpdateSelf) {
+
+		utils.RespondError(context, utils.NewForbidden(fmt.Errorf("%w: requires %s", utils.ErrPermissionDenied, constants.PermissionUsersUpdateSelf)))
+		return nil, false
+	}
+
+	return principal, true
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+
+	models "backend-task/internal/auth/models"
+	APIKeyServiceInterface "backend-task/internal/auth/services/interface"
+	constants "backend-task/internal/constants"
+	"backend-task/internal/utils"
+)
+
+type APIKeyHandler struct {
+	Service APIKeyServiceInterface.APIKeyService
+}
+
+func NewAPIKeyHandler(s APIKeyServiceInterface.APIKeyService) *APIKeyHandler {
+
+	return &APIKeyHandler{Service: s}
+}
+
+// IssueAPIKey godoc
+// @Summary Issue an API key.
+// @Description Issues a key for machine clients, scoped to permissions and optionally to client IPs / CIDR ranges. The key is only returned in this response; send it in the X-API-Key header.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param apiKey body models.CreateAPIKeyReq true "API key info"
+// @Success 201 {object} models.IssuedAPIKey
+// @Failure 400 {object} models.ErrorResponse "Invalid request. Possible reasons: missing name, unknown permission, or invalid allowed ip."
+// @Failure 403 {object} models.ErrorResponse "Caller lacks apikeys:manage"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /api-keys [post]
+func (apiKeyHandler *APIKeyHandler) IssueAPIKey(context *gin.Context) {
+
+	var body models.CreateAPIKeyReq
+	if err := context.ShouldBindJSON(&body); err != nil {
+
+		utils.RespondError(context, utils.NewBindingError(err))
+		return
+	}
+
+	issued, err := apiKeyHandler.Service.IssueAPIKey(context.Request.Context(), body)
+	if err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	context.JSON(constants.StatusCreated, issued)
+}
+
+// ListAPIKeys godoc
+// @Summary List API keys.
+// @Description Lists every key ( including revoked ones ) with its prefix, scopes, last use and usage count. Secrets are never returned.
+// @Tags api-keys
+// @Produce json
+// @Success 200 {array} models.APIKey
+// @Failure 403 {object} models.ErrorResponse "Caller lacks apikeys:manage"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /api-keys [get]
+func (apiKeyHandler *APIKeyHandler) ListAPIKeys(context *gin.Context) {
+
+	apiKeys, err := apiKeyHandler.Service.ListAPIKeys(context.Request.Context())
+	if err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	context.JSON(constants.StatusOK, apiKeys)
+}
+
+// RotateAPIKey godoc
+// @Summary Rotate an API key.
+// @Description Replaces the key's secret ( and prefix ) while keeping its scopes. The old key stops working immediately.
+// @Tags api-keys
+// @Produce json
+// @Param id path string true "API key ID"
+// @Success 200 {object} models.IssuedAPIKey
+// @Failure 400 {object} models.ErrorResponse "Invalid ID"
+// @Failure 404 {object} models.ErrorResponse "API key not found"
+// @Failure 409 {object} models.ErrorResponse "API key is revoked"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /api-keys/{id}/rotate [post]
+func (apiKeyHandler *APIKeyHandler) RotateAPIKey(context *gin.Context) {
+
+	issued, err := apiKeyHandler.Service.RotateAPIKey(context.Request.Context(), context.Param("id"))
+	if err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	context.JSON(constants.StatusOK, issued)
+}
+
+// RevokeAPIKey godoc
+// @Summary Revoke an API key.
+// @Tags api-keys
+// @Param id path string true "API key ID"
+// @Success 204
+// @Failure 400 {object} models.ErrorResponse "Invalid ID"
+// @Failure 404 {object} models.ErrorResponse "API key not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /api-keys/{id} [delete]
+func (apiKeyHandler *APIKeyHandler) RevokeAPIKey(context *gin.Context) {
+
+	if err := apiKeyHandler.Service.RevokeAPIKey(context.Request.Context(), context.Param("id")); err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	context.Status(constants.StatusNoContent)
+}
@@ -0,0 +1,54 @@
+package models
+
+import userModels "backend-task/internal/user/models"
+
+// Register Req Creates A User Together With Their Password :
+type RegisterReq struct {
+	Name        string `json:"name" binding:"required,notblank" example:"John Doe"`
+	Email       string `json:"email" binding:"required,email" example:"john@example.com"`
+	DateOfBirth string `json:"date_of_birth" binding:"required,datetime=2006-01-02,pastdate" example:"1990-01-01"`
+	Password    string `json:"password" binding:"required" example:"correct-horse-battery-staple"`
+
+	// Required For Users Under 18 ( See CreateUserReq ).
+	Guardian *userModels.GuardianReq `json:"guardian,omitempty"`
+}
+
+// Register Resp Is The Same Whether Or Not The Email Was Already Registered :
+type RegisterResp struct {
+	Message string `json:"message" example:"Check your inbox to finish signing up"`
+}
+
+// Login Req Exchanges Credentials For Tokens :
+type LoginReq struct {
+	Email    string `json:"email" binding:"required" example:"john@example.com"`
+	Password string `json:"password" binding:"required" example:"correct-horse-battery-staple"`
+}
+
+// Refresh Req Carries A Refresh Token ( Used By Refresh And Logout ) :
+type RefreshReq struct {
+	RefreshToken string `json:"refresh_token" binding:"required" example:"9c2f...e1"`
+}
+
+// Change Password Req Replaces The Caller's Password :
+type ChangePasswordReq struct {
+	CurrentPassword string `json:"current_password" binding:"required" example:"correct-horse-battery-staple"`
+	NewPassword     string `json:"new_password" binding:"required" example:"a-brand-new-passphrase"`
+}
+
+// Set Roles Req Replaces The Roles Of A Password Account :
+type SetRolesReq struct {
+	Roles []string `json:"roles" binding:"required,min=1" example:"admin"`
+}
+
+// Token Pair Is Returned By Login, MFA Verification, Step-Up And Refresh.
+// When A Second Factor Is Needed, Login Returns Only The MFA Challenge.
+//
+// @Description Access Token ( JWT ) And Single-Use Refresh Token, Or An MFA Challenge.
+type TokenPair struct {
+	AccessToken  string `json:"access_token,omitempty" example:"eyJhbGciOiJIUzI1NiIs..."`
+	RefreshToken string `json:"refresh_token,omitempty" example:"9c2f...e1"`
+	TokenType    string `json:"token_type,omitempty" example:"Bearer"`
+	ExpiresIn    int64  `json:"expires_in" example:"900"` // Token ( Or Challenge ) Lifetime In Seconds.
+	MFARequired  bool   `json:"mfa_required,omitempty" example:"false"`
+	MFAToken     string `json:"mfa_token,omitempty" example:"Q2hhbGxlbmdl..."` // Exchanged At /auth/mfa/verify.
+}
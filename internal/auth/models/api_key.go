@@ -0,0 +1,81 @@
+package models
+
+import (
+	"time"
+
+	"backend-task/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// API Key Authenticates A Machine Client. Only The SHA-256 Hash Of The Key Is Stored.
+//
+// @Description API Key ( The Secret Is Only Returned When Issued Or Rotated ).
+type APIKey struct {
+
+	// Key Unique Identifier ( UUID ).
+	ID uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000" gorm:"type:uuid;primaryKey"`
+
+	// Tenant The Key Acts For.
+	TenantID string `json:"tenant_id" example:"default" gorm:"not null;default:default;index;size:64"`
+
+	// Human Readable Label.
+	Name string `json:"name" example:"nightly-batch" gorm:"not null;size:128"`
+
+	// Visible Prefix Identifying The Key ( First Part Of The Key ).
+	Prefix string `json:"prefix" example:"bt_1a2b3c4d" gorm:"not null;uniqueIndex;size:32"`
+
+	// Hex SHA-256 Of The Full Key ( Never Returned ).
+	Hash string `json:"-" gorm:"not null;size:64"`
+
+	// Permissions Granted To The Key.
+	Permissions utils.StringList `json:"permissions" swaggertype:"array,string" example:"users:read,users:import" gorm:"type:text;not null"`
+
+	// Client IPs / CIDR Ranges Allowed To Use The Key ( Empty = Any ).
+	AllowedIPs utils.StringList `json:"allowed_ips" swaggertype:"array,string" example:"10.0.0.0/8" gorm:"type:text;not null"`
+
+	// Actor That Issued The Key.
+	CreatedBy string `json:"created_by" example:"admin-1" gorm:"not null;size:128"`
+
+	// Number Of Authenticated Requests Made With The Key.
+	UsageCount int64 `json:"usage_count" example:"42" gorm:"not null;default:0"`
+
+	// Timestamp Of The Last Authenticated Request.
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" example:"2025-09-01T12:30:00Z"`
+
+	// Timestamp Of The Last Rotation.
+	RotatedAt *time.Time `json:"rotated_at,omitempty" example:"2025-09-01T12:30:00Z"`
+
+	// Revoked Keys Can No Longer Authenticate.
+	RevokedAt *time.Time `json:"revoked_at,omitempty" example:"2025-09-02T08:00:00Z"`
+
+	// Timestamp When The Key Was Issued.
+	CreatedAt time.Time `json:"created_at" example:"2025-09-01T12:00:00Z"`
+}
+
+// Before Create Ensures UUID Is Set Automatically :
+func (apiKey *APIKey) BeforeCreate(tx *gorm.DB) (err error) {
+
+	if apiKey.ID == uuid.Nil {
+
+		apiKey.ID = uuid.New()
+	}
+
+	return nil
+}
+
+// Issued API Key Carries The Plain Key, Shown Exactly Once :
+type IssuedAPIKey struct {
+	*APIKey
+
+	// The Full Key; Store It Now, It Cannot Be Retrieved Again.
+	Key string `json:"key" example:"bt_1a2b3c4d_Zm9vYmFyYmF6cXV4..."`
+}
+
+// Create API Key Req Represents The Payload For Issuing A Key :
+type CreateAPIKeyReq struct {
+	Name        string   `json:"name" binding:"required" example:"nightly-batch"`
+	Permissions []string `json:"permissions" binding:"required,min=1" example:"users:read,users:import"`
+	AllowedIPs  []string `json:"allowed_ips,omitempty" example:"10.0.0.0/8,192.0.2.10"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import "github.com/golang-jwt/jwt/v5"
+
+// Claims Are The Verified Contents Of A Bearer Token.
+// The Subject Identifies The Caller And Is Recorded As The Audit Actor.
+type Claims struct {
+	jwt.RegisteredClaims
+
+	// Display Email Of The Caller ( Optional ).
+	Email string `json:"email,omitempty"`
+
+	// Roles Granted To The Caller ( e.g., "viewer", "admin" ).
+	Roles []string `json:"roles,omitempty"`
+
+	// Login Session Of Tokens Issued By This API ( Checked For Revocation ).
+	SessionID string `json:"sid,omitempty"`
+
+	// Authentication Methods ( RFC 8176 ); "mfa" Or "otp" Satisfies Admin-Only Routes.
+	AMR []string `json:"amr,omitempty"`
+
+	// Tenant The Caller Belongs To; Requests Naming Another Tenant Are Refused.
+	TenantID string `json:"tenant_id,omitempty"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"backend-task/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+// Credential Holds A User's Password Hash And Lockout State ( Never Serialized ) :
+type Credential struct {
+
+	// Owning User ( One Credential Per User ).
+	UserID uuid.UUID `gorm:"type:uuid;primaryKey"`
+
+	// PHC Formatted argon2id Hash.
+	PasswordHash string `gorm:"not null;size:255"`
+
+	// Roles Put Into Issued Tokens ( Empty Means "member" ).
+	Roles utils.StringList `gorm:"type:text"`
+
+	// Consecutive Failed Logins Since The Last Success.
+	FailedAttempts int `gorm:"not null;default:0"`
+
+	// Logins Are Refused Until This Time.
+	LockedUntil *time.Time
+
+	PasswordChangedAt time.Time `gorm:"not null"`
+	UpdatedAt         time.Time
+}
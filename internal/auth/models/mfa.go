@@ -0,0 +1,94 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MFA Factor Is A User's TOTP Secret ( RFC 6238 ). It Only Counts Once Activated :
+type MFAFactor struct {
+	UserID uuid.UUID `gorm:"type:uuid;primaryKey"`
+
+	// Base32 Shared Secret ( Never Serialized ).
+	Secret string `gorm:"not null;size:64"`
+
+	Enabled bool `gorm:"not null;default:false"`
+
+	// Time Step Of The Last Accepted Code; Older Or Equal Steps Are Replays.
+	LastUsedStep int64 `gorm:"not null;default:0"`
+
+	ActivatedAt *time.Time
+	CreatedAt   time.Time
+}
+
+// Recovery Code Is A One-Time Fallback For A Lost Authenticator. Only Its Hash Is Stored :
+type RecoveryCode struct {
+	ID       uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	UserID   uuid.UUID  `gorm:"type:uuid;not null;index"`
+	CodeHash string     `gorm:"not null;uniqueIndex;size:64"`
+	UsedAt   *time.Time // Each Code Works Once.
+}
+
+// Before Create Ensures UUID Is Set Automatically :
+func (recoveryCode *RecoveryCode) BeforeCreate(tx *gorm.DB) (err error) {
+
+	if recoveryCode.ID == uuid.Nil {
+
+		recoveryCode.ID = uuid.New()
+	}
+
+	return nil
+}
+
+// MFA Challenge Links A Successful Password Check To The Second Factor :
+type MFAChallenge struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	TenantID  string    `gorm:"not null;default:default;size:64"` // Tenant The Password Was Checked In.
+	TokenHash string    `gorm:"not null;uniqueIndex;size:64"`
+	ClientIP  string    `gorm:"size:64"`
+	Attempts  int       `gorm:"not null;default:0"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// Before Create Ensures UUID Is Set Automatically :
+func (challenge *MFAChallenge) BeforeCreate(tx *gorm.DB) (err error) {
+
+	if challenge.ID == uuid.Nil {
+
+		challenge.ID = uuid.New()
+	}
+
+	return nil
+}
+
+// MFA Enrollment Is Returned When A TOTP Secret Is Generated.
+//
+// @Description Scan The otpauth URI ( Or Type The Secret ) Into An Authenticator App, Then Activate With A Code.
+type MFAEnrollment struct {
+	Secret     string `json:"secret" example:"JBSWY3DPEHPK3PXP"`
+	OTPAuthURI string `json:"otpauth_uri" example:"otpauth://totp/backend-task:john@example.com?secret=JBSWY3DPEHPK3PXP&issuer=backend-task"`
+}
+
+// Recovery Codes Are Shown Exactly Once, On Activation.
+//
+// @Description One-Time Recovery Codes.
+type RecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes" example:"abcd-efgh,ijkl-mnop"`
+}
+
+// MFA Code Req Carries A TOTP Code Or A Recovery Code :
+type MFACodeReq struct {
+	Code         string `json:"code,omitempty" example:"123456"`
+	RecoveryCode string `json:"recovery_code,omitempty" example:"abcd-efgh"`
+}
+
+// MFA Verify Req Completes A Login Challenge :
+type MFAVerifyReq struct {
+	MFAToken string `json:"mfa_token" binding:"required" example:"Q2hhbGxlbmdl..."`
+	MFACodeReq
+}
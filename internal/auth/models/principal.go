@@ -0,0 +1,40 @@
+package models
+
+import "context"
+
+// Principal Is The Authenticated Caller With Its Roles Resolved Into Permissions :
+type Principal struct {
+	Subject     string
+	Email       string
+	Roles       []string
+	Permissions map[string]bool
+	SessionID   string // Set For Tokens Issued By Password Login.
+	MFA         bool   // A Second Factor Was Presented ( Never For API Keys ).
+	TenantID    string // Tenant The Caller Is Bound To ( Empty: The Default Tenant ).
+}
+
+// Can Reports Whether The Principal Holds The Permission :
+func (principal *Principal) Can(permission string) bool {
+
+	return principal != nil && principal.Permissions[permission]
+}
+
+type principalKey struct{}
+
+// WithPrincipal Returns A Copy Of The Context Carrying The Caller :
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext Returns The Caller Stored In The Context ( nil When Unauthenticated ) :
+func PrincipalFromContext(ctx context.Context) *Principal {
+
+	if ctx == nil {
+
+		return nil
+	}
+
+	principal, _ := ctx.Value(principalKey{}).(*Principal)
+	return principal
+}
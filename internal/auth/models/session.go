@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Session Is One Login; Its Refresh Tokens Form A Rotation Family :
+type Session struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;index"`
+	TenantID  string     `gorm:"not null;default:default;size:64"` // Tenant Of The User; Refreshes Run In It Whatever The Client Sends.
+	ClientIP  string     `gorm:"size:64"`
+	MFA       bool       `gorm:"not null;default:false"` // A Second Factor Was Verified ( At Login Or By Step-Up ).
+	RevokedAt *time.Time // Set On Logout, Password Change, Role Change Or Refresh-Token Reuse.
+	CreatedAt time.Time
+}
+
+// Before Create Ensures UUID Is Set Automatically :
+func (session *Session) BeforeCreate(tx *gorm.DB) (err error) {
+
+	if session.ID == uuid.Nil {
+
+		session.ID = uuid.New()
+	}
+
+	return nil
+}
+
+// Refresh Token Is A Single-Use Token Of A Session. Only Its Hash Is Stored :
+type RefreshToken struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	SessionID uuid.UUID  `gorm:"type:uuid;not null;index"`
+	TokenHash string     `gorm:"not null;uniqueIndex;size:64"`
+	UsedAt    *time.Time // Set When Exchanged; Presenting It Again Is Reuse.
+	ExpiresAt time.Time  `gorm:"not null"`
+	CreatedAt time.Time
+}
+
+// Before Create Ensures UUID Is Set Automatically :
+func (refreshToken *RefreshToken) BeforeCreate(tx *gorm.DB) (err error) {
+
+	if refreshToken.ID == uuid.Nil {
+
+		refreshToken.ID = uuid.New()
+	}
+
+	return nil
+}
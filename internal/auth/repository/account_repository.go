@@ -0,0 +1,252 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"backend-task/internal/auth/models"
+	"backend-task/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Account Repository Interface :
+type AccountRepository interface {
+	GetCredential(context context.Context, userID uuid.UUID) (*models.Credential, error) // utils.ErrAccountNotFound When Missing.
+	SaveCredential(context context.Context, credential *models.Credential) error
+	SaveCredentialTx(gormDB *gorm.DB, credential *models.Credential) error
+
+	CreateSession(context context.Context, session *models.Session, token *models.RefreshToken) error
+	GetSession(context context.Context, id uuid.UUID) (*models.Session, error)
+	RevokeSession(context context.Context, id uuid.UUID, at time.Time) error
+	RevokeUserSessions(context context.Context, userID uuid.UUID, at time.Time) error
+	RevokeUserSessionsTx(gormDB *gorm.DB, userID uuid.UUID, at time.Time) error
+
+	GetRefreshToken(context context.Context, tokenHash string) (*models.RefreshToken, error)
+	RotateRefreshToken(context context.Context, used *models.RefreshToken, next *models.RefreshToken, at time.Time) (bool, error)
+
+	MarkSessionMFA(context context.Context, id uuid.UUID) error
+	GetMFAFactor(context context.Context, userID uuid.UUID) (*models.MFAFactor, error)
+	SaveMFAFactor(context context.Context, factor *models.MFAFactor) error
+	UseTOTPStep(context context.Context, userID uuid.UUID, step int64) (bool, error)
+	ReplaceRecoveryCodes(context context.Context, userID uuid.UUID, codes []*models.RecoveryCode) error
+	UseRecoveryCode(context context.Context, userID uuid.UUID, codeHash string, at time.Time) (bool, error)
+
+	CreateMFAChallenge(context context.Context, challenge *models.MFAChallenge) error
+	GetMFAChallenge(context context.Context, tokenHash string) (*models.MFAChallenge, error)
+	RecordMFAChallengeFailure(context context.Context, id uuid.UUID) error
+	UseMFAChallenge(context context.Context, id uuid.UUID, at time.Time) (bool, error)
+}
+
+// AccountRepositoryDB Implementation :
+type AccountRepositoryDB struct {
+	gormDB *gorm.DB
+}
+
+// Constructor :
+func NewAccountRepository(db *gorm.DB) AccountRepository {
+
+	return &AccountRepositoryDB{gormDB: db}
+}
+
+// ---------------- Credentials ----------------
+
+func (accountRepositoryDB *AccountRepositoryDB) GetCredential(context context.Context, userID uuid.UUID) (*models.Credential, error) {
+
+	var credential models.Credential
+	if err := accountRepositoryDB.gormDB.WithContext(context).First(&credential, "user_id = ?", userID).Error; err != nil {
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+
+			return nil, utils.ErrAccountNotFound
+		}
+
+		return nil, fmt.Errorf("failed to get credential: %w", err)
+	}
+
+	return &credential, nil
+}
+
+func (accountRepositoryDB *AccountRepositoryDB) SaveCredential(context context.Context, credential *models.Credential) error {
+
+	return accountRepositoryDB.gormDB.WithContext(context).Save(credential).Error
+}
+
+// SaveCredentialTx Saves Within The Caller's Transaction ( e.g., Together With A New User ) :
+func (accountRepositoryDB *AccountRepositoryDB) SaveCredentialTx(gormDB *gorm.DB, credential *models.Credential) error {
+
+	return gormDB.Save(credential).Error
+}
+
+// ---------------- Sessions ----------------
+
+// CreateSession Stores A New Session With Its First Refresh Token :
+func (accountRepositoryDB *AccountRepositoryDB) CreateSession(context context.Context, session *models.Session, token *models.RefreshToken) error {
+
+	return accountRepositoryDB.gormDB.WithContext(context).Transaction(func(tx *gorm.DB) error {
+
+		if err := tx.Create(session).Error; err != nil {
+
+			return err
+		}
+
+		token.SessionID = session.ID
+		return tx.Create(token).Error
+	})
+}
+
+func (accountRepositoryDB *AccountRepositoryDB) GetSession(context context.Context, id uuid.UUID) (*models.Session, error) {
+
+	var session models.Session
+	if err := accountRepositoryDB.gormDB.WithContext(context).First(&session, "id = ?", id).Error; err != nil {
+
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (accountRepositoryDB *AccountRepositoryDB) RevokeSession(context context.Context, id uuid.UUID, at time.Time) error {
+
+	return accountRepositoryDB.gormDB.WithContext(context).Model(&models.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).Update("revoked_at", at).Error
+}
+
+func (accountRepositoryDB *AccountRepositoryDB) RevokeUserSessions(context context.Context, userID uuid.UUID, at time.Time) error {
+
+	return accountRepositoryDB.RevokeUserSessionsTx(accountRepositoryDB.gormDB.WithContext(context), userID, at)
+}
+
+// RevokeUserSessionsTx Revokes Within The Caller's Transaction ( e.g., Together With A Suspension ) :
+func (accountRepositoryDB *AccountRepositoryDB) RevokeUserSessionsTx(gormDB *gorm.DB, userID uuid.UUID, at time.Time) error {
+
+	return gormDB.Model(&models.Session{}).Where("user_id = ? AND revoked_at IS NULL", userID).Update("revoked_at", at).Error
+}
+
+// ---------------- Refresh Tokens ----------------
+
+func (accountRepositoryDB *AccountRepositoryDB) GetRefreshToken(context context.Context, tokenHash string) (*models.RefreshToken, error) {
+
+	var token models.RefreshToken
+	if err := accountRepositoryDB.gormDB.WithContext(context).First(&token, "token_hash = ?", tokenHash).Error; err != nil {
+
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// RotateRefreshToken Marks The Presented Token Used And Stores Its Successor Atomically.
+// It Returns False When The Token Was Already Used ( Reuse, Or A Concurrent Refresh Won ).
+func (accountRepositoryDB *AccountRepositoryDB) RotateRefreshToken(context context.Context, used *models.RefreshToken, next *models.RefreshToken, at time.Time) (bool, error) {
+
+	rotated := false
+	err := accountRepositoryDB.gormDB.WithContext(context).Transaction(func(tx *gorm.DB) error {
+
+		result := tx.Model(&models.RefreshToken{}).Where("id = ? AND used_at IS NULL", used.ID).Update("used_at", at)
+		if result.Error != nil || result.RowsAffected == 0 {
+
+			return result.Error
+		}
+
+		next.SessionID = used.SessionID
+		if err := tx.Create(next).Error; err != nil {
+
+			return err
+		}
+
+		rotated = true
+		return nil
+	})
+
+	return rotated, err
+}
+
+// ---------------- Multi-Factor Authentication ----------------
+
+func (accountRepositoryDB *AccountRepositoryDB) MarkSessionMFA(context context.Context, id uuid.UUID) error {
+
+	return accountRepositoryDB.gormDB.WithContext(context).Model(&models.Session{}).Where("id = ?", id).Update("mfa", true).Error
+}
+
+func (accountRepositoryDB *AccountRepositoryDB) GetMFAFactor(context context.Context, userID uuid.UUID) (*models.MFAFactor, error) {
+
+	var factor models.MFAFactor
+	if err := accountRepositoryDB.gormDB.WithContext(context).First(&factor, "user_id = ?", userID).Error; err != nil {
+
+		return nil, err
+	}
+
+	return &factor, nil
+}
+
+func (accountRepositoryDB *AccountRepositoryDB) SaveMFAFactor(context context.Context, factor *models.MFAFactor) error {
+
+	return accountRepositoryDB.gormDB.WithContext(context).Save(factor).Error
+}
+
+// UseTOTPStep Records The Step Of An Accepted Code; It Returns False For A Replay ( Same Or Older Step ) :
+func (accountRepositoryDB *AccountRepositoryDB) UseTOTPStep(context context.Context, userID uuid.UUID, step int64) (bool, error) {
+
+	result := accountRepositoryDB.gormDB.WithContext(context).Model(&models.MFAFactor{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).Update("last_used_step", step)
+
+	return result.RowsAffected == 1, result.Error
+}
+
+// ReplaceRecoveryCodes Swaps The Whole Set, Invalidating Earlier Codes :
+func (accountRepositoryDB *AccountRepositoryDB) ReplaceRecoveryCodes(context context.Context, userID uuid.UUID, codes []*models.RecoveryCode) error {
+
+	return accountRepositoryDB.gormDB.WithContext(context).Transaction(func(tx *gorm.DB) error {
+
+		if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+
+			return err
+		}
+
+		return tx.Create(codes).Error
+	})
+}
+
+// UseRecoveryCode Consumes An Unused Code; It Returns False When No Such Code Is Left :
+func (accountRepositoryDB *AccountRepositoryDB) UseRecoveryCode(context context.Context, userID uuid.UUID, codeHash string, at time.Time) (bool, error) {
+
+	result := accountRepositoryDB.gormDB.WithContext(context).Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).Update("used_at", at)
+
+	return result.RowsAffected == 1, result.Error
+}
+
+func (accountRepositoryDB *AccountRepositoryDB) CreateMFAChallenge(context context.Context, challenge *models.MFAChallenge) error {
+
+	return accountRepositoryDB.gormDB.WithContext(context).Create(challenge).Error
+}
+
+func (accountRepositoryDB *AccountRepositoryDB) GetMFAChallenge(context context.Context, tokenHash string) (*models.MFAChallenge, error) {
+
+	var challenge models.MFAChallenge
+	if err := accountRepositoryDB.gormDB.WithContext(context).First(&challenge, "token_hash = ?", tokenHash).Error; err != nil {
+
+		return nil, err
+	}
+
+	return &challenge, nil
+}
+
+func (accountRepositoryDB *AccountRepositoryDB) RecordMFAChallengeFailure(context context.Context, id uuid.UUID) error {
+
+	return accountRepositoryDB.gormDB.WithContext(context).Model(&models.MFAChallenge{}).Where("id = ?", id).
+		Update("attempts", gorm.Expr("attempts + 1")).Error
+}
+
+// UseMFAChallenge Consumes The Challenge; It Returns False When It Was Already Used :
+func (accountRepositoryDB *AccountRepositoryDB) UseMFAChallenge(context context.Context, id uuid.UUID, at time.Time) (bool, error) {
+
+	result := accountRepositoryDB.gormDB.WithContext(context).Model(&models.MFAChallenge{}).
+		Where("id = ? AND used_at IS NULL", id).Update("used_at", at)
+
+	return result.RowsAffected == 1, result.Error
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"backend-task/internal/auth/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// API Key Repository Interface :
+type APIKeyRepository interface {
+	CreateAPIKeyTx(gormDB *gorm.DB, apiKey *models.APIKey) error
+	GetAPIKey(context context.Context, id uuid.UUID) (*models.APIKey, error)
+	GetAPIKeyByPrefix(context context.Context, prefix string) (*models.APIKey, error)
+	ListAPIKeys(context context.Context, tenantID string) ([]*models.APIKey, error)
+	UpdateAPIKeyTx(gormDB *gorm.DB, apiKey *models.APIKey) error
+	RecordUsage(context context.Context, id uuid.UUID, usedAt time.Time) error
+}
+
+// APIKeyRepositoryDB Implementation :
+type APIKeyRepositoryDB struct {
+	gormDB *gorm.DB
+}
+
+// Constructor :
+func NewAPIKeyRepository(db *gorm.DB) APIKeyRepository {
+
+	return &APIKeyRepositoryDB{gormDB: db}
+}
+
+// CreateAPIKeyTx Stores The Key Within The Caller's Transaction ( Together With Its Audit Entry ) :
+func (apiKeyRepositoryDB *APIKeyRepositoryDB) CreateAPIKeyTx(gormDB *gorm.DB, apiKey *models.APIKey) error {
+
+	return gormDB.Create(apiKey).Error
+}
+
+func (apiKeyRepositoryDB *APIKeyRepositoryDB) GetAPIKey(context context.Context, id uuid.UUID) (*models.APIKey, error) {
+
+	var apiKey models.APIKey
+	if err := apiKeyRepositoryDB.gormDB.WithContext(context).First(&apiKey, "id = ?", id).Error; err != nil {
+
+		return nil, err
+	}
+
+	return &apiKey, nil
+}
+
+func (apiKeyRepositoryDB *APIKeyRepositoryDB) GetAPIKeyByPrefix(context context.Context, prefix string) (*models.APIKey, error) {
+
+	var apiKey models.APIKey
+	if err := apiKeyRepositoryDB.gormDB.WithContext(context).First(&apiKey, "prefix = ?", prefix).Error; err != nil {
+
+		return nil, err
+	}
+
+	return &apiKey, nil
+}
+
+// ListAPIKeys Returns Every Key Of The Tenant ( Including Revoked Ones ), Newest First :
+func (apiKeyRepositoryDB *APIKeyRepositoryDB) ListAPIKeys(context context.Context, tenantID string) ([]*models.APIKey, error) {
+
+	var apiKeys []*models.APIKey
+	if err := apiKeyRepositoryDB.gormDB.WithContext(context).Where("tenant_id = ?", tenantID).Order("created_at DESC").Find(&apiKeys).Error; err != nil {
+
+		return nil, err
+	}
+
+	return apiKeys, nil
+}
+
+func (apiKeyRepositoryDB *APIKeyRepositoryDB) UpdateAPIKeyTx(gormDB *gorm.DB, apiKey *models.APIKey) error {
+
+	return gormDB.Save(apiKey).Error
+}
+
+// RecordUsage Bumps The Usage Counter Atomically ( Concurrent Requests Never Lose A Count ) :
+func (apiKeyRepositoryDB *APIKeyRepositoryDB) RecordUsage(context context.Context, id uuid.UUID, usedAt time.Time) error {
+
+	return apiKeyRepositoryDB.gormDB.WithContext(context).Model(&models.APIKey{}).Where("id = ?", id).
+		Updates(map[string]any{"usage_count": gorm.Expr("usage_count + 1"), "last_used_at": usedAt}).Error
+}
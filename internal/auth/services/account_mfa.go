@@ -0,0 +1,449 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+
+	"backend-task/internal/auth/models"
+	"backend-task/internal/constants"
+	"backend-task/internal/tenant"
+	"backend-task/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+	"gorm.io/gorm"
+)
+
+var totpOptions = totp.ValidateOpts{Period: constants.TOTPPeriodSeconds, Digits: otp.DigitsSix, Algorithm: otp.AlgorithmSHA1}
+
+// ---------------- Enrolment ----------------
+
+// EnrollMFA Generates A New ( Inactive ) TOTP Secret; Enrolling Again Before Activation Replaces It :
+func (accountService *AccountService) EnrollMFA(context context.Context, userID string) (*models.MFAEnrollment, error) {
+
+	uid, err := accountService.accountID(context, userID)
+	if err != nil {
+
+		return nil, err
+	}
+
+	factor, err := accountService.accounts.GetMFAFactor(context, uid)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+
+		return nil, err
+	}
+
+	if factor != nil && factor.Enabled {
+
+		return nil, utils.NewConflict(utils.ErrMFAAlreadyEnabled)
+	}
+
+	user, err := accountService.users.GetUserByID(context, uid)
+	if err != nil {
+
+		return nil, err
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      accountService.config.MFAIssuer,
+		AccountName: user.Email,
+		Period:      constants.TOTPPeriodSeconds,
+		Digits:      otp.DigitsSix,
+		Algorithm:   otp.AlgorithmSHA1,
+	})
+	if err != nil {
+
+		return nil, err
+	}
+
+	factor = &models.MFAFactor{UserID: uid, Secret: key.Secret()}
+	if err := accountService.accounts.SaveMFAFactor(context, factor); err != nil {
+
+		return nil, err
+	}
+
+	return &models.MFAEnrollment{Secret: key.Secret(), OTPAuthURI: key.URL()}, nil
+}
+
+// ActivateMFA Confirms The Authenticator With A Code And Returns The One-Time Recovery Codes :
+func (accountService *AccountService) ActivateMFA(context context.Context, userID, code string) (*models.RecoveryCodes, error) {
+
+	uid, err := accountService.accountID(context, userID)
+	if err != nil {
+
+		return nil, err
+	}
+
+	factor, err := accountService.accounts.GetMFAFactor(context, uid)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+
+		return nil, utils.NewBadRequest(utils.ErrMFANotEnrolled)
+	}
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	if factor.Enabled {
+
+		return nil, utils.NewConflict(utils.ErrMFAAlreadyEnabled)
+	}
+
+	now := accountService.config.Now().UTC()
+	step, ok := matchTOTP(factor.Secret, code, now)
+	if !ok {
+
+		return nil, utils.NewBadRequest(utils.ErrInvalidMFACode)
+	}
+
+	codes, records, err := newRecoveryCodes(uid)
+	if err != nil {
+
+		return nil, err
+	}
+
+	if err := accountService.accounts.ReplaceRecoveryCodes(context, uid, records); err != nil {
+
+		return nil, err
+	}
+
+	factor.Enabled = true
+	factor.LastUsedStep = step
+	factor.ActivatedAt = &now
+	if err := accountService.accounts.SaveMFAFactor(context, factor); err != nil {
+
+		return nil, err
+	}
+
+	return &models.RecoveryCodes{RecoveryCodes: codes}, nil
+}
+
+// ---------------- Login Challenge & Step-Up ----------------
+
+// VerifyMFA Completes A Login Challenge With A TOTP Or Recovery Code.
+// Failures Count Towards The Account Lockout, So Codes Cannot Be Brute Forced Across Challenges.
+func (accountService *AccountService) VerifyMFA(context context.Context, req models.MFAVerifyReq) (*models.TokenPair, error) {
+
+	if accountService.issuer == nil {
+
+		return nil, utils.NewInternalError(utils.ErrTokenSigningKeyMissing)
+	}
+
+	now := accountService.config.Now().UTC()
+
+	challenge, err := accountService.accounts.GetMFAChallenge(context, hashRefreshToken(req.MFAToken))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+
+		return nil, utils.NewUnauthorized(utils.ErrInvalidMFAChallenge)
+	}
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	if challenge.UsedAt != nil || !now.Before(challenge.ExpiresAt) || challenge.Attempts >= constants.MFAChallengeMaxAttempts {
+
+		return nil, utils.NewUnauthorized(utils.ErrInvalidMFAChallenge)
+	}
+
+	// The Session Opens In The Tenant The Password Was Checked In :
+	context = tenant.WithID(context, challenge.TenantID)
+
+	credential, err := accountService.accounts.GetCredential(context, challenge.UserID)
+	if err != nil {
+
+		return nil, err
+	}
+
+	if credential.LockedUntil != nil && now.Before(*credential.LockedUntil) {
+
+		return nil, utils.NewUnauthorized(utils.ErrInvalidMFAChallenge)
+	}
+
+	verified, err := accountService.verifySecondFactor(context, challenge.UserID, req.MFACodeReq, now)
+	if err != nil {
+
+		return nil, err
+	}
+
+	if !verified {
+
+		if err := accountService.accounts.RecordMFAChallengeFailure(context, challenge.ID); err != nil {
+
+			return nil, err
+		}
+
+		if err := accountService.recordFailedLogin(context, credential, now); err != nil {
+
+			return nil, err
+		}
+
+		return nil, utils.NewUnauthorized(utils.ErrInvalidMFACode)
+	}
+
+	used, err := accountService.accounts.UseMFAChallenge(context, challenge.ID, now)
+	if err != nil {
+
+		return nil, err
+	}
+
+	if !used {
+
+		return nil, utils.NewUnauthorized(utils.ErrInvalidMFAChallenge)
+	}
+
+	if credential.FailedAttempts > 0 {
+
+		credential.FailedAttempts = 0
+		credential.LockedUntil = nil
+		if err := accountService.accounts.SaveCredential(context, credential); err != nil {
+
+			return nil, err
+		}
+	}
+
+	user, err := accountService.users.GetUserByID(context, challenge.UserID)
+	if err != nil {
+
+		return nil, err
+	}
+
+	return accountService.startSession(context, user, credential, true, now)
+}
+
+// StepUp Upgrades The Caller's Session With A Second Factor And Returns A Fresh Access Token.
+// Failures Count Towards The Account Lockout Like Those Of A Login Challenge.
+func (accountService *AccountService) StepUp(context context.Context, userID, sessionID string, req models.MFACodeReq) (*models.TokenPair, error) {
+
+	if accountService.issuer == nil {
+
+		return nil, utils.NewInternalError(utils.ErrTokenSigningKeyMissing)
+	}
+
+	uid, err := accountService.accountID(context, userID)
+	if err != nil {
+
+		return nil, err
+	}
+
+	sid, err := uuid.Parse(sessionID)
+	if err != nil {
+
+		return nil, utils.NewUnauthorized(utils.ErrSessionRevoked)
+	}
+
+	session, err := accountService.accounts.GetSession(context, sid)
+	if err != nil || session.UserID != uid || session.RevokedAt != nil {
+
+		return nil, utils.NewUnauthorized(utils.ErrSessionRevoked)
+	}
+
+	credential, err := accountService.accounts.GetCredential(context, uid)
+	if err != nil {
+
+		return nil, err
+	}
+
+	now := accountService.config.Now().UTC()
+	if credential.LockedUntil != nil && now.Before(*credential.LockedUntil) {
+
+		return nil, utils.NewBadRequest(utils.ErrInvalidMFACode)
+	}
+
+	verified, err := accountService.verifySecondFactor(context, uid, req, now)
+	if err != nil {
+
+		return nil, err
+	}
+
+	if !verified {
+
+		if err := accountService.recordFailedLogin(context, credential, now); err != nil {
+
+			return nil, err
+		}
+
+		return nil, utils.NewBadRequest(utils.ErrInvalidMFACode)
+	}
+
+	if credential.FailedAttempts > 0 {
+
+		credential.FailedAttempts = 0
+		credential.LockedUntil = nil
+		if err := accountService.accounts.SaveCredential(context, credential); err != nil {
+
+			return nil, err
+		}
+	}
+
+	if err := accountService.accounts.MarkSessionMFA(context, sid); err != nil {
+
+		return nil, err
+	}
+
+	session.MFA = true
+
+	user, err := accountService.users.GetUserByID(context, uid)
+	if err != nil {
+
+		return nil, err
+	}
+
+	// The Refresh Token Stays The Same; Later Refreshes Keep The MFA Claim :
+	return accountService.tokenPair(user, credential, session, "")
+}
+
+// ---------------- Helpers ----------------
+
+func (accountService *AccountService) enabledMFAFactor(context context.Context, userID uuid.UUID) (*models.MFAFactor, error) {
+
+	factor, err := accountService.accounts.GetMFAFactor(context, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && !factor.Enabled) {
+
+		return nil, nil
+	}
+
+	return factor, err
+}
+
+func (accountService *AccountService) newMFAChallenge(context context.Context, userID uuid.UUID, now time.Time) (*models.TokenPair, error) {
+
+	token, _, err := accountService.newRefreshToken(now)
+	if err != nil {
+
+		return nil, err
+	}
+
+	challenge := &models.MFAChallenge{
+		UserID:    userID,
+		TenantID:  tenant.ID(context),
+		TokenHash: hashRefreshToken(token),
+		ClientIP:  utils.RequestMetaFromContext(context).ClientIP,
+		ExpiresAt: now.Add(constants.MFAChallengeTTL * time.Second),
+	}
+
+	if err := accountService.accounts.CreateMFAChallenge(context, challenge); err != nil {
+
+		return nil, err
+	}
+
+	return &models.TokenPair{MFARequired: true, MFAToken: token, ExpiresIn: constants.MFAChallengeTTL}, nil
+}
+
+// verifySecondFactor Accepts A TOTP Code ( Each Time Step Only Once ) Or An Unused Recovery Code :
+func (accountService *AccountService) verifySecondFactor(context context.Context, userID uuid.UUID, req models.MFACodeReq, now time.Time) (bool, error) {
+
+	switch {
+	case req.Code != "":
+		factor, err := accountService.enabledMFAFactor(context, userID)
+		if err != nil || factor == nil {
+
+			return false, err
+		}
+
+		step, ok := matchTOTP(factor.Secret, req.Code, now)
+		if !ok {
+
+			return false, nil
+		}
+
+		return accountService.accounts.UseTOTPStep(context, userID, step)
+
+	case req.RecoveryCode != "":
+		return accountService.accounts.UseRecoveryCode(context, userID, hashRefreshToken(normalizeRecoveryCode(req.RecoveryCode)), now)
+
+	default:
+		return false, utils.NewBadRequest(utils.ErrMFACodeRequired)
+	}
+}
+
+// accountID Parses The Caller's ID And Ensures It Has A Password Account :
+func (accountService *AccountService) accountID(context context.Context, userID string) (uuid.UUID, error) {
+
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+
+		return uuid.Nil, utils.NewForbidden(utils.ErrNotAnAccount)
+	}
+
+	if _, err := accountService.accounts.GetCredential(context, uid); err != nil {
+
+		if errors.Is(err, utils.ErrAccountNotFound) {
+
+			return uuid.Nil, utils.NewForbidden(utils.ErrNotAnAccount)
+		}
+
+		return uuid.Nil, err
+	}
+
+	return uid, nil
+}
+
+// matchTOTP Checks The Code Against The Current Step And TOTPSkewSteps Either Side ( RFC 6238 Drift Window ),
+// Returning The Matching Step :
+func matchTOTP(secret, code string, now time.Time) (int64, bool) {
+
+	code = strings.TrimSpace(code)
+	if len(code) != constants.TOTPDigits {
+
+		return 0, false
+	}
+
+	current := now.Unix() / constants.TOTPPeriodSeconds
+	for offset := int64(-constants.TOTPSkewSteps); offset <= constants.TOTPSkewSteps; offset++ {
+
+		step := current + offset
+		expected, err := totp.GenerateCodeCustom(secret, time.Unix(step*constants.TOTPPeriodSeconds, 0), totpOptions)
+		if err != nil {
+
+			return 0, false
+		}
+
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// newRecoveryCodes Returns The Plain Codes ( Shown Once ) And Their Hashed Records :
+func newRecoveryCodes(userID uuid.UUID) ([]string, []*models.RecoveryCode, error) {
+
+	codes := make([]string, 0, constants.RecoveryCodeCount)
+	records := make([]*models.RecoveryCode, 0, constants.RecoveryCodeCount)
+
+	for i := 0; i < constants.RecoveryCodeCount; i++ {
+
+		random := make([]byte, constants.RecoveryCodeBytes)
+		if _, err := rand.Read(random); err != nil {
+
+			return nil, nil, err
+		}
+
+		encoded := strings.ToLower(recoveryCodeEncoding.EncodeToString(random))
+		code := encoded[:4] + "-" + encoded[4:]
+
+		codes = append(codes, code)
+		records = append(records, &models.RecoveryCode{UserID: userID, CodeHash: hashRefreshToken(normalizeRecoveryCode(code))})
+	}
+
+	return codes, records, nil
+}
+
+// normalizeRecoveryCode Ignores Case, Spaces And Dashes :
+func normalizeRecoveryCode(code string) string {
+
+	return strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(strings.TrimSpace(code)))
+}
@@ -0,0 +1,582 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	auditServiceInterface "backend-task/internal/audit/services/interface"
+	"backend-task/internal/auth/models"
+	"backend-task/internal/auth/repository"
+	accountServiceInterface "backend-task/internal/auth/services/interface"
+	"backend-task/internal/constants"
+	"backend-task/internal/tenant"
+	userModels "backend-task/internal/user/models"
+	userRepository "backend-task/internal/user/repository"
+	userServices "backend-task/internal/user/services"
+	userServiceInterface "backend-task/internal/user/services/interface"
+	"backend-task/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Account Config Tunes Sessions And Lockout :
+type AccountConfig struct {
+	RefreshTTL       time.Duration
+	LockoutThreshold int           // Failed Logins Before Locking.
+	LockoutBaseDelay time.Duration // First Lock, Doubled On Every Further Failure.
+	LockoutMaxDelay  time.Duration
+	MFAIssuer        string           // Issuer Shown In Authenticator Apps.
+	Now              func() time.Time // Injectable Clock.
+}
+
+type AccountService struct {
+	db       *gorm.DB
+	accounts repository.AccountRepository
+	users    userRepository.UserRepository
+	creator  userServiceInterface.UserService
+	audit    auditServiceInterface.AuditService
+	issuer   *TokenIssuer
+	config   AccountConfig
+}
+
+func NewAccountService(db *gorm.DB, accounts repository.AccountRepository, users userRepository.UserRepository, creator userServiceInterface.UserService, audit auditServiceInterface.AuditService, issuer *TokenIssuer, config AccountConfig) accountServiceInterface.AccountService {
+
+	if config.Now == nil {
+
+		config.Now = time.Now
+	}
+
+	if config.LockoutThreshold <= 0 {
+
+		config.LockoutThreshold = constants.DefaultLockoutThreshold
+	}
+
+	if config.MFAIssuer == "" {
+
+		config.MFAIssuer = constants.DefaultMFAIssuer
+	}
+
+	return &AccountService{db: db, accounts: accounts, users: users, creator: creator, audit: audit, issuer: issuer, config: config}
+}
+
+// ---------------- Registration ----------------
+
+// Register Answers A Taken Email Like A New Registration ( nil User, nil Error ) So The Public Endpoint
+// Cannot Probe For Accounts; The Owner Is Emailed Instead. The User And Its Password Are Saved Together.
+func (accountService *AccountService) Register(context context.Context, req models.RegisterReq) (*userModels.User, error) {
+
+	if err := validatePassword(req.Password); err != nil {
+
+		return nil, err
+	}
+
+	hash, err := HashPassword(req.Password)
+	if err != nil {
+
+		return nil, err
+	}
+
+	user, err := accountService.creator.CreateUserWithGuardianTx(context, req.Name, req.Email, req.DateOfBirth, req.Guardian, func(gormDB *gorm.DB, user *userModels.User) error {
+
+		credential := &models.Credential{UserID: user.ID, PasswordHash: hash, PasswordChangedAt: accountService.config.Now().UTC()}
+		return accountService.accounts.SaveCredentialTx(gormDB, credential)
+	})
+
+	if errors.Is(err, utils.ErrEmailAlreadyExists) {
+
+		accountService.creator.NotifyEmailTaken(context, req.Email)
+		return nil, nil
+	}
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// ---------------- Login ----------------
+
+// Login Never Reveals Whether The Email Exists: Unknown Emails, Users Without A Password,
+// Locked Accounts And Wrong Passwords All Return The Same Error After A Full Hash Computation.
+func (accountService *AccountService) Login(context context.Context, req models.LoginReq) (*models.TokenPair, error) {
+
+	if accountService.issuer == nil {
+
+		return nil, utils.NewInternalError(utils.ErrTokenSigningKeyMissing)
+	}
+
+	now := accountService.config.Now().UTC()
+
+	user, credential, err := accountService.findCredential(context, userServices.NormalizeEmail(req.Email))
+	if err != nil {
+
+		return nil, err
+	}
+
+	if credential == nil || (credential.LockedUntil != nil && now.Before(*credential.LockedUntil)) {
+
+		VerifyPassword(dummyPasswordHash(), req.Password)
+		return nil, utils.NewUnauthorized(utils.ErrInvalidCredentials)
+	}
+
+	matched, err := VerifyPassword(credential.PasswordHash, req.Password)
+	if err != nil {
+
+		return nil, err
+	}
+
+	if !matched {
+
+		if err := accountService.recordFailedLogin(context, credential, now); err != nil {
+
+			return nil, err
+		}
+
+		return nil, utils.NewUnauthorized(utils.ErrInvalidCredentials)
+	}
+
+	if credential.FailedAttempts > 0 || credential.LockedUntil != nil {
+
+		credential.FailedAttempts = 0
+		credential.LockedUntil = nil
+		if err := accountService.accounts.SaveCredential(context, credential); err != nil {
+
+			return nil, err
+		}
+	}
+
+	// Only Revealed Once The Password Matched :
+	if !canSignIn(user) {
+
+		return nil, utils.NewForbidden(utils.ErrUserNotActive)
+	}
+
+	// Accounts With An Authenticator Must Pass The Second Factor First :
+	factor, err := accountService.enabledMFAFactor(context, user.ID)
+	if err != nil {
+
+		return nil, err
+	}
+
+	if factor != nil {
+
+		return accountService.newMFAChallenge(context, user.ID, now)
+	}
+
+	return accountService.startSession(context, user, credential, false, now)
+}
+
+// startSession Opens A Session And Issues Its First Token Pair :
+func (accountService *AccountService) startSession(context context.Context, user *userModels.User, credential *models.Credential, mfa bool, now time.Time) (*models.TokenPair, error) {
+
+	refreshToken, token, err := accountService.newRefreshToken(now)
+	if err != nil {
+
+		return nil, err
+	}
+
+	session := &models.Session{UserID: user.ID, TenantID: user.TenantID, ClientIP: utils.RequestMetaFromContext(context).ClientIP, MFA: mfa}
+	if err := accountService.accounts.CreateSession(context, session, token); err != nil {
+
+		return nil, err
+	}
+
+	return accountService.tokenPair(user, credential, session, refreshToken)
+}
+
+// findCredential Returns A nil Credential ( Not An Error ) For Unknown Emails And Users Without A Password :
+func (accountService *AccountService) findCredential(context context.Context, email string) (*userModels.User, *models.Credential, error) {
+
+	user, err := accountService.users.GetUserByEmail(context, email)
+	if errors.Is(err, utils.ErrUserNotFound) {
+
+		return nil, nil, nil
+	}
+
+	if err != nil {
+
+		return nil, nil, err
+	}
+
+	credential, err := accountService.accounts.GetCredential(context, user.ID)
+	if errors.Is(err, utils.ErrAccountNotFound) {
+
+		return user, nil, nil
+	}
+
+	return user, credential, err
+}
+
+// recordFailedLogin Counts A Failed Password Or Second Factor, Locking The Account Past The Threshold :
+func (accountService *AccountService) recordFailedLogin(context context.Context, credential *models.Credential, now time.Time) error {
+
+	credential.FailedAttempts++
+	if credential.FailedAttempts >= accountService.config.LockoutThreshold {
+
+		lockedUntil := now.Add(accountService.lockoutDelay(credential.FailedAttempts))
+		credential.LockedUntil = &lockedUntil
+	}
+
+	return accountService.accounts.SaveCredential(context, credential)
+}
+
+// lockoutDelay Returns BaseDelay * 2^( failures-threshold ), Capped At MaxDelay :
+func (accountService *AccountService) lockoutDelay(failures int) time.Duration {
+
+	delay := accountService.config.LockoutBaseDelay
+	for i := accountService.config.LockoutThreshold; i < failures && delay < accountService.config.LockoutMaxDelay; i++ {
+
+		delay *= 2
+	}
+
+	if accountService.config.LockoutMaxDelay > 0 && delay > accountService.config.LockoutMaxDelay {
+
+		delay = accountService.config.LockoutMaxDelay
+	}
+
+	return delay
+}
+
+// ---------------- Refresh & Logout ----------------
+
+func (accountService *AccountService) Refresh(context context.Context, refreshToken string) (*models.TokenPair, error) {
+
+	if accountService.issuer == nil {
+
+		return nil, utils.NewInternalError(utils.ErrTokenSigningKeyMissing)
+	}
+
+	now := accountService.config.Now().UTC()
+
+	current, session, err := accountService.findRefreshToken(context, refreshToken)
+	if err != nil {
+
+		return nil, err
+	}
+
+	if current == nil || session.RevokedAt != nil || !now.Before(current.ExpiresAt) {
+
+		return nil, utils.NewUnauthorized(utils.ErrInvalidRefreshToken)
+	}
+
+	// Reuse Of A Rotated Token Means It Leaked: Kill The Whole Family :
+	if current.UsedAt != nil {
+
+		return nil, accountService.revokeOnReuse(context, session, now)
+	}
+
+	nextToken, next, err := accountService.newRefreshToken(now)
+	if err != nil {
+
+		return nil, err
+	}
+
+	rotated, err := accountService.accounts.RotateRefreshToken(context, current, next, now)
+	if err != nil {
+
+		return nil, err
+	}
+
+	if !rotated {
+
+		return nil, accountService.revokeOnReuse(context, session, now)
+	}
+
+	// The Session Decides The Tenant, Not The Header Of The ( Unauthenticated ) Refresh Request :
+	context = tenant.WithID(context, session.TenantID)
+
+	user, err := accountService.users.GetUserByID(context, session.UserID)
+	if err != nil {
+
+		if errors.Is(err, utils.ErrUserNotFound) {
+
+			return nil, utils.NewUnauthorized(utils.ErrInvalidRefreshToken)
+		}
+
+		return nil, err
+	}
+
+	if !canSignIn(user) {
+
+		return nil, utils.NewForbidden(utils.ErrUserNotActive)
+	}
+
+	// Roles Are Re-Read So Role Changes Apply On The Next Refresh :
+	credential, err := accountService.accounts.GetCredential(context, user.ID)
+	if err != nil {
+
+		return nil, err
+	}
+
+	return accountService.tokenPair(user, credential, session, nextToken)
+}
+
+func (accountService *AccountService) Logout(context context.Context, refreshToken string) error {
+
+	current, session, err := accountService.findRefreshToken(context, refreshToken)
+	if err != nil || current == nil {
+
+		return err
+	}
+
+	return accountService.accounts.RevokeSession(context, session.ID, accountService.config.Now().UTC())
+}
+
+// findRefreshToken Returns nil ( Not An Error ) For Unknown Tokens :
+func (accountService *AccountService) findRefreshToken(context context.Context, refreshToken string) (*models.RefreshToken, *models.Session, error) {
+
+	token, err := accountService.accounts.GetRefreshToken(context, hashRefreshToken(refreshToken))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+
+		return nil, nil, nil
+	}
+
+	if err != nil {
+
+		return nil, nil, err
+	}
+
+	session, err := accountService.accounts.GetSession(context, token.SessionID)
+	if err != nil {
+
+		return nil, nil, err
+	}
+
+	return token, session, nil
+}
+
+func (accountService *AccountService) revokeOnReuse(context context.Context, session *models.Session, now time.Time) error {
+
+	utils.Error("refresh token reuse detected, revoking session " + session.ID.String())
+	if err := accountService.accounts.RevokeSession(context, session.ID, now); err != nil {
+
+		return err
+	}
+
+	return utils.NewUnauthorized(utils.ErrInvalidRefreshToken)
+}
+
+// ---------------- Passwords & Sessions ----------------
+
+func (accountService *AccountService) ChangePassword(context context.Context, userID string, req models.ChangePasswordReq) error {
+
+	// Callers Without A Password ( API Keys, External Tokens ) Have None To Change :
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+
+		return utils.NewForbidden(utils.ErrNotAnAccount)
+	}
+
+	credential, err := accountService.accounts.GetCredential(context, uid)
+	if err != nil {
+
+		if errors.Is(err, utils.ErrAccountNotFound) {
+
+			return utils.NewForbidden(utils.ErrNotAnAccount)
+		}
+
+		return err
+	}
+
+	matched, err := VerifyPassword(credential.PasswordHash, req.CurrentPassword)
+	if err != nil {
+
+		return err
+	}
+
+	if !matched {
+
+		return utils.NewBadRequest(utils.ErrCurrentPasswordMismatch)
+	}
+
+	if err := validatePassword(req.NewPassword); err != nil {
+
+		return err
+	}
+
+	hash, err := HashPassword(req.NewPassword)
+	if err != nil {
+
+		return err
+	}
+
+	now := accountService.config.Now().UTC()
+	credential.PasswordHash = hash
+	credential.PasswordChangedAt = now
+	credential.FailedAttempts = 0
+	credential.LockedUntil = nil
+
+	if err := accountService.accounts.SaveCredential(context, credential); err != nil {
+
+		return err
+	}
+
+	return accountService.accounts.RevokeUserSessions(context, uid, now)
+}
+
+// SetRoles Replaces The Roles Of A Password Account And Revokes Its Sessions ( So A Demotion Applies At Once ) :
+func (accountService *AccountService) SetRoles(context context.Context, userID string, roles []string) error {
+
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+
+		return utils.NewBadRequest(utils.ErrInvalidID)
+	}
+
+	for _, role := range roles {
+
+		if strings.TrimSpace(role) == "" {
+
+			return utils.NewBadRequest(utils.ErrInvalidRoles)
+		}
+	}
+
+	credential, err := accountService.accounts.GetCredential(context, uid)
+	if err != nil {
+
+		if errors.Is(err, utils.ErrAccountNotFound) {
+
+			return utils.NewNotFound(utils.ErrAccountNotFound)
+		}
+
+		return err
+	}
+
+	before := accountRoles{Roles: credential.Roles}
+	credential.Roles = utils.StringList(roles)
+
+	// New Roles, Revoked Sessions And The Audit Entry Commit Together :
+	return accountService.db.WithContext(context).Transaction(func(gormDB *gorm.DB) error {
+
+		if err := accountService.accounts.SaveCredentialTx(gormDB, credential); err != nil {
+
+			return err
+		}
+
+		if err := accountService.accounts.RevokeUserSessionsTx(gormDB, uid, accountService.config.Now().UTC()); err != nil {
+
+			return err
+		}
+
+		return accountService.audit.RecordTx(context, gormDB, constants.AuditActionUpdate, constants.AuditTargetAccount, uid.String(), &before, &accountRoles{Roles: credential.Roles})
+	})
+}
+
+func (accountService *AccountService) SessionActive(context context.Context, sessionID string) (bool, error) {
+
+	uid, err := uuid.Parse(sessionID)
+	if err != nil {
+
+		return false, nil
+	}
+
+	session, err := accountService.accounts.GetSession(context, uid)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+
+		return false, nil
+	}
+
+	if err != nil {
+
+		return false, err
+	}
+
+	return session.RevokedAt == nil, nil
+}
+
+// ---------------- Helpers ----------------
+
+// accountRoles Is What The Audit Trail Keeps Of A Credential ( Never The Password Hash ) :
+type accountRoles struct {
+	Roles utils.StringList `json:"roles"`
+}
+
+// tokenPair Issues An Access Token Carrying The Account's Roles And The Session's Authentication Methods :
+func (accountService *AccountService) tokenPair(user *userModels.User, credential *models.Credential, session *models.Session, refreshToken string) (*models.TokenPair, error) {
+
+	roles := []string(credential.Roles)
+	if len(roles) == 0 {
+
+		roles = []string{constants.RoleMember}
+	}
+
+	amr := []string{constants.AMRPassword}
+	if session.MFA {
+
+		amr = append(amr, constants.AMRMFA, constants.AMROTP)
+	}
+
+	accessToken, err := accountService.issuer.Issue(user.ID.String(), user.Email, user.TenantID, roles, amr, session.ID)
+	if err != nil {
+
+		return nil, err
+	}
+
+	return &models.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    constants.TokenTypeBearer,
+		ExpiresIn:    int64(accountService.issuer.TTL().Seconds()),
+	}, nil
+}
+
+func (accountService *AccountService) newRefreshToken(now time.Time) (string, *models.RefreshToken, error) {
+
+	random := make([]byte, constants.RefreshTokenBytes)
+	if _, err := rand.Read(random); err != nil {
+
+		return "", nil, err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(random)
+
+	return token, &models.RefreshToken{TokenHash: hashRefreshToken(token), ExpiresAt: now.Add(accountService.config.RefreshTTL)}, nil
+}
+
+func hashRefreshToken(token string) string {
+
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func validatePassword(password string) error {
+
+	if length := len([]rune(password)); length < constants.MinPasswordChars || length > constants.MaxPasswordChars {
+
+		return utils.NewBadRequest(utils.ErrWeakPassword)
+	}
+
+	return nil
+}
+
+var (
+	dummyHashOnce sync.Once
+	dummyHash     string
+)
+
+// dummyPasswordHash Is Verified Against When There Is No Real Hash, So Unknown Emails Cost The Same Time :
+func dummyPasswordHash() string {
+
+	dummyHashOnce.Do(func() {
+
+		dummyHash, _ = HashPassword(uuid.NewString())
+	})
+
+	return dummyHash
+}
+
+// canSignIn Reports Whether The User's Status Allows New Tokens ( Suspended And Deactivated Users Get None ) :
+func canSignIn(user *userModels.User) bool {
+
+	return user.Status != constants.UserStatusSuspended && user.Status != constants.UserStatusDeactivated
+}
@@ -0,0 +1,367 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	auditServiceInterface "backend-task/internal/audit/services/interface"
+	"backend-task/internal/auth/models"
+	"backend-task/internal/auth/repository"
+	apiKeyServiceInterface "backend-task/internal/auth/services/interface"
+	"backend-task/internal/constants"
+	"backend-task/internal/tenant"
+	"backend-task/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type APIKeyService struct {
+	db      *gorm.DB
+	apiKeys repository.APIKeyRepository
+	audit   auditServiceInterface.AuditService
+}
+
+func NewAPIKeyService(db *gorm.DB, apiKeys repository.APIKeyRepository, audit auditServiceInterface.AuditService) apiKeyServiceInterface.APIKeyService {
+
+	return &APIKeyService{db: db, apiKeys: apiKeys, audit: audit}
+}
+
+// ---------------- Management ----------------
+
+func (apiKeyService *APIKeyService) IssueAPIKey(context context.Context, req models.CreateAPIKeyReq) (*models.IssuedAPIKey, error) {
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > constants.MaxAPIKeyNameChars {
+
+		return nil, utils.NewBadRequest(utils.ErrInvalidAPIKeyName)
+	}
+
+	for _, permission := range req.Permissions {
+
+		if !slices.Contains(constants.AllPermissions, permission) {
+
+			return nil, utils.NewBadRequest(fmt.Errorf("%w: %s", utils.ErrUnknownPermission, permission))
+		}
+
+		if constants.MFARequiredPermissions[permission] {
+
+			return nil, utils.NewBadRequest(fmt.Errorf("%w: %s", utils.ErrAPIKeyPermissionRequiresMFA, permission))
+		}
+	}
+
+	if err := checkDelegable(context, req.Permissions); err != nil {
+
+		return nil, err
+	}
+
+	for _, allowed := range req.AllowedIPs {
+
+		if !validAllowedIP(allowed) {
+
+			return nil, utils.NewBadRequest(fmt.Errorf("%w: %s", utils.ErrInvalidAllowedIP, allowed))
+		}
+	}
+
+	key, prefix, err := generateAPIKey()
+	if err != nil {
+
+		return nil, err
+	}
+
+	apiKey := &models.APIKey{
+		Name:        name,
+		Prefix:      prefix,
+		Hash:        hashAPIKey(key),
+		Permissions: utils.StringList(req.Permissions),
+		AllowedIPs:  utils.StringList(req.AllowedIPs),
+		CreatedBy:   utils.RequestMetaFromContext(context).ActorID,
+		TenantID:    tenant.ID(context),
+	}
+
+	if apiKey.AllowedIPs == nil {
+
+		apiKey.AllowedIPs = utils.StringList{}
+	}
+
+	// The Audit Entry Sees The Stored Key Only, Whose Hash Is Never Serialized :
+	err = apiKeyService.db.WithContext(context).Transaction(func(gormDB *gorm.DB) error {
+
+		if err := apiKeyService.apiKeys.CreateAPIKeyTx(gormDB, apiKey); err != nil {
+
+			return err
+		}
+
+		return apiKeyService.audit.RecordTx(context, gormDB, constants.AuditActionCreate, constants.AuditTargetAPIKey, apiKey.ID.String(), nil, apiKey)
+	})
+	if err != nil {
+
+		return nil, err
+	}
+
+	return &models.IssuedAPIKey{APIKey: apiKey, Key: key}, nil
+}
+
+func (apiKeyService *APIKeyService) ListAPIKeys(context context.Context) ([]*models.APIKey, error) {
+
+	return apiKeyService.apiKeys.ListAPIKeys(context, tenant.ID(context))
+}
+
+func (apiKeyService *APIKeyService) RotateAPIKey(context context.Context, id string) (*models.IssuedAPIKey, error) {
+
+	apiKey, err := apiKeyService.getAPIKey(context, id)
+	if err != nil {
+
+		return nil, err
+	}
+
+	if apiKey.RevokedAt != nil {
+
+		return nil, utils.NewConflict(utils.ErrAPIKeyRevoked)
+	}
+
+	// The New Secret Carries The Key's Scopes, So Only A Caller Holding Them May Mint It :
+	if err := checkDelegable(context, apiKey.Permissions); err != nil {
+
+		return nil, err
+	}
+
+	key, prefix, err := generateAPIKey()
+	if err != nil {
+
+		return nil, err
+	}
+
+	before := *apiKey
+	now := time.Now().UTC()
+	apiKey.Prefix = prefix
+	apiKey.Hash = hashAPIKey(key)
+	apiKey.RotatedAt = &now
+
+	if err := apiKeyService.updateAndRecord(context, &before, apiKey); err != nil {
+
+		return nil, err
+	}
+
+	return &models.IssuedAPIKey{APIKey: apiKey, Key: key}, nil
+}
+
+// RevokeAPIKey Is Idempotent; The First Revocation Time Is Kept :
+func (apiKeyService *APIKeyService) RevokeAPIKey(context context.Context, id string) error {
+
+	apiKey, err := apiKeyService.getAPIKey(context, id)
+	if err != nil {
+
+		return err
+	}
+
+	if apiKey.RevokedAt != nil {
+
+		return nil
+	}
+
+	before := *apiKey
+	now := time.Now().UTC()
+	apiKey.RevokedAt = &now
+
+	return apiKeyService.updateAndRecord(context, &before, apiKey)
+}
+
+// updateAndRecord Saves A Changed Key And Its Audit Entry In One Transaction :
+func (apiKeyService *APIKeyService) updateAndRecord(context context.Context, before, apiKey *models.APIKey) error {
+
+	return apiKeyService.db.WithContext(context).Transaction(func(gormDB *gorm.DB) error {
+
+		if err := apiKeyService.apiKeys.UpdateAPIKeyTx(gormDB, apiKey); err != nil {
+
+			return err
+		}
+
+		return apiKeyService.audit.RecordTx(context, gormDB, constants.AuditActionUpdate, constants.AuditTargetAPIKey, apiKey.ID.String(), before, apiKey)
+	})
+}
+
+func (apiKeyService *APIKeyService) getAPIKey(context context.Context, id string) (*models.APIKey, error) {
+
+	uid, err := uuid.Parse(id)
+	if err != nil {
+
+		return nil, utils.NewBadRequest(utils.ErrInvalidID)
+	}
+
+	apiKey, err := apiKeyService.apiKeys.GetAPIKey(context, uid)
+	if err != nil {
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+
+			return nil, utils.NewNotFound(utils.ErrAPIKeyNotFound)
+		}
+
+		return nil, err
+	}
+
+	// Keys Of Other Tenants Do Not Exist For The Caller :
+	if apiKey.TenantID != tenant.ID(context) {
+
+		return nil, utils.NewNotFound(utils.ErrAPIKeyNotFound)
+	}
+
+	return apiKey, nil
+}
+
+// ---------------- Authentication ----------------
+
+// Authenticate Looks The Key Up By Its Visible Prefix And Compares Hashes In Constant Time.
+// Unknown, Mismatched And Revoked Keys Are Indistinguishable To The Caller.
+func (apiKeyService *APIKeyService) Authenticate(context context.Context, key, clientIP string) (*models.Principal, error) {
+
+	prefix, ok := apiKeyPrefix(key)
+	if !ok {
+
+		return nil, utils.NewUnauthorized(utils.ErrInvalidAPIKey)
+	}
+
+	apiKey, err := apiKeyService.apiKeys.GetAPIKeyByPrefix(context, prefix)
+	if err != nil {
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+
+			return nil, utils.NewUnauthorized(utils.ErrInvalidAPIKey)
+		}
+
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(apiKey.Hash), []byte(hashAPIKey(key))) != 1 || apiKey.RevokedAt != nil {
+
+		return nil, utils.NewUnauthorized(utils.ErrInvalidAPIKey)
+	}
+
+	if !ipAllowed(apiKey.AllowedIPs, clientIP) {
+
+		return nil, utils.NewForbidden(utils.ErrAPIKeyIPNotAllowed)
+	}
+
+	if err := apiKeyService.apiKeys.RecordUsage(context, apiKey.ID, time.Now().UTC()); err != nil {
+
+		return nil, err
+	}
+
+	// A Key Is A Single Factor: Step-Up Protected Permissions Are Never Granted To It ( See IssueAPIKey ) :
+	principal := &models.Principal{Subject: constants.APIKeyActorPrefix + apiKey.Prefix, Permissions: map[string]bool{}, TenantID: apiKey.TenantID}
+	for _, permission := range apiKey.Permissions {
+
+		principal.Permissions[permission] = true
+	}
+
+	return principal, nil
+}
+
+// ---------------- Helpers ----------------
+
+// checkDelegable Refuses Permissions The Caller Does Not Hold, So A Key Never Outranks Whoever Minted It :
+func checkDelegable(context context.Context, permissions []string) error {
+
+	principal := models.PrincipalFromContext(context)
+	for _, permission := range permissions {
+
+		if !principal.Can(permission) {
+
+			return utils.NewForbidden(fmt.Errorf("%w: cannot grant %s", utils.ErrPermissionDenied, permission))
+		}
+	}
+
+	return nil
+}
+
+// generateAPIKey Returns A New Key ( bt_<prefix hex>_<secret> ) And Its Visible Prefix :
+func generateAPIKey() (string, string, error) {
+
+	random := make([]byte, constants.APIKeyPrefixBytes+constants.APIKeySecretBytes)
+	if _, err := rand.Read(random); err != nil {
+
+		return "", "", err
+	}
+
+	prefix := constants.APIKeyPrefix + hex.EncodeToString(random[:constants.APIKeyPrefixBytes])
+	secret := base64.RawURLEncoding.EncodeToString(random[constants.APIKeyPrefixBytes:])
+
+	return prefix + "_" + secret, prefix, nil
+}
+
+func apiKeyPrefix(key string) (string, bool) {
+
+	if !strings.HasPrefix(key, constants.APIKeyPrefix) {
+
+		return "", false
+	}
+
+	prefix, _, ok := strings.Cut(key[len(constants.APIKeyPrefix):], "_")
+	if !ok || len(prefix) != constants.APIKeyPrefixBytes*2 {
+
+		return "", false
+	}
+
+	return constants.APIKeyPrefix + prefix, true
+}
+
+func hashAPIKey(key string) string {
+
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func validAllowedIP(allowed string) bool {
+
+	if _, _, err := net.ParseCIDR(allowed); err == nil {
+
+		return true
+	}
+
+	return net.ParseIP(allowed) != nil
+}
+
+// ipAllowed Matches The Client IP Against Single Addresses And CIDR Ranges ( An Empty List Allows Any ) :
+func ipAllowed(allowed utils.StringList, clientIP string) bool {
+
+	if len(allowed) == 0 {
+
+		return true
+	}
+
+	ip := net.ParseIP(clientIP)
+	if ip == nil {
+
+		return false
+	}
+
+	for _, entry := range allowed {
+
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+
+			if network.Contains(ip) {
+
+				return true
+			}
+
+			continue
+		}
+
+		if allowedIP := net.ParseIP(entry); allowedIP != nil && allowedIP.Equal(ip) {
+
+			return true
+		}
+	}
+
+	return false
+}
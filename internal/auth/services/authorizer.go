@@ -0,0 +1,90 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"backend-task/internal/auth/models"
+	"backend-task/internal/constants"
+	"backend-task/internal/utils"
+)
+
+// Authorizer Resolves Role Names Into Permissions ( Built-In Roles Plus Custom Roles From Config ) :
+type Authorizer struct {
+	roles map[string][]string
+}
+
+// NewAuthorizer Merges Custom Roles Into The Built-In Ones.
+// Custom Roles Cannot Redefine A Built-In Role Or Use An Unknown Permission.
+func NewAuthorizer(custom map[string][]string) (*Authorizer, error) {
+
+	roles := make(map[string][]string, len(constants.BuiltInRoles)+len(custom))
+	for role, permissions := range constants.BuiltInRoles {
+
+		roles[role] = permissions
+	}
+
+	for role, permissions := range custom {
+
+		role = strings.TrimSpace(role)
+		if _, builtIn := constants.BuiltInRoles[role]; builtIn || role == "" {
+
+			return nil, fmt.Errorf("custom role %q cannot be defined", role)
+		}
+
+		for _, permission := range permissions {
+
+			if !slices.Contains(constants.AllPermissions, permission) {
+
+				return nil, fmt.Errorf("%w: %q in role %q", utils.ErrUnknownPermission, permission, role)
+			}
+		}
+
+		roles[role] = permissions
+	}
+
+	return &Authorizer{roles: roles}, nil
+}
+
+// ParseCustomRoles Decodes The RBAC_CUSTOM_ROLES Setting ( Empty Means None ) :
+func ParseCustomRoles(raw string) (map[string][]string, error) {
+
+	if strings.TrimSpace(raw) == "" {
+
+		return nil, nil
+	}
+
+	var custom map[string][]string
+	if err := json.Unmarshal([]byte(raw), &custom); err != nil {
+
+		return nil, fmt.Errorf("parse custom roles: %w", err)
+	}
+
+	return custom, nil
+}
+
+// Principal Builds The Caller From Verified Claims; Unknown Roles Grant Nothing :
+func (authorizer *Authorizer) Principal(claims *models.Claims) *models.Principal {
+
+	principal := &models.Principal{
+		Subject:     claims.Subject,
+		Email:       claims.Email,
+		Roles:       claims.Roles,
+		Permissions: map[string]bool{},
+		SessionID:   claims.SessionID,
+		MFA:         slices.Contains(claims.AMR, constants.AMRMFA) || slices.Contains(claims.AMR, constants.AMROTP),
+		TenantID:    claims.TenantID,
+	}
+
+	for _, role := range claims.Roles {
+
+		for _, permission := range authorizer.roles[role] {
+
+			principal.Permissions[permission] = true
+		}
+	}
+
+	return principal
+}
@@ -0,0 +1,45 @@
+package serviceInterface
+
+import (
+	"context"
+
+	"backend-task/internal/auth/models"
+	userModels "backend-task/internal/user/models"
+)
+
+// Account Service Defines Password Login And Session Management :
+type AccountService interface {
+
+	// Register Creates A User ( Allocated Like Any Other ) With A Password; A Taken Email Returns No User And No Error.
+	Register(context context.Context, req models.RegisterReq) (*userModels.User, error)
+
+	// Login Exchanges Email & Password For Tokens. Every Failure Looks The Same To The Caller.
+	Login(context context.Context, req models.LoginReq) (*models.TokenPair, error)
+
+	// Refresh Rotates A Refresh Token; Presenting A Used Token Revokes The Whole Session.
+	Refresh(context context.Context, refreshToken string) (*models.TokenPair, error)
+
+	// Logout Revokes The Session Of The Refresh Token ( Unknown Tokens Are Ignored ).
+	Logout(context context.Context, refreshToken string) error
+
+	// ChangePassword Replaces A User's Password And Revokes All Their Sessions.
+	ChangePassword(context context.Context, userID string, req models.ChangePasswordReq) error
+
+	// SessionActive Reports Whether Tokens Of The Session Are Still Accepted.
+	SessionActive(context context.Context, sessionID string) (bool, error)
+
+	// SetRoles Replaces The Roles Of An Account And Revokes Its Sessions So They Apply At Once.
+	SetRoles(context context.Context, userID string, roles []string) error
+
+	// EnrollMFA Starts TOTP Enrolment And Returns The Secret With Its otpauth URI.
+	EnrollMFA(context context.Context, userID string) (*models.MFAEnrollment, error)
+
+	// ActivateMFA Confirms Enrolment With A Code And Returns One-Time Recovery Codes.
+	ActivateMFA(context context.Context, userID, code string) (*models.RecoveryCodes, error)
+
+	// VerifyMFA Completes A Login Challenge With A TOTP Or Recovery Code.
+	VerifyMFA(context context.Context, req models.MFAVerifyReq) (*models.TokenPair, error)
+
+	// StepUp Adds The Second Factor To An Existing Session And Returns A New Access Token.
+	StepUp(context context.Context, userID, sessionID string, req models.MFACodeReq) (*models.TokenPair, error)
+}
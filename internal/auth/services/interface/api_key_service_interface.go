@@ -0,0 +1,26 @@
+package serviceInterface
+
+import (
+	"context"
+
+	"backend-task/internal/auth/models"
+)
+
+// API Key Service Defines Key Management And Key Authentication :
+type APIKeyService interface {
+
+	// IssueAPIKey Creates A Key Scoped To Permissions ( And Optionally IPs ); The Plain Key Is Returned Once.
+	IssueAPIKey(context context.Context, req models.CreateAPIKeyReq) (*models.IssuedAPIKey, error)
+
+	// ListAPIKeys Lists The Keys Of The Caller's Tenant Without Secrets.
+	ListAPIKeys(context context.Context) ([]*models.APIKey, error)
+
+	// RotateAPIKey Replaces The Secret Of A Key, Keeping Its Scopes; The Old Key Stops Working Immediately.
+	RotateAPIKey(context context.Context, id string) (*models.IssuedAPIKey, error)
+
+	// RevokeAPIKey Permanently Disables A Key.
+	RevokeAPIKey(context context.Context, id string) error
+
+	// Authenticate Resolves A Presented Key Into A Principal And Records Its Usage.
+	Authenticate(context context.Context, key, clientIP string) (*models.Principal, error)
+}
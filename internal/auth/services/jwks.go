@@ -0,0 +1,88 @@
+package service
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+
+	"backend-task/internal/utils"
+)
+
+// jsonWebKey Is The Subset Of RFC 7517 Needed For RS256 ( "RSA" ) And HS256 ( "oct" ) Keys :
+type jsonWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+	Modulus   string `json:"n"`
+	Exponent  string `json:"e"`
+	Secret    string `json:"k"`
+}
+
+// loadJWKS Reads A Local JWKS File Into Verification Keys Indexed By "kid" :
+func loadJWKS(path string) (map[string]any, error) {
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+
+		return nil, err
+	}
+
+	var document struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+
+	if err := json.Unmarshal(raw, &document); err != nil {
+
+		return nil, fmt.Errorf("parse jwks: %w", err)
+	}
+
+	keys := make(map[string]any, len(document.Keys))
+	for _, key := range document.Keys {
+
+		// Keys Meant For Encryption Are Not Signing Keys :
+		if key.Use != "" && key.Use != "sig" {
+
+			continue
+		}
+
+		parsed, err := key.verificationKey()
+		if err != nil {
+
+			return nil, fmt.Errorf("jwk %q: %w", key.KeyID, err)
+		}
+
+		keys[key.KeyID] = parsed
+	}
+
+	return keys, nil
+}
+
+func (key jsonWebKey) verificationKey() (any, error) {
+
+	switch key.KeyType {
+	case "RSA":
+		modulus, err := base64.RawURLEncoding.DecodeString(key.Modulus)
+		if err != nil {
+
+			return nil, err
+		}
+
+		exponent, err := base64.RawURLEncoding.DecodeString(key.Exponent)
+		if err != nil {
+
+			return nil, err
+		}
+
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(modulus), E: int(new(big.Int).SetBytes(exponent).Int64())}, nil
+
+	case "oct":
+		return base64.RawURLEncoding.DecodeString(key.Secret)
+
+	default:
+		return nil, fmt.Errorf("%w: kty %q", utils.ErrUnsupportedJWK, key.KeyType)
+	}
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"backend-task/internal/auth/models"
+	"backend-task/internal/constants"
+	"backend-task/internal/utils"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWT Config Describes Where Verification Keys Come From And Which Claims Are Required :
+type JWTConfig struct {
+	HMACKeyFile      string        // Shared Secret ( HS256 ).
+	RSAPublicKeyFile string        // PEM Public Key ( RS256 ).
+	JWKSFile         string        // Local JWKS Document ( HS256 / RS256, Selected By "kid" ).
+	Issuer           string        // Required "iss" ( Skipped When Empty ).
+	Audience         string        // Required "aud" ( Skipped When Empty ).
+	Leeway           time.Duration // Allowed Clock Skew For "exp" / "nbf" / "iat".
+	Now              func() time.Time
+}
+
+// JWT Verifier Validates Bearer Tokens Against The Configured Keys :
+type JWTVerifier struct {
+	hmacKey   []byte
+	rsaKey    any
+	jwks      map[string]any
+	parser    *jwt.Parser
+	algorithm []string
+}
+
+func NewJWTVerifier(config JWTConfig) (*JWTVerifier, error) {
+
+	if config.Now == nil {
+
+		config.Now = time.Now
+	}
+
+	verifier := &JWTVerifier{}
+
+	if config.HMACKeyFile != "" {
+
+		key, err := os.ReadFile(config.HMACKeyFile)
+		if err != nil {
+
+			return nil, fmt.Errorf("read hmac key: %w", err)
+		}
+
+		verifier.hmacKey = bytes.TrimSpace(key)
+	}
+
+	if config.RSAPublicKeyFile != "" {
+
+		pem, err := os.ReadFile(config.RSAPublicKeyFile)
+		if err != nil {
+
+			return nil, fmt.Errorf("read rsa public key: %w", err)
+		}
+
+		if verifier.rsaKey, err = jwt.ParseRSAPublicKeyFromPEM(pem); err != nil {
+
+			return nil, fmt.Errorf("parse rsa public key: %w", err)
+		}
+	}
+
+	if config.JWKSFile != "" {
+
+		keys, err := loadJWKS(config.JWKSFile)
+		if err != nil {
+
+			return nil, err
+		}
+
+		verifier.jwks = keys
+	}
+
+	if verifier.hmacKey == nil && verifier.rsaKey == nil && len(verifier.jwks) == 0 {
+
+		return nil, utils.ErrNoVerificationKeys
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{constants.JWTAlgorithmHS256, constants.JWTAlgorithmRS256}),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(config.Leeway),
+		jwt.WithTimeFunc(config.Now),
+	}
+
+	if config.Issuer != "" {
+
+		options = append(options, jwt.WithIssuer(config.Issuer))
+	}
+
+	if config.Audience != "" {
+
+		options = append(options, jwt.WithAudience(config.Audience))
+	}
+
+	verifier.parser = jwt.NewParser(options...)
+
+	return verifier, nil
+}
+
+// Verify Parses The Token, Checks Its Signature And Registered Claims, And Returns The Claims :
+func (verifier *JWTVerifier) Verify(token string) (*models.Claims, error) {
+
+	claims := &models.Claims{}
+	if _, err := verifier.parser.ParseWithClaims(token, claims, verifier.keyFor); err != nil {
+
+		return nil, fmt.Errorf("%w: %v", utils.ErrInvalidToken, err)
+	}
+
+	if claims.Subject == "" {
+
+		return nil, fmt.Errorf("%w: missing subject", utils.ErrInvalidToken)
+	}
+
+	return claims, nil
+}
+
+// keyFor Selects The Verification Key; A Key Is Only Ever Used With The Algorithm Family It Belongs To :
+func (verifier *JWTVerifier) keyFor(token *jwt.Token) (any, error) {
+
+	var key any
+	if kid, _ := token.Header["kid"].(string); kid != "" && verifier.jwks != nil {
+
+		key = verifier.jwks[kid]
+		if key == nil {
+
+			return nil, fmt.Errorf("unknown key id %q", kid)
+		}
+	}
+
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if key == nil {
+
+			key = verifier.hmacKey
+		}
+
+		if secret, ok := key.([]byte); ok && len(secret) > 0 {
+
+			return secret, nil
+		}
+
+	case *jwt.SigningMethodRSA:
+		if key == nil {
+
+			key = verifier.rsaKey
+		}
+
+		if key != nil {
+
+			if _, isSecret := key.([]byte); !isSecret {
+
+				return key, nil
+			}
+		}
+	}
+
+	return nil, errors.New("no key for token algorithm")
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"backend-task/internal/constants"
+	"backend-task/internal/utils"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// HashPassword Returns A PHC Formatted argon2id Hash :
+// $argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<key>
+func HashPassword(password string) (string, error) {
+
+	salt := make([]byte, constants.Argon2SaltBytes)
+	if _, err := rand.Read(salt); err != nil {
+
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, constants.Argon2Iterations, constants.Argon2Memory, constants.Argon2Parallelism, constants.Argon2KeyBytes)
+
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		constants.PasswordHashAlgorithm, argon2.Version, constants.Argon2Memory, constants.Argon2Iterations, constants.Argon2Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// VerifyPassword Checks A Password Against A Hash, Using The Parameters Stored In The Hash
+// ( So Raising The Defaults Never Breaks Existing Passwords ) :
+func VerifyPassword(encoded, password string) (bool, error) {
+
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != constants.PasswordHashAlgorithm {
+
+		return false, utils.ErrMalformedPasswordHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+
+		return false, utils.ErrMalformedPasswordHash
+	}
+
+	var memory, iterations uint32
+	var parallelism uint8
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &iterations, &parallelism); err != nil {
+
+		return false, utils.ErrMalformedPasswordHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+
+		return false, utils.ErrMalformedPasswordHash
+	}
+
+	expected, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+
+		return false, utils.ErrMalformedPasswordHash
+	}
+
+	actual := argon2.IDKey([]byte(password), salt, iterations, memory, parallelism, uint32(len(expected)))
+
+	return subtle.ConstantTimeCompare(actual, expected) == 1, nil
+}
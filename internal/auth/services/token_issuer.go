@@ -0,0 +1,103 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"time"
+
+	"backend-task/internal/auth/models"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// Token Issuer Config Describes How Access Tokens Are Signed :
+type TokenIssuerConfig struct {
+	HMACKeyFile       string // HS256 Secret ( Same File The Verifier Reads ).
+	RSAPrivateKeyFile string // RS256 Private Key; Takes Precedence Over HMAC.
+	Issuer            string
+	Audience          string
+	TTL               time.Duration
+	Now               func() time.Time
+}
+
+// Token Issuer Signs Access Tokens For Password Logins :
+type TokenIssuer struct {
+	method jwt.SigningMethod
+	key    any
+	config TokenIssuerConfig
+}
+
+// NewTokenIssuer Returns nil ( And No Error ) When No Signing Key Is Configured :
+func NewTokenIssuer(config TokenIssuerConfig) (*TokenIssuer, error) {
+
+	if config.Now == nil {
+
+		config.Now = time.Now
+	}
+
+	switch {
+	case config.RSAPrivateKeyFile != "":
+		pem, err := os.ReadFile(config.RSAPrivateKeyFile)
+		if err != nil {
+
+			return nil, fmt.Errorf("read rsa private key: %w", err)
+		}
+
+		key, err := jwt.ParseRSAPrivateKeyFromPEM(pem)
+		if err != nil {
+
+			return nil, fmt.Errorf("parse rsa private key: %w", err)
+		}
+
+		return &TokenIssuer{method: jwt.SigningMethodRS256, key: key, config: config}, nil
+
+	case config.HMACKeyFile != "":
+		key, err := os.ReadFile(config.HMACKeyFile)
+		if err != nil {
+
+			return nil, fmt.Errorf("read hmac key: %w", err)
+		}
+
+		return &TokenIssuer{method: jwt.SigningMethodHS256, key: bytes.TrimSpace(key), config: config}, nil
+
+	default:
+		return nil, nil
+	}
+}
+
+// Issue Signs An Access Token For The Subject, Bound To A Session And The Subject's Tenant.
+// The amr Claim Records How The Caller Authenticated ( e.g., "pwd", "mfa" ).
+func (issuer *TokenIssuer) Issue(subject, email, tenantID string, roles, amr []string, sessionID uuid.UUID) (string, error) {
+
+	now := issuer.config.Now()
+	claims := models.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Subject:   subject,
+			Issuer:    issuer.config.Issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(issuer.config.TTL)),
+		},
+		Email:     email,
+		Roles:     roles,
+		SessionID: sessionID.String(),
+		AMR:       amr,
+		TenantID:  tenantID,
+	}
+
+	if issuer.config.Audience != "" {
+
+		claims.Audience = jwt.ClaimStrings{issuer.config.Audience}
+	}
+
+	return jwt.NewWithClaims(issuer.method, claims).SignedString(issuer.key)
+}
+
+// TTL Is The Lifetime Of Issued Access Tokens :
+func (issuer *TokenIssuer) TTL() time.Duration {
+
+	return issuer.config.TTL
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+)
+
+// Exit Codes :
+const (
+	ExitOK       = 0
+	ExitFailure  = 1 // The Command Ran But Reported Problems ( e.g., Invalid Rows ).
+	ExitUsageErr = 2
+)
+
+// Run Executes A Subcommand ( e.g., "import" ) And Returns The Process Exit Code :
+func Run(args []string, stdout, stderr io.Writer) int {
+
+	if len(args) == 0 {
+
+		usage(stderr)
+		return ExitUsageErr
+	}
+
+	switch args[0] {
+	case "import":
+		return runImport(args[1:], stdout, stderr)
+
+	case "export":
+		return runExport(args[1:], stdout, stderr)
+
+	case "help", "-h", "--help":
+		usage(stdout)
+		return ExitOK
+
+	default:
+		fmt.Fprintf(stderr, "unknown command %q\n\n", args[0])
+		usage(stderr)
+		return ExitUsageErr
+	}
+}
+
+func usage(writer io.Writer) {
+
+	fmt.Fprintln(writer, `Usage: app <command> [flags]
+
+Without a command the HTTP server is started.
+
+Commands:
+  import   Import users from a CSV file ( name,email,date_of_birth )
+  export   Export users or groups as CSV or NDJSON
+
+Run "app <command> -h" for command flags.`)
+}
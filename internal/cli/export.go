@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"backend-task/internal/constants"
+	"backend-task/internal/db"
+	"backend-task/internal/router"
+	"backend-task/internal/tenant"
+	"backend-task/internal/user/models"
+	services "backend-task/internal/user/services"
+	"backend-task/internal/utils"
+)
+
+// runExport Streams Users Or Groups To A File ( Or Stdout ) Using The Same Export As GET /users/export :
+func runExport(args []string, stdout, stderr io.Writer) int {
+
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	entity := flags.String("entity", "users", "what to export: users or groups")
+	format := flags.String("format", constants.ExportFormatCSV, "export format: csv or ndjson")
+	group := flags.String("group", "", "users only: group filter ( e.g., adult-1 )")
+	status := flags.String("status", "", "users only: status filter ( default leaves out suspended and deactivated )")
+	base := flags.String("base", "", "groups only: base category filter ( e.g., adult )")
+	compress := flags.Bool("gzip", false, "compress the output with gzip")
+	out := flags.String("out", "-", "output file ( \"-\" writes stdout )")
+	tenantID := flags.String("tenant", constants.DefaultTenantID, "tenant to export")
+
+	if err := flags.Parse(args); err != nil {
+
+		return ExitUsageErr
+	}
+
+	if _, ok := services.ExportContentType(*format); !ok {
+
+		fmt.Fprintf(stderr, "export: %v\n", utils.ErrUnsupportedExportFormat)
+		return ExitUsageErr
+	}
+
+	if _, err := services.ListedStatuses(*status); err != nil {
+
+		fmt.Fprintf(stderr, "export: %v\n", utils.ErrInvalidUserStatus)
+		return ExitUsageErr
+	}
+
+	if *entity != "users" && *entity != "groups" {
+
+		fmt.Fprintf(stderr, "export: unknown entity %q ( users or groups )\n", *entity)
+		return ExitUsageErr
+	}
+
+	if !tenant.ValidID(*tenantID) {
+
+		fmt.Fprintf(stderr, "export: %v\n", utils.ErrInvalidTenantID)
+		return ExitUsageErr
+	}
+
+	output := stdout
+	if *out != "-" {
+
+		file, err := os.Create(*out)
+		if err != nil {
+
+			fmt.Fprintf(stderr, "export: %v\n", err)
+			return ExitFailure
+		}
+
+		defer file.Close()
+		output = file
+	}
+
+	userService := router.NewServices(db.InitDB()).Users
+	export := userService.ExportUsers
+	if *entity == "groups" {
+
+		export = userService.ExportGroups
+	}
+
+	writer := utils.NewStreamWriter(output, *compress)
+	if err := export(tenant.WithID(context.Background(), *tenantID), writer, models.ExportOptions{Format: *format, Group: *group, Status: *status, Base: *base}); err != nil {
+
+		fmt.Fprintf(stderr, "export: %v\n", err)
+		return ExitFailure
+	}
+
+	if err := writer.Close(); err != nil {
+
+		fmt.Fprintf(stderr, "export: %v\n", err)
+		return ExitFailure
+	}
+
+	return ExitOK
+}
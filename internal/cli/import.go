@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"backend-task/internal/constants"
+	"backend-task/internal/db"
+	"backend-task/internal/router"
+	"backend-task/internal/tenant"
+	"backend-task/internal/user/models"
+	services "backend-task/internal/user/services"
+	"backend-task/internal/utils"
+)
+
+// runImport Imports Users From A CSV File Using The Same Service As POST /users/import,
+// Writing The JSON Report To Stdout ( Or -report ) And A Summary To Stderr :
+func runImport(args []string, stdout, stderr io.Writer) int {
+
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	file := flags.String("file", "", "CSV file to import ( \"-\" reads stdin )")
+	dryRun := flags.Bool("dry-run", false, "validate only, without creating users")
+	batchSize := flags.Int("batch-size", constants.DefaultImportBatchSize, "rows committed per transaction")
+	reportPath := flags.String("report", "", "write the JSON report to this file instead of stdout")
+	tenantID := flags.String("tenant", constants.DefaultTenantID, "tenant the users are imported into")
+
+	if err := flags.Parse(args); err != nil {
+
+		return ExitUsageErr
+	}
+
+	if *file == "" {
+
+		fmt.Fprintln(stderr, "import: -file is required")
+		flags.Usage()
+		return ExitUsageErr
+	}
+
+	if !tenant.ValidID(*tenantID) {
+
+		fmt.Fprintf(stderr, "import: %v\n", utils.ErrInvalidTenantID)
+		return ExitUsageErr
+	}
+
+	var input io.Reader = os.Stdin
+	if *file != "-" {
+
+		source, err := os.Open(*file)
+		if err != nil {
+
+			fmt.Fprintf(stderr, "import: %v\n", err)
+			return ExitFailure
+		}
+
+		defer source.Close()
+		input = source
+	}
+
+	rows, err := services.ParseImportCSV(input)
+	if err != nil {
+
+		fmt.Fprintf(stderr, "import: %v\n", err)
+		return ExitFailure
+	}
+
+	userService := router.NewServices(db.InitDB()).Users
+	report, err := userService.ImportUsers(tenant.WithID(context.Background(), *tenantID), rows, models.ImportOptions{DryRun: *dryRun, BatchSize: *batchSize})
+	if err != nil {
+
+		fmt.Fprintf(stderr, "import: %v\n", err)
+		return ExitFailure
+	}
+
+	output := stdout
+	if *reportPath != "" {
+
+		reportFile, err := os.Create(*reportPath)
+		if err != nil {
+
+			fmt.Fprintf(stderr, "import: %v\n", err)
+			return ExitFailure
+		}
+
+		defer reportFile.Close()
+		output = reportFile
+	}
+
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+
+		fmt.Fprintf(stderr, "import: %v\n", err)
+		return ExitFailure
+	}
+
+	fmt.Fprintf(stderr, "import: total=%d valid=%d invalid=%d created=%d failed=%d dry_run=%t\n",
+		report.Total, report.Valid, report.Invalid, report.Created, report.Failed, report.DryRun)
+
+	if report.Invalid > 0 || report.Failed > 0 {
+
+		return ExitFailure
+	}
+
+	return ExitOK
+}
@@ -2,6 +2,8 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"time"
 
 	"backend-task/internal/utils"
 
@@ -27,3 +29,27 @@ func GetEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+// GetEnvInt Retrieves A Positive Integer Environment Variable Or Returns The Fallback.
+func GetEnvInt(key string, fallback int) int {
+
+	value, err := strconv.Atoi(GetEnv(key, strconv.Itoa(fallback)))
+	if err != nil || value <= 0 {
+
+		return fallback
+	}
+
+	return value
+}
+
+// GetEnvDuration Retrieves A Positive Duration ( e.g., "30s" ) Environment Variable Or Parses The Fallback.
+func GetEnvDuration(key, fallback string) time.Duration {
+
+	value, err := time.ParseDuration(GetEnv(key, fallback))
+	if err != nil || value <= 0 {
+
+		value, _ = time.ParseDuration(fallback)
+	}
+
+	return value
+}
@@ -0,0 +1,31 @@
+package constants
+
+// ---------------- Audit Actions ----------------
+
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+	AuditActionMerge  = "merge"
+)
+
+// ---------------- Audit Target Types ----------------
+
+const (
+	AuditTargetUser    = "user"
+	AuditTargetGroup   = "group"
+	AuditTargetConsent = "consent"
+	AuditTargetAPIKey  = "api_key"
+	AuditTargetAccount = "account" // Login Credential Of A User ( Target ID = User ID ).
+	AuditTargetWebhook = "webhook_subscription"
+)
+
+// ---------------- Audit Settings ----------------
+
+const (
+	AUDIT_MASKED_FIELDS = "AUDIT_MASKED_FIELDS" // Comma Separated JSON Field Names To Mask.
+
+	AuditDefaultMaskedFields = "email,date_of_birth,guardian_email"
+	AuditAnonymousActor      = "anonymous"
+	AuditMaskValue           = "***"
+)
@@ -0,0 +1,109 @@
+package constants
+
+// ---------------- Authentication Settings ----------------
+
+const (
+	AUTH_DISABLED     = "AUTH_DISABLED"     // "true" Turns Authentication Off ( Local Development Only ).
+	AUTH_BYPASS_PATHS = "AUTH_BYPASS_PATHS" // Comma Separated Paths; A Trailing "*" Matches A Prefix.
+
+	JWT_HMAC_KEY_FILE       = "JWT_HMAC_KEY_FILE"       // Shared Secret For HS256.
+	JWT_RSA_PUBLIC_KEY_FILE = "JWT_RSA_PUBLIC_KEY_FILE" // PEM Public Key For RS256.
+	JWT_JWKS_FILE           = "JWT_JWKS_FILE"           // Local JWKS Document ( Keys Selected By "kid" ).
+	JWT_ISSUER              = "JWT_ISSUER"
+	JWT_AUDIENCE            = "JWT_AUDIENCE"
+	JWT_LEEWAY              = "JWT_LEEWAY" // Allowed Clock Skew.
+
+	CORS_ALLOWED_ORIGINS = "CORS_ALLOWED_ORIGINS" // Comma Separated, "*" Allows Any Origin ( Default: None, Same Origin Only ).
+	TRUSTED_PROXIES      = "TRUSTED_PROXIES"      // Comma Separated IPs / CIDRs Whose X-Forwarded-For Is Believed ( Default: None ).
+
+	DefaultAuthBypassPaths    = "/health,/swagger/*"
+	DefaultJWTLeeway          = "30s"
+	DefaultCORSAllowedOrigins = ""
+)
+
+// ---------------- Authentication Tokens ----------------
+
+const (
+	HeaderAuthorization   = "Authorization"
+	HeaderWWWAuthenticate = "WWW-Authenticate"
+	BearerPrefix          = "Bearer "
+
+	JWTAlgorithmHS256 = "HS256"
+	JWTAlgorithmRS256 = "RS256"
+
+	ContextKeyClaims = "auth.claims" // Gin Context Key Holding The Verified Claims.
+)
+
+// ---------------- API Keys ----------------
+
+const (
+	HeaderAPIKey = "X-API-Key"
+
+	APIKeyPrefix       = "bt_"     // Keys Look Like bt_<8 hex>_<secret>; "bt_<8 hex>" Is Stored In Clear.
+	APIKeyPrefixBytes  = 4         // Random Bytes In The Visible Prefix.
+	APIKeySecretBytes  = 32        // Random Bytes In The Secret Part.
+	APIKeyActorPrefix  = "apikey:" // Audit Actor For Key Callers, e.g. "apikey:bt_1a2b3c4d".
+	MaxAPIKeyNameChars = 128
+)
+
+// ---------------- Accounts ( Password Login ) ----------------
+
+const (
+	JWT_RSA_PRIVATE_KEY_FILE = "JWT_RSA_PRIVATE_KEY_FILE" // PEM Private Key; When Set, Issued Tokens Use RS256 Instead Of HS256.
+
+	AUTH_ACCESS_TOKEN_TTL   = "AUTH_ACCESS_TOKEN_TTL"
+	AUTH_REFRESH_TOKEN_TTL  = "AUTH_REFRESH_TOKEN_TTL"
+	AUTH_LOCKOUT_THRESHOLD  = "AUTH_LOCKOUT_THRESHOLD"  // Failed Logins Before The Account Is Locked.
+	AUTH_LOCKOUT_BASE_DELAY = "AUTH_LOCKOUT_BASE_DELAY" // First Lock, Doubled On Every Further Failure.
+	AUTH_LOCKOUT_MAX_DELAY  = "AUTH_LOCKOUT_MAX_DELAY"
+
+	DefaultAccessTokenTTL   = "15m"
+	DefaultRefreshTokenTTL  = "720h"
+	DefaultLockoutThreshold = 5
+	DefaultLockoutBaseDelay = "1m"
+	DefaultLockoutMaxDelay  = "1h"
+	RefreshTokenBytes       = 32
+	MinPasswordChars        = 10
+	MaxPasswordChars        = 128
+	TokenTypeBearer         = "Bearer"
+	PublicAuthPaths         = "/api/v1/auth/register,/api/v1/auth/login,/api/v1/auth/refresh,/api/v1/auth/logout,/api/v1/auth/mfa/verify,/api/v1/users/verify,/api/v1/consents/grant" // Always Bypassed.
+	PasswordHashAlgorithm   = "argon2id"
+	Argon2Memory            = 19 * 1024 // KiB ( OWASP Baseline ).
+	Argon2Iterations        = 2
+	Argon2Parallelism       = 1
+	Argon2SaltBytes         = 16
+	Argon2KeyBytes          = 32
+)
+
+// ---------------- Multi-Factor Authentication ----------------
+
+const (
+	MFA_ISSUER = "MFA_ISSUER" // Issuer Shown In Authenticator Apps.
+
+	DefaultMFAIssuer        = "backend-task"
+	TOTPPeriodSeconds       = 30
+	TOTPSkewSteps           = 1 // Codes From One Step Before / After Are Accepted ( Clock Drift ).
+	TOTPDigits              = 6
+	RecoveryCodeCount       = 10
+	RecoveryCodeBytes       = 5 // Rendered As 8 Base32 Characters, e.g. "abcd-efgh".
+	MFAChallengeTTL         = 5 * 60
+	MFAChallengeMaxAttempts = 5
+
+	// Authentication Method References ( RFC 8176 "amr" Claim ) :
+	AMRPassword = "pwd"
+	AMRMFA      = "mfa"
+	AMROTP      = "otp"
+)
+
+// MFARequiredPermissions Are Refused To Tokens Without An MFA Claim ( Admin-Only Actions ) :
+var MFARequiredPermissions = map[string]bool{
+	PermissionUsersErase:     true,
+	PermissionGroupsManage:   true,
+	PermissionAuditRead:      true,
+	PermissionWebhooksManage: true,
+	PermissionAPIKeysManage:  true,
+	PermissionAccountsManage: true,
+	PermissionConsentsManage: true,
+	PermissionUsersStatus:    true,
+	PermissionUsersMerge:     true,
+}
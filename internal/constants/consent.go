@@ -0,0 +1,18 @@
+package constants
+
+// ---------------- Guardian Consent ----------------
+
+const (
+	CONSENT_TTL = "CONSENT_TTL" // How Long A Consent Link Stays Valid.
+
+	ConsentStatusPending = "pending"
+	ConsentStatusGranted = "granted"
+	ConsentStatusRevoked = "revoked"
+
+	ConsentMethodEmailLink = "email_link" // Guardian Opened The Link Sent To Them.
+
+	DefaultConsentTTL    = "168h"
+	ConsentTokenBytes    = 32
+	ConsentGrantPath     = "/api/v1/consents/grant"
+	MaxConsentReasonSize = 512
+)
@@ -6,3 +6,44 @@ const (
 	DriverPostgres = "postgres"
 	DriverSqlite   = "sqlite"
 )
+
+// ---------------- Database Constraints ----------------
+
+const (
+	// PostgresUniqueViolation Is The SQLSTATE Postgres Reports For A Unique Constraint Violation.
+	PostgresUniqueViolation = "23505"
+
+	// Legacy GORM Index On email Alone ( Before Tenants ), Which Would Keep Emails Unique Across Tenants.
+	IndexUsersEmail = "idx_users_email"
+
+	// Legacy GORM Index On ( tenant_id, email ), Replaced By The Case-Insensitive One Below.
+	IndexUsersTenantEmail = "idx_users_tenant_email"
+
+	// Unique Per Tenant Regardless Of Case ( Works On Postgres And SQLite ).
+	IndexUsersTenantEmailLower = "idx_users_tenant_email_lower"
+
+	// Unique Per Tenant By Canonical Form, So Provider Aliases ( Gmail Dots, +tags ) Collide Too.
+	IndexUsersTenantEmailCanonical = "idx_users_tenant_email_canonical"
+
+	// Holds The Pre-Tenant groups Table While SQLite Rebuilds It With The ( tenant_id, name ) Primary Key.
+	TableGroupsLegacy = "groups_legacy"
+
+	// Numbers Outbox Events In Insert Order ( A Postgres Sequence, Its Trigger Function And The Trigger Share The Name ).
+	OutboxSequence = "outbox_events_sequence"
+)
+
+// ---------------- User Search ----------------
+
+const (
+	// Postgres: Trigram ( pg_trgm ) Indexes For Fuzzy And Partial Matches, Plus A Full-Text Index For Whole Words.
+	IndexUsersNameTrgm   = "idx_users_name_trgm"
+	IndexUsersEmailTrgm  = "idx_users_email_trgm"
+	IndexUsersSearchText = "idx_users_search_text"
+
+	// SQLite: FTS5 Table ( Trigram Tokenizer ) Mirroring users.name And users.email, Kept In Sync By Triggers.
+	TableUsersFTS = "users_fts"
+
+	MinSearchQueryLength  = 3   // Characters; Shorter Terms Have No Trigrams To Match.
+	MaxSearchQueryLength  = 100 // Characters.
+	SearchMinTrigramShare = 0.5 // SQLite: Share Of The Query's Trigrams A Row Must Contain.
+)
@@ -0,0 +1,17 @@
+package constants
+
+// ---------------- Duplicate Detection ----------------
+
+const (
+	// Score Weights ( Summing To 1 ) Of The Signals That Two Users Are One Person :
+	DuplicateNameWeight  = 0.5 // Times The Name Similarity ( 0..1 ).
+	DuplicateBirthWeight = 0.3 // Identical Date Of Birth.
+	DuplicateEmailWeight = 0.2 // Identical Canonical Email Local Part On Different Domains.
+
+	DuplicateSimilarName     = 0.85 // Name Similarity From Which "similar_name" Is Reported.
+	DefaultDuplicateMinScore = 0.7
+
+	DuplicateReasonSimilarName    = "similar_name"
+	DuplicateReasonSameBirth      = "same_date_of_birth"
+	DuplicateReasonSameEmailLocal = "same_email_local_part"
+)
@@ -0,0 +1,31 @@
+package constants
+
+// ---------------- Domain Event Types ----------------
+
+const (
+	EventUserCreated      = "user.created"
+	EventUserUpdated      = "user.updated"
+	EventUserGroupChanged = "user.group_changed"
+	EventUserMerged       = "user.merged"
+	EventGroupCreated     = "group.created"
+	EventGroupFull        = "group.full"
+)
+
+// ---------------- Event Settings ----------------
+
+const (
+	EventEnvelopeVersion = 1 // Bumped On Breaking Payload Changes.
+
+	EVENT_PUBLISHER      = "EVENT_PUBLISHER" // log | file | memory
+	EVENT_SINK_FILE      = "EVENT_SINK_FILE"
+	OUTBOX_POLL_INTERVAL = "OUTBOX_POLL_INTERVAL"
+	OUTBOX_BATCH_SIZE    = "OUTBOX_BATCH_SIZE"
+
+	EventPublisherLog    = "log"
+	EventPublisherFile   = "file"
+	EventPublisherMemory = "memory"
+
+	DefaultEventSinkFile      = "events.ndjson"
+	DefaultOutboxPollInterval = "1s"
+	DefaultOutboxBatchSize    = 100
+)
@@ -13,5 +13,5 @@ const (
 // ---------------- Group Settings ----------------
 
 const (
-	GroupCapacity = 3 // Maximum Users Per Group.
+	GroupCapacity = 3 // Maximum Users Per Group ( Default, Overridable Per Tenant ).
 )
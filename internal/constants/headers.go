@@ -0,0 +1,8 @@
+package constants
+
+// ---------------- HTTP Headers ----------------
+
+const (
+	HeaderRequestID = "X-Request-ID"
+	HeaderLocation  = "Location"
+)
@@ -3,9 +3,15 @@ package constants
 // ---------------- HTTP Status Codes ----------------
 
 const (
-	StatusOK                  = 200
-	StatusCreated             = 201
-	StatusBadRequest          = 400
-	StatusNotFound            = 404
-	StatusInternalServerError = 500
+	StatusOK                   = 200
+	StatusCreated              = 201
+	StatusAccepted             = 202
+	StatusNoContent            = 204
+	StatusBadRequest           = 400
+	StatusUnauthorized         = 401
+	StatusForbidden            = 403
+	StatusNotFound             = 404
+	StatusConflict             = 409
+	StatusUnsupportedMediaType = 415
+	StatusInternalServerError  = 500
 )
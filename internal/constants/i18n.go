@@ -0,0 +1,28 @@
+package constants
+
+// ---------------- Localization ----------------
+
+const (
+	HeaderAcceptLanguage  = "Accept-Language"
+	HeaderContentLanguage = "Content-Language"
+
+	LanguageEnglish = "en"
+	LanguageArabic  = "ar"
+	LanguageGerman  = "de"
+
+	DefaultLanguage = LanguageEnglish // Fallback For Unsupported Languages And Missing Messages.
+)
+
+// Message Keys Besides Error Codes ( Which Are Keys Themselves ). Placeholders Are Written {name} :
+const (
+	MessageTitlePrefix = "title." // Followed By The HTTP Status, e.g. "title.404".
+	MessageRulePrefix  = "rule."  // Followed By The Validation Rule; Placeholders {field} And {param}.
+
+	MessageEmailVerificationSubject = "email.verification.subject"
+	MessageEmailVerificationBody    = "email.verification.body" // {name}, {link}, {ttl}
+	MessageConsentEmailSubject      = "email.consent.subject"
+	MessageConsentEmailBody         = "email.consent.body" // {guardian}, {name}, {email}, {link}, {ttl}
+	MessageEmailTakenSubject        = "email.taken.subject"
+	MessageEmailTakenBody           = "email.taken.body" // {email}
+	MessageRegistrationAccepted     = "registration.accepted"
+)
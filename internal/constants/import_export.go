@@ -0,0 +1,45 @@
+package constants
+
+// ---------------- Import Row Status ----------------
+
+const (
+	ImportRowPending = "pending" // Not Processed Yet ( Async Jobs ).
+	ImportRowValid   = "valid"   // Passed Validation ( Dry Run ).
+	ImportRowInvalid = "invalid" // Failed Validation, Never Written.
+	ImportRowCreated = "created" // Committed.
+	ImportRowFailed  = "failed"  // Valid, But Its Batch Could Not Be Committed.
+)
+
+// ---------------- Import File Format ----------------
+
+const (
+	ContentTypeCSV = "text/csv"
+
+	ImportColumnName        = "name"
+	ImportColumnEmail       = "email"
+	ImportColumnDateOfBirth = "date_of_birth"
+
+	// Optional, For Minors ( See GuardianReq ) :
+	ImportColumnGuardianName  = "guardian_name"
+	ImportColumnGuardianEmail = "guardian_email"
+)
+
+// ---------------- Import Settings ----------------
+
+const (
+	DefaultImportBatchSize = 100
+	ImportMaxRows          = 100000
+	ImportMaxBodyBytes     = 32 << 20 // 32 MiB.
+)
+
+// ---------------- Export Formats ----------------
+
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+
+	ContentTypeNDJSON = "application/x-ndjson"
+	ContentTypeGzip   = "application/gzip"
+
+	DefaultExportBatchSize = 500 // Rows Fetched Per Query While Streaming.
+)
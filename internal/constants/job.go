@@ -0,0 +1,30 @@
+package constants
+
+// ---------------- Job Kinds ----------------
+
+const (
+	JobKindUserImport = "user_import"
+)
+
+// ---------------- Job States ----------------
+
+const (
+	JobQueued    = "queued"
+	JobRunning   = "running"
+	JobCompleted = "completed" // All Rows Processed ( Some May Have Failed ).
+	JobFailed    = "failed"    // Aborted By An Unexpected Error.
+	JobCancelled = "cancelled"
+)
+
+// ---------------- Job Settings ----------------
+
+const (
+	JOB_WORKERS       = "JOB_WORKERS"
+	JOB_POLL_INTERVAL = "JOB_POLL_INTERVAL"
+	JOB_CHUNK_SIZE    = "JOB_CHUNK_SIZE"
+
+	DefaultJobWorkers      = 1
+	DefaultJobPollInterval = "1s"
+	DefaultJobChunkSize    = 500 // Rows Per Checkpoint ( One Transaction Each ).
+	JobRowPageSize         = 500 // Rows Per Insert / Error File Page.
+)
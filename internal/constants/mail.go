@@ -0,0 +1,40 @@
+package constants
+
+// ---------------- Mail Settings ----------------
+
+const (
+	MAIL_DRIVER     = "MAIL_DRIVER" // smtp | file | memory
+	MAIL_FROM       = "MAIL_FROM"
+	MAIL_SINK_FILE  = "MAIL_SINK_FILE"
+	SMTP_HOST       = "SMTP_HOST"
+	SMTP_PORT       = "SMTP_PORT"
+	SMTP_USERNAME   = "SMTP_USERNAME"
+	SMTP_PASSWORD   = "SMTP_PASSWORD"
+	PUBLIC_BASE_URL = "PUBLIC_BASE_URL" // Used To Build Links In Emails.
+
+	MailDriverSMTP   = "smtp"
+	MailDriverFile   = "file"
+	MailDriverMemory = "memory"
+
+	DefaultMailFrom      = "no-reply@backend-task.local"
+	DefaultMailSinkFile  = "mail.ndjson"
+	DefaultSMTPPort      = "587"
+	DefaultPublicBaseURL = "http://localhost:8080"
+)
+
+// ---------------- Email Verification ----------------
+
+const (
+	EMAIL_VERIFICATION_KEY = "EMAIL_VERIFICATION_KEY" // HMAC Key For Verification Tokens.
+	EMAIL_VERIFICATION_TTL = "EMAIL_VERIFICATION_TTL"
+
+	DefaultEmailVerificationTTL = "24h"
+	EmailVerificationPath       = "/api/v1/users/verify"
+)
+
+// ---------------- Email Addresses ----------------
+
+const (
+	EMAIL_FOLD_PROVIDER_ALIASES   = "EMAIL_FOLD_PROVIDER_ALIASES"   // "false" Compares Gmail Dots / +tags Literally.
+	DISPOSABLE_EMAIL_DOMAINS_FILE = "DISPOSABLE_EMAIL_DOMAINS_FILE" // One Domain Per Line; Empty = No Blocklist.
+)
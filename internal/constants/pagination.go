@@ -0,0 +1,9 @@
+package constants
+
+// ---------------- Pagination Settings ----------------
+
+const (
+	DefaultPage     = 1
+	DefaultPageSize = 50
+	MaxPageSize     = 500
+)
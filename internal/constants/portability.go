@@ -0,0 +1,22 @@
+package constants
+
+// ---------------- Data Portability Export ----------------
+
+const (
+	EXPORT_SIGNING_KEY = "EXPORT_SIGNING_KEY" // HMAC Key For Signed Archives.
+
+	ExportFormatJSON = "json"
+	ExportFormatZip  = "zip"
+	ContentTypeZip   = "application/zip"
+
+	DataExportBundleFile    = "bundle.json"
+	DataExportManifestFile  = "manifest.json"
+	DataExportSignatureFile = "manifest.sig"
+	DataExportAlgorithm     = "HMAC-SHA256"
+)
+
+// ---------------- Group Membership History ----------------
+
+const (
+	MembershipReasonAllocated = "allocated" // Seat Taken On Creation.
+)
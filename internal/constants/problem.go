@@ -0,0 +1,20 @@
+package constants
+
+// ---------------- Problem Details ( RFC 7807 ) ----------------
+
+const (
+	HeaderContentType      = "Content-Type"
+	ContentTypeProblemJSON = "application/problem+json"
+	ProblemTypePrefix      = "urn:backend-task:problem:" // Followed By The Error Code In Kebab Case, e.g. "user-email-taken".
+)
+
+// Error Codes For Errors Without A Registry Entry, By HTTP Status :
+const (
+	ErrorCodeBadRequest           = "BAD_REQUEST"
+	ErrorCodeUnauthorized         = "UNAUTHORIZED"
+	ErrorCodeForbidden            = "FORBIDDEN"
+	ErrorCodeNotFound             = "NOT_FOUND"
+	ErrorCodeConflict             = "CONFLICT"
+	ErrorCodeUnsupportedMediaType = "UNSUPPORTED_MEDIA_TYPE"
+	ErrorCodeInternal             = "INTERNAL_ERROR"
+)
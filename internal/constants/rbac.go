@@ -0,0 +1,56 @@
+package constants
+
+// ---------------- Roles ----------------
+
+const (
+	RoleViewer = "viewer" // Reads Users & Streams Events; Edits Only Their Own Record.
+	RoleEditor = "editor" // Viewer + Creates, Updates, Imports & Exports Users.
+	RoleAdmin  = "admin"  // Everything, Including Audit, Webhooks, API Keys & Group Administration.
+	RoleMember = "member" // Self-Service Account: Reads & Edits Only Their Own Record.
+
+	RBAC_CUSTOM_ROLES = "RBAC_CUSTOM_ROLES" // JSON Object, e.g. {"support":["users:read","audit:read"]}.
+)
+
+// ---------------- Permissions ----------------
+
+const (
+	PermissionUsersRead       = "users:read"
+	PermissionUsersReadSelf   = "users:read:self" // GET Of The Caller's Own Record ( Token Subject = User ID ).
+	PermissionUsersCreate     = "users:create"
+	PermissionUsersUpdateSelf = "users:update:self" // PATCH Of The Caller's Own Record ( Token Subject = User ID ).
+	PermissionUsersUpdate     = "users:update"      // PATCH Of Any Record.
+	PermissionUsersImport     = "users:import"
+	PermissionUsersExport     = "users:export"
+	PermissionUsersExportSelf = "users:export:self" // Data Portability Export Of The Caller's Own Record.
+	PermissionUsersErase      = "users:erase"
+	PermissionGroupsManage    = "groups:manage" // Moving Users Between Groups, Rebalancing.
+	PermissionAuditRead       = "audit:read"
+	PermissionEventsRead      = "events:read"
+	PermissionWebhooksManage  = "webhooks:manage"
+	PermissionJobsManage      = "jobs:manage"
+	PermissionAPIKeysManage   = "apikeys:manage"
+	PermissionAccountsManage  = "accounts:manage" // Assigning Roles To Password Accounts.
+	PermissionConsentsManage  = "consents:manage" // Querying And Revoking Guardian Consents.
+	PermissionUsersStatus     = "users:status"    // Suspending, Deactivating And Reactivating Users.
+	PermissionUsersMerge      = "users:merge"     // Finding Duplicate Users And Merging Them.
+)
+
+// AllPermissions Lists Every Permission Known To The API ( Custom Roles May Only Use These ) :
+var AllPermissions = []string{
+	PermissionUsersRead, PermissionUsersReadSelf, PermissionUsersCreate, PermissionUsersUpdateSelf, PermissionUsersUpdate,
+	PermissionUsersImport, PermissionUsersExport, PermissionUsersExportSelf, PermissionUsersErase, PermissionGroupsManage,
+	PermissionAuditRead, PermissionEventsRead, PermissionWebhooksManage, PermissionJobsManage,
+	PermissionAPIKeysManage, PermissionAccountsManage, PermissionConsentsManage, PermissionUsersStatus,
+	PermissionUsersMerge,
+}
+
+// BuiltInRoles Maps Each Built-In Role To Its Permissions ( Admin Is Granted Everything ) :
+var BuiltInRoles = map[string][]string{
+	RoleMember: {PermissionUsersReadSelf, PermissionUsersUpdateSelf, PermissionUsersExportSelf},
+	RoleViewer: {PermissionUsersRead, PermissionUsersReadSelf, PermissionUsersUpdateSelf, PermissionUsersExportSelf, PermissionEventsRead},
+	RoleEditor: {
+		PermissionUsersRead, PermissionUsersReadSelf, PermissionUsersUpdateSelf, PermissionUsersExportSelf, PermissionEventsRead,
+		PermissionUsersCreate, PermissionUsersUpdate, PermissionUsersImport, PermissionUsersExport, PermissionJobsManage,
+	},
+	RoleAdmin: AllPermissions,
+}
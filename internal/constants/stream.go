@@ -0,0 +1,16 @@
+package constants
+
+// ---------------- Event Stream ( SSE ) Settings ----------------
+
+const (
+	SSE_HEARTBEAT_INTERVAL = "SSE_HEARTBEAT_INTERVAL"
+	SSE_REPLAY_BUFFER      = "SSE_REPLAY_BUFFER"
+
+	HeaderLastEventID = "Last-Event-ID"
+
+	DefaultSSEHeartbeatInterval = "15s"
+	DefaultSSEReplayBuffer      = 1000
+	SSESubscriberBuffer         = 64 // Events Queued Per Client Before It Is Considered Too Slow.
+	SSEReplayLimit              = 1000
+	SSERetryMillis              = 3000
+)
@@ -0,0 +1,21 @@
+package constants
+
+// ---------------- Multi-Tenancy ----------------
+
+const (
+	TENANT_SETTINGS = "TENANT_SETTINGS" // JSON Object Of Per-Tenant Overrides, e.g. {"acme":{"group_capacity":5}}.
+
+	HeaderTenantID   = "X-Tenant-ID"
+	QueryTenantID    = "tenant"    // Used By Emailed Links, Which Cannot Carry Headers.
+	ColumnTenantID   = "tenant_id" // Column Added To Every Tenant-Scoped Table.
+	DefaultTenantID  = "default"   // Requests Without A Tenant ( And Rows Created Before Multi-Tenancy ).
+	MaxTenantIDChars = 64
+)
+
+// ---------------- Age Bands ( Defaults, Overridable Per Tenant ) ----------------
+
+const (
+	DefaultChildMaxAge = 12 // 0-12 Is child.
+	DefaultTeenMaxAge  = 17 // 13-17 Is teen.
+	DefaultAdultMaxAge = 64 // 18-64 Is adult, Older Is senior.
+)
@@ -0,0 +1,24 @@
+package constants
+
+// ---------------- User Status ----------------
+
+const (
+	UserStatusPendingConsent = "pending_consent" // Minor Waiting For Guardian Consent, Unallocated.
+	UserStatusActive         = "active"          // Allocated To A Group.
+	UserStatusSuspended      = "suspended"       // Blocked ( e.g. Abuse ); Keeps Their Seat Unless It Was Released.
+	UserStatusDeactivated    = "deactivated"     // Closed; Never Holds A Seat.
+
+	MaxStatusReasonSize = 512
+)
+
+// UserStatusTransitions Lists The Statuses Each Status May Move To Through The Status Endpoints.
+// pending_consent -> active Happens Only Through Guardian Consent :
+var UserStatusTransitions = map[string][]string{
+	UserStatusPendingConsent: {UserStatusDeactivated},
+	UserStatusActive:         {UserStatusSuspended, UserStatusDeactivated},
+	UserStatusSuspended:      {UserStatusActive, UserStatusDeactivated},
+	UserStatusDeactivated:    {UserStatusActive},
+}
+
+// ListedUserStatuses Are Returned By User Listings Unless A Status Is Asked For Explicitly :
+var ListedUserStatuses = []string{UserStatusPendingConsent, UserStatusActive}
@@ -0,0 +1,12 @@
+package constants
+
+// ---------------- Custom Validation Rules ( `binding` Tags ) ----------------
+
+const (
+	RuleNotBlank = "notblank" // String With At Least One Non-Space Character.
+	RulePastDate = "pastdate" // yyyy-mm-dd Date That Is Not In The Future.
+
+	// Checked By The Services, Not By A Tag :
+	RuleNotDisposable = "notdisposable" // Email Whose Domain Is Not On The Disposable Blocklist.
+	RuleUnique        = "unique"        // Email Not Used By Another User Or An Earlier Element Of The Same Request.
+)
@@ -0,0 +1,37 @@
+package constants
+
+// ---------------- Webhook Delivery Status ----------------
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryDead      = "dead" // Dead Letter : Retries Exhausted.
+)
+
+// ---------------- Webhook Headers ----------------
+
+const (
+	HeaderWebhookSignature = "X-Webhook-Signature"
+	HeaderWebhookEvent     = "X-Webhook-Event"
+	HeaderWebhookEventID   = "X-Webhook-Event-ID"
+	HeaderWebhookDelivery  = "X-Webhook-Delivery"
+)
+
+// ---------------- Webhook Settings ----------------
+
+const (
+	WEBHOOK_MAX_ATTEMPTS  = "WEBHOOK_MAX_ATTEMPTS"
+	WEBHOOK_BASE_BACKOFF  = "WEBHOOK_BASE_BACKOFF"
+	WEBHOOK_MAX_BACKOFF   = "WEBHOOK_MAX_BACKOFF"
+	WEBHOOK_TIMEOUT       = "WEBHOOK_TIMEOUT"
+	WEBHOOK_POLL_INTERVAL = "WEBHOOK_POLL_INTERVAL"
+
+	WebhookAllEvents           = "*"
+	WebhookMinSecretLength     = 16
+	DefaultWebhookMaxAttempts  = 8
+	DefaultWebhookBaseBackoff  = "30s"
+	DefaultWebhookMaxBackoff   = "1h"
+	DefaultWebhookTimeout      = "10s"
+	DefaultWebhookPollInterval = "2s"
+	DefaultWebhookBatchSize    = 50
+)
@@ -3,11 +3,17 @@ package db
 import (
 	"fmt"
 	"os"
+	"strings"
 
+	auditModels "backend-task/internal/audit/models"
+	authModels "backend-task/internal/auth/models"
 	"backend-task/internal/config"
 	constants "backend-task/internal/constants"
+	eventModels "backend-task/internal/events/models"
+	jobModels "backend-task/internal/job/models"
 	"backend-task/internal/user/models"
 	"backend-task/internal/utils"
+	webhookModels "backend-task/internal/webhook/models"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
@@ -58,12 +64,205 @@ func open(dsn, driverName string) (*gorm.DB, error) {
 
 func autoMigrate(db *gorm.DB) {
 
-	if err := db.AutoMigrate(&models.User{}, &models.Group{}); err != nil {
+	if err := Migrate(db); err != nil {
 
 		utils.Fatal(fmt.Sprintf("%s: %v", utils.ErrMigrationFailed, err))
 	}
 }
 
+// Migrate Creates Or Updates All Tables Owned By The Application :
+func Migrate(db *gorm.DB) error {
+
+	err := db.AutoMigrate(
+		&models.User{}, &models.Group{}, &models.GroupMembership{}, &models.GuardianConsent{}, &auditModels.AuditEntry{}, &eventModels.OutboxEvent{},
+		&webhookModels.WebhookSubscription{}, &webhookModels.WebhookDelivery{}, &webhookModels.WebhookAttempt{},
+		&jobModels.Job{}, &jobModels.JobRow{}, &authModels.APIKey{},
+		&authModels.Credential{}, &authModels.Session{}, &authModels.RefreshToken{},
+		&authModels.MFAFactor{}, &authModels.RecoveryCode{}, &authModels.MFAChallenge{},
+	)
+
+	if err != nil {
+
+		return err
+	}
+
+	if err := migrateGroupPrimaryKey(db); err != nil {
+
+		return err
+	}
+
+	if err := migrateUserEmailIndexes(db); err != nil {
+
+		return err
+	}
+
+	if err := migrateOutboxSequence(db); err != nil {
+
+		return err
+	}
+
+	return migrateUserSearch(db)
+}
+
+// migrateUserEmailIndexes Enforces Email Uniqueness Per Tenant In The Database, Ignoring Case And
+// Provider Aliases. GORM Tags Cannot Declare Expression Indexes, So The Indexes Are Created Here :
+func migrateUserEmailIndexes(db *gorm.DB) error {
+
+	// Indexes Left By Earlier Schemas; The Pre-Tenant One Would Still Make Emails Unique Across Tenants :
+	for _, legacy := range []string{constants.IndexUsersEmail, constants.IndexUsersTenantEmail} {
+
+		if !db.Migrator().HasIndex(&models.User{}, legacy) {
+
+			continue
+		}
+
+		if err := db.Migrator().DropIndex(&models.User{}, legacy); err != nil {
+
+			return err
+		}
+	}
+
+	if err := db.Exec(fmt.Sprintf("CREATE UNIQUE INDEX IF NOT EXISTS %s ON users (tenant_id, lower(email))", constants.IndexUsersTenantEmailLower)).Error; err != nil {
+
+		return err
+	}
+
+	// Rows Written Before Canonical Forms Existed Start From Their Lower-Cased Address ( Already Unique );
+	// Provider Aliases Are Folded The Next Time Their Email Changes :
+	if err := db.Exec("UPDATE users SET email_canonical = lower(email) WHERE email_canonical = ''").Error; err != nil {
+
+		return err
+	}
+
+	return db.Exec(fmt.Sprintf("CREATE UNIQUE INDEX IF NOT EXISTS %s ON users (tenant_id, email_canonical)", constants.IndexUsersTenantEmailCanonical)).Error
+}
+
+// migrateGroupPrimaryKey Widens The Primary Key Of A Pre-Tenant groups Table ( name ) To ( tenant_id, name ),
+// Which AutoMigrate Cannot Change. Existing Rows Already Belong To The Default Tenant :
+func migrateGroupPrimaryKey(db *gorm.DB) error {
+
+	switch db.Dialector.Name() {
+	case constants.DriverPostgres:
+		return migratePostgresGroupPrimaryKey(db)
+
+	case constants.DriverSqlite:
+		return migrateSqliteGroupPrimaryKey(db)
+
+	default:
+		return nil
+	}
+}
+
+func migratePostgresGroupPrimaryKey(db *gorm.DB) error {
+
+	var constraint string
+	err := db.Raw(`SELECT tc.constraint_name FROM information_schema.table_constraints tc
+		WHERE tc.table_schema = current_schema() AND tc.table_name = 'groups' AND tc.constraint_type = 'PRIMARY KEY'
+		AND NOT EXISTS ( SELECT 1 FROM information_schema.key_column_usage kcu
+			WHERE kcu.constraint_name = tc.constraint_name AND kcu.table_schema = tc.table_schema AND kcu.column_name = 'tenant_id' )`).Scan(&constraint).Error
+	if err != nil || constraint == "" {
+
+		return err
+	}
+
+	return db.Exec(fmt.Sprintf(`ALTER TABLE groups DROP CONSTRAINT %q, ADD PRIMARY KEY (tenant_id, name)`, constraint)).Error
+}
+
+// migrateSqliteGroupPrimaryKey Rebuilds The Table, As SQLite Cannot Alter A Primary Key In Place :
+func migrateSqliteGroupPrimaryKey(db *gorm.DB) error {
+
+	var keyed int
+	if err := db.Raw("SELECT pk FROM pragma_table_info('groups') WHERE name = 'tenant_id'").Scan(&keyed).Error; err != nil || keyed > 0 {
+
+		return err
+	}
+
+	columns, err := db.Migrator().ColumnTypes(&models.Group{})
+	if err != nil {
+
+		return err
+	}
+
+	names := make([]string, len(columns))
+	for index, column := range columns {
+
+		names[index] = fmt.Sprintf("%q", column.Name())
+	}
+	list := strings.Join(names, ", ")
+
+	return db.Transaction(func(tx *gorm.DB) error {
+
+		if err := tx.Migrator().RenameTable(&models.Group{}, constants.TableGroupsLegacy); err != nil {
+
+			return err
+		}
+
+		// Index Names Are Global In SQLite And Follow The Renamed Table, So They Must Go Before CreateTable :
+		var indexes []string
+		if err := tx.Raw("SELECT name FROM sqlite_master WHERE type = 'index' AND tbl_name = ? AND sql IS NOT NULL", constants.TableGroupsLegacy).Scan(&indexes).Error; err != nil {
+
+			return err
+		}
+
+		for _, index := range indexes {
+
+			if err := tx.Exec(fmt.Sprintf("DROP INDEX %q", index)).Error; err != nil {
+
+				return err
+			}
+		}
+
+		if err := tx.Migrator().CreateTable(&models.Group{}); err != nil {
+
+			return err
+		}
+
+		if err := tx.Exec(fmt.Sprintf("INSERT INTO groups (%[1]s) SELECT %[1]s FROM %[2]s", list, constants.TableGroupsLegacy)).Error; err != nil {
+
+			return err
+		}
+
+		return tx.Migrator().DropTable(constants.TableGroupsLegacy)
+	})
+}
+
+// migrateOutboxSequence Numbers Outbox Events On Insert, So Readers Page By A Cursor That Never Ties
+// ( Timestamps Do ). Events Stored Before The Column Existed Are Numbered In Their Original Order :
+func migrateOutboxSequence(db *gorm.DB) error {
+
+	var statements []string
+	switch db.Dialector.Name() {
+	case constants.DriverPostgres:
+		statements = []string{
+			fmt.Sprintf("CREATE SEQUENCE IF NOT EXISTS %s OWNED BY outbox_events.sequence", constants.OutboxSequence),
+			fmt.Sprintf("UPDATE outbox_events SET sequence = numbered.next FROM ( SELECT id, nextval('%s') AS next FROM"+
+				" ( SELECT id FROM outbox_events WHERE sequence IS NULL ORDER BY occurred_at, id ) AS pending ) AS numbered"+
+				" WHERE outbox_events.id = numbered.id", constants.OutboxSequence),
+			fmt.Sprintf("CREATE OR REPLACE FUNCTION %[1]s() RETURNS trigger AS $$ BEGIN NEW.sequence := nextval('%[1]s'); RETURN NEW; END $$ LANGUAGE plpgsql", constants.OutboxSequence),
+			fmt.Sprintf("DROP TRIGGER IF EXISTS %s ON outbox_events", constants.OutboxSequence),
+			fmt.Sprintf("CREATE TRIGGER %[1]s BEFORE INSERT ON outbox_events FOR EACH ROW EXECUTE FUNCTION %[1]s()", constants.OutboxSequence),
+		}
+
+	case constants.DriverSqlite:
+		// Writers Are Serialized, So The Next Number Cannot Be Taken Twice :
+		statements = []string{
+			"UPDATE outbox_events SET sequence = rowid WHERE sequence IS NULL",
+			fmt.Sprintf("CREATE TRIGGER IF NOT EXISTS %s AFTER INSERT ON outbox_events WHEN new.sequence IS NULL BEGIN"+
+				" UPDATE outbox_events SET sequence = ( SELECT COALESCE(MAX(sequence), 0) + 1 FROM outbox_events ) WHERE rowid = new.rowid; END", constants.OutboxSequence),
+		}
+	}
+
+	for _, statement := range statements {
+
+		if err := db.Exec(statement).Error; err != nil {
+
+			return err
+		}
+	}
+
+	return nil
+}
+
 func buildDSN(driver string) string {
 
 	if driver == constants.DriverSqlite {
@@ -0,0 +1,35 @@
+package db
+
+import (
+	"errors"
+
+	constants "backend-task/internal/constants"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mattn/go-sqlite3"
+	"gorm.io/gorm"
+)
+
+// IsUniqueViolation Reports Whether err Comes From A Unique Index Or Constraint,
+// Whether Or Not GORM Was Opened With TranslateError :
+func IsUniqueViolation(err error) bool {
+
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+
+		return true
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+
+		return pgErr.Code == constants.PostgresUniqueViolation
+	}
+
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+
+		return sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique
+	}
+
+	return false
+}
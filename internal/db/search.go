@@ -0,0 +1,224 @@
+package db
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	constants "backend-task/internal/constants"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ---------------- User Search ----------------
+
+// UserSearch Matches Users Against A Free-Text Term, Tolerating Typos And Partial Words.
+// The Engine Follows The Driver Picked In open: pg_trgm And tsvector On Postgres, FTS5 On SQLite.
+type UserSearch struct {
+	joins string
+	match clause.Expr
+	rank  clause.Expr
+}
+
+// NewUserSearch Prepares A Search For term ( Already Trimmed ) On gormDB's Engine :
+func NewUserSearch(gormDB *gorm.DB, term string) UserSearch {
+
+	term = strings.ToLower(term)
+
+	if gormDB.Dialector.Name() == constants.DriverPostgres {
+
+		return postgresUserSearch(term)
+	}
+
+	return sqliteUserSearch(term, gormDB.Migrator().HasTable(constants.TableUsersFTS))
+}
+
+// Match Scopes A users Query To Matching Rows ( Safe To Count ) :
+func (search UserSearch) Match(gormDB *gorm.DB) *gorm.DB {
+
+	if search.joins != "" {
+
+		gormDB = gormDB.Joins(search.joins)
+	}
+
+	return gormDB.Where(search.match)
+}
+
+// Rank Orders Matching Rows Best First :
+func (search UserSearch) Rank(gormDB *gorm.DB) *gorm.DB {
+
+	return gormDB.Order(clause.OrderBy{Expression: search.rank})
+}
+
+// postgresUserSearch Matches Whole Or Misspelled Words By Trigram Word Similarity ( pg_trgm ),
+// Any Substring By LIKE ( Trigram Indexed Too ) And Whole Words By Full Text, Ranked By The Best Of Them :
+func postgresUserSearch(term string) UserSearch {
+
+	pattern := "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(term) + "%"
+
+	return UserSearch{
+		match: clause.Expr{
+			SQL: "(lower(users.name) %> ? OR lower(users.email) %> ? OR lower(users.name) LIKE ? OR lower(users.email) LIKE ?" +
+				" OR to_tsvector('simple', users.name || ' ' || users.email) @@ plainto_tsquery('simple', ?))",
+			Vars: []any{term, term, pattern, pattern, term},
+		},
+		rank: clause.Expr{
+			SQL: "GREATEST(word_similarity(?, lower(users.name)), word_similarity(?, lower(users.email))," +
+				" ts_rank(to_tsvector('simple', users.name || ' ' || users.email), plainto_tsquery('simple', ?))) DESC, users.id",
+			Vars: []any{term, term, term},
+		},
+	}
+}
+
+// sqliteUserSearch Keeps Rows Containing Enough Of The Term's Trigrams, Most Trigrams First.
+// With FTS5 The Trigram Index Finds The Candidates ( Ties Broken By bm25 ); Without It Every Row Is Scanned :
+func sqliteUserSearch(term string, fts bool) UserSearch {
+
+	grams := searchGrams(term)
+
+	hits := make([]string, len(grams))
+	vars := make([]any, len(grams))
+	for index, gram := range grams {
+
+		hits[index] = "(instr(lower(users.name || ' ' || users.email), ?) > 0)"
+		vars[index] = gram
+	}
+
+	hitsSQL := "(" + strings.Join(hits, " + ") + ")"
+	needed := int(math.Ceil(float64(len(grams)) * constants.SearchMinTrigramShare))
+
+	search := UserSearch{
+		match: clause.Expr{SQL: hitsSQL + " >= ?", Vars: append(append([]any{}, vars...), needed)},
+		rank:  clause.Expr{SQL: hitsSQL + " DESC, users.id", Vars: vars},
+	}
+
+	query := ftsQuery(grams)
+	if !fts || query == "" {
+
+		return search
+	}
+
+	search.joins = fmt.Sprintf("JOIN %[1]s ON %[1]s.rowid = users.rowid", constants.TableUsersFTS)
+	search.match = clause.Expr{SQL: constants.TableUsersFTS + " MATCH ? AND " + search.match.SQL, Vars: append([]any{query}, search.match.Vars...)}
+	search.rank = clause.Expr{SQL: hitsSQL + " DESC, bm25(" + constants.TableUsersFTS + "), users.id", Vars: vars}
+
+	return search
+}
+
+// searchGrams Splits The Term Into The Distinct Trigrams Of Its Words; Words Under Three Characters Are Kept Whole :
+func searchGrams(term string) []string {
+
+	seen := map[string]bool{}
+	var grams []string
+	add := func(gram string) {
+
+		if !seen[gram] {
+
+			seen[gram] = true
+			grams = append(grams, gram)
+		}
+	}
+
+	for _, word := range strings.Fields(term) {
+
+		runes := []rune(word)
+		if len(runes) < 3 {
+
+			add(word)
+			continue
+		}
+
+		for index := 0; index+3 <= len(runes); index++ {
+
+			add(string(runes[index : index+3]))
+		}
+	}
+
+	return grams
+}
+
+// ftsQuery ORs The Trigrams As FTS5 Phrases ( The Trigram Tokenizer Cannot Match Shorter Strings ) :
+func ftsQuery(grams []string) string {
+
+	var phrases []string
+	for _, gram := range grams {
+
+		if len([]rune(gram)) == 3 {
+
+			phrases = append(phrases, `"`+strings.ReplaceAll(gram, `"`, `""`)+`"`)
+		}
+	}
+
+	return strings.Join(phrases, " OR ")
+}
+
+// ---------------- Search Migrations ----------------
+
+// migrateUserSearch Creates The Engine's Search Indexes. On SQLite The FTS5 Table Is Rebuilt From users
+// On Every Run ( Schema Changes May Recreate users ); Builds Without FTS5 Fall Back To Scanning :
+func migrateUserSearch(db *gorm.DB) error {
+
+	switch db.Dialector.Name() {
+	case constants.DriverPostgres:
+		return migratePostgresUserSearch(db)
+
+	case constants.DriverSqlite:
+		return migrateSqliteUserSearch(db)
+
+	default:
+		return nil
+	}
+}
+
+func migratePostgresUserSearch(db *gorm.DB) error {
+
+	statements := []string{
+		"CREATE EXTENSION IF NOT EXISTS pg_trgm",
+		fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON users USING gin (lower(name) gin_trgm_ops)", constants.IndexUsersNameTrgm),
+		fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON users USING gin (lower(email) gin_trgm_ops)", constants.IndexUsersEmailTrgm),
+		fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON users USING gin (to_tsvector('simple', name || ' ' || email))", constants.IndexUsersSearchText),
+	}
+
+	for _, statement := range statements {
+
+		if err := db.Exec(statement).Error; err != nil {
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+func migrateSqliteUserSearch(db *gorm.DB) error {
+
+	var fts5 bool
+	if err := db.Raw("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&fts5).Error; err != nil || !fts5 {
+
+		return err
+	}
+
+	table := constants.TableUsersFTS
+	statements := []string{
+		fmt.Sprintf("CREATE VIRTUAL TABLE IF NOT EXISTS %[1]s USING fts5(name, email, content='users', content_rowid='rowid', tokenize='trigram')", table),
+		fmt.Sprintf("CREATE TRIGGER IF NOT EXISTS %[1]s_insert AFTER INSERT ON users BEGIN"+
+			" INSERT INTO %[1]s(rowid, name, email) VALUES (new.rowid, new.name, new.email); END", table),
+		fmt.Sprintf("CREATE TRIGGER IF NOT EXISTS %[1]s_delete AFTER DELETE ON users BEGIN"+
+			" INSERT INTO %[1]s(%[1]s, rowid, name, email) VALUES ('delete', old.rowid, old.name, old.email); END", table),
+		fmt.Sprintf("CREATE TRIGGER IF NOT EXISTS %[1]s_update AFTER UPDATE OF name, email ON users BEGIN"+
+			" INSERT INTO %[1]s(%[1]s, rowid, name, email) VALUES ('delete', old.rowid, old.name, old.email);"+
+			" INSERT INTO %[1]s(rowid, name, email) VALUES (new.rowid, new.name, new.email); END", table),
+		fmt.Sprintf("INSERT INTO %[1]s(%[1]s) VALUES ('rebuild')", table),
+	}
+
+	for _, statement := range statements {
+
+		if err := db.Exec(statement).Error; err != nil {
+
+			return err
+		}
+	}
+
+	return nil
+}
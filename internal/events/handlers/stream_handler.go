@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	constants "backend-task/internal/constants"
+	models "backend-task/internal/events/models"
+	"backend-task/internal/events/repository"
+	services "backend-task/internal/events/services"
+	"backend-task/internal/tenant"
+	"backend-task/internal/utils"
+)
+
+type StreamHandler struct {
+	Broker    *services.Broker
+	Outbox    repository.OutboxRepository
+	Heartbeat time.Duration
+}
+
+func NewStreamHandler(broker *services.Broker, outbox repository.OutboxRepository, heartbeat time.Duration) *StreamHandler {
+
+	return &StreamHandler{Broker: broker, Outbox: outbox, Heartbeat: heartbeat}
+}
+
+// Stream godoc
+// @Summary Stream user and group changes ( Server-Sent Events ).
+// @Description Pushes domain events ( user.created, user.updated, user.group_changed, group.created, group.full ) of the caller's tenant as they happen.
+// @Description Each SSE message has the envelope ID as "id", the event type as "event" and the envelope JSON as "data".
+// @Description Reconnect with the Last-Event-ID header to resume. Comment heartbeats are sent periodically.
+// @Tags events
+// @Produce text/event-stream
+// @Param types query string false "Comma separated event types (e.g., user.created,group.full)"
+// @Param group_base query string false "Comma separated group bases (child, teen, adult, senior)"
+// @Param Last-Event-ID header string false "Resume after this event ID"
+// @Success 200 {string} string "text/event-stream"
+// @Router /events/stream [get]
+func (streamHandler *StreamHandler) Stream(context *gin.Context) {
+
+	filter := models.NewStreamFilter(tenant.ID(context.Request.Context()), context.Query("types"), context.Query("group_base"))
+	lastEventID := context.GetHeader(constants.HeaderLastEventID)
+
+	subscription, backlog, found := streamHandler.Broker.Subscribe(filter, lastEventID)
+	defer streamHandler.Broker.Unsubscribe(subscription)
+
+	// Resume From The Outbox When The Event Fell Out Of The Replay Buffer ( e.g., After A Restart ) :
+	if lastEventID != "" && !found {
+
+		backlog = streamHandler.replayFromOutbox(context, filter, lastEventID)
+	}
+
+	header := context.Writer.Header()
+	header.Set("Content-Type", sse.ContentType)
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	context.Status(http.StatusOK)
+
+	// Tell The Client How Long To Wait Before Reconnecting :
+	fmt.Fprintf(context.Writer, "retry:%d\n\n", constants.SSERetryMillis)
+
+	sent := map[uuid.UUID]bool{}
+	for _, envelope := range backlog {
+
+		if err := writeEnvelope(context, envelope); err != nil {
+
+			return
+		}
+
+		sent[envelope.ID] = true
+	}
+	context.Writer.Flush()
+
+	heartbeat := time.NewTicker(streamHandler.Heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+
+		select {
+		case <-context.Request.Context().Done():
+			return
+
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(context.Writer, ": heartbeat\n\n"); err != nil {
+
+				return
+			}
+			context.Writer.Flush()
+
+		case envelope, open := <-subscription.Events:
+			if !open {
+
+				// Dropped As A Slow Consumer ( Or Shutting Down ) : The Client Reconnects With Last-Event-ID.
+				return
+			}
+
+			if sent[envelope.ID] {
+
+				continue
+			}
+
+			if err := writeEnvelope(context, envelope); err != nil {
+
+				return
+			}
+			context.Writer.Flush()
+		}
+	}
+}
+
+func (streamHandler *StreamHandler) replayFromOutbox(context *gin.Context, filter models.StreamFilter, lastEventID string) []models.Envelope {
+
+	afterID, err := uuid.Parse(lastEventID)
+	if err != nil {
+
+		return nil
+	}
+
+	events, err := streamHandler.Outbox.ListSince(context.Request.Context(), afterID, constants.SSEReplayLimit)
+	if err != nil {
+
+		utils.Error(fmt.Sprintf("event stream replay: %v", err))
+		return nil
+	}
+
+	var backlog []models.Envelope
+	for _, event := range events {
+
+		if envelope := event.Envelope(); filter.Matches(envelope) {
+
+			backlog = append(backlog, envelope)
+		}
+	}
+
+	return backlog
+}
+
+func writeEnvelope(context *gin.Context, envelope models.Envelope) error {
+
+	data, err := json.Marshal(envelope)
+	if err != nil {
+
+		return err
+	}
+
+	return sse.Encode(context.Writer, sse.Event{Id: envelope.ID.String(), Event: envelope.Type, Data: data})
+}
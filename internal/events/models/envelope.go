@@ -0,0 +1,32 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Envelope Is The Versioned Wire Format Of A Domain Event.
+//
+// @Description Domain Event ( e.g., user.created ) Delivered To Downstream Systems.
+type Envelope struct {
+
+	// Event Unique Identifier ( UUID ), Stable Across Redeliveries.
+	ID uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+
+	// Tenant Whose Change The Event Describes; Only That Tenant's Streams And Webhooks Receive It.
+	TenantID string `json:"tenant_id" example:"default"`
+
+	// Event Type ( e.g., "user.created", "group.full" ).
+	Type string `json:"type" example:"user.created"`
+
+	// Envelope / Payload Schema Version.
+	Version int `json:"version" example:"1"`
+
+	// Timestamp When The Change Was Committed.
+	OccurredAt time.Time `json:"occurred_at" example:"2025-09-01T12:00:00Z"`
+
+	// Event Specific Payload.
+	Payload json.RawMessage `json:"payload" swaggertype:"object"`
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Outbox Event Is An Envelope Persisted In The Same Transaction As The Change,
+// Waiting To Be Relayed To The Publisher ( At Least Once ).
+type OutboxEvent struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	Sequence    int64      `gorm:"<-:false;uniqueIndex"` // Assigned By The Database On Insert ( See db.migrateOutboxSequence ).
+	TenantID    string     `gorm:"not null;default:default;index;size:64"`
+	Type        string     `gorm:"not null;index;size:64"`
+	Version     int        `gorm:"not null"`
+	OccurredAt  time.Time  `gorm:"not null;index"`
+	Payload     string     `gorm:"type:text;not null"`
+	PublishedAt *time.Time `gorm:"index"`
+	Attempts    int        `gorm:"not null;default:0"`
+	LastError   string     `gorm:"size:1024"`
+}
+
+// Before Create Ensures UUID Is Set Automatically :
+func (event *OutboxEvent) BeforeCreate(tx *gorm.DB) (err error) {
+
+	if event.ID == uuid.Nil {
+
+		event.ID = uuid.New()
+	}
+
+	return nil
+}
+
+// Envelope Converts The Stored Row Into Its Wire Format :
+func (event *OutboxEvent) Envelope() Envelope {
+
+	return Envelope{
+		ID:         event.ID,
+		TenantID:   event.TenantID,
+		Type:       event.Type,
+		Version:    event.Version,
+		OccurredAt: event.OccurredAt,
+		Payload:    json.RawMessage(event.Payload),
+	}
+}
@@ -0,0 +1,16 @@
+package models
+
+import userModels "backend-task/internal/user/models"
+
+// User Event Payload Is Carried By user.* Events :
+type UserEventPayload struct {
+	User          userModels.User `json:"user"`
+	GroupBase     string          `json:"group_base,omitempty" example:"adult"`
+	PreviousGroup string          `json:"previous_group,omitempty" example:"adult-1"`
+	MergedUserID  string          `json:"merged_user_id,omitempty" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"` // user.merged Only.
+}
+
+// Group Event Payload Is Carried By group.* Events :
+type GroupEventPayload struct {
+	Group userModels.Group `json:"group"`
+}
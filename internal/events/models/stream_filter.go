@@ -0,0 +1,78 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Stream Filter Selects Which Envelopes A Stream Subscriber Receives: Always Its Own Tenant's,
+// Narrowed By Type And Group Base ( Empty Means Every Type / Base ) :
+type StreamFilter struct {
+	TenantID   string
+	Types      map[string]bool
+	GroupBases map[string]bool
+}
+
+// NewStreamFilter Builds A Filter For The Tenant From Comma Separated Lists :
+func NewStreamFilter(tenantID, types, groupBases string) StreamFilter {
+
+	return StreamFilter{TenantID: tenantID, Types: toSet(types), GroupBases: toSet(groupBases)}
+}
+
+// Matches Reports Whether The Envelope Passes The Filter :
+func (filter StreamFilter) Matches(envelope Envelope) bool {
+
+	if envelope.TenantID != filter.TenantID {
+
+		return false
+	}
+
+	if len(filter.Types) > 0 && !filter.Types[envelope.Type] {
+
+		return false
+	}
+
+	if len(filter.GroupBases) > 0 && !filter.GroupBases[envelopeGroupBase(envelope)] {
+
+		return false
+	}
+
+	return true
+}
+
+// envelopeGroupBase Extracts The Group Base From user.* ( group_base ) Or group.* ( group.base ) Payloads :
+func envelopeGroupBase(envelope Envelope) string {
+
+	var payload struct {
+		GroupBase string `json:"group_base"`
+		Group     struct {
+			Base string `json:"base"`
+		} `json:"group"`
+	}
+
+	if err := json.Unmarshal(envelope.Payload, &payload); err != nil {
+
+		return ""
+	}
+
+	if payload.GroupBase != "" {
+
+		return payload.GroupBase
+	}
+
+	return payload.Group.Base
+}
+
+func toSet(list string) map[string]bool {
+
+	set := map[string]bool{}
+	for _, item := range strings.Split(list, ",") {
+
+		if item = strings.TrimSpace(item); item != "" {
+
+			set[item] = true
+		}
+	}
+
+	return set
+}
@@ -0,0 +1,132 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"backend-task/internal/events/models"
+	"backend-task/internal/utils"
+)
+
+// Publisher Delivers Event Envelopes To Downstream Consumers.
+// Implementations Must Tolerate Redelivery ( The Relay Is At Least Once ).
+type Publisher interface {
+	Publish(context context.Context, envelope models.Envelope) error
+}
+
+// ---------------- In-Memory Publisher ( Tests ) ----------------
+
+// InMemoryPublisher Keeps Every Published Envelope In Memory :
+type InMemoryPublisher struct {
+	mutex     sync.Mutex
+	envelopes []models.Envelope
+}
+
+func NewInMemoryPublisher() *InMemoryPublisher {
+
+	return &InMemoryPublisher{}
+}
+
+func (inMemoryPublisher *InMemoryPublisher) Publish(context context.Context, envelope models.Envelope) error {
+
+	inMemoryPublisher.mutex.Lock()
+	defer inMemoryPublisher.mutex.Unlock()
+
+	inMemoryPublisher.envelopes = append(inMemoryPublisher.envelopes, envelope)
+	return nil
+}
+
+// Envelopes Returns A Copy Of Everything Published So Far :
+func (inMemoryPublisher *InMemoryPublisher) Envelopes() []models.Envelope {
+
+	inMemoryPublisher.mutex.Lock()
+	defer inMemoryPublisher.mutex.Unlock()
+
+	return append([]models.Envelope(nil), inMemoryPublisher.envelopes...)
+}
+
+// ---------------- Log Publisher ( Local Development ) ----------------
+
+// LogPublisher Writes Every Envelope To The Application Log :
+type LogPublisher struct{}
+
+func NewLogPublisher() *LogPublisher {
+
+	return &LogPublisher{}
+}
+
+func (logPublisher *LogPublisher) Publish(context context.Context, envelope models.Envelope) error {
+
+	raw, err := json.Marshal(envelope)
+	if err != nil {
+
+		return err
+	}
+
+	utils.Info(fmt.Sprintf("event published: %s", raw))
+	return nil
+}
+
+// ---------------- File Publisher ( Local Development ) ----------------
+
+// FilePublisher Appends Every Envelope As One JSON Line ( NDJSON ) To A File :
+type FilePublisher struct {
+	mutex sync.Mutex
+	path  string
+}
+
+func NewFilePublisher(path string) *FilePublisher {
+
+	return &FilePublisher{path: path}
+}
+
+func (filePublisher *FilePublisher) Publish(context context.Context, envelope models.Envelope) error {
+
+	raw, err := json.Marshal(envelope)
+	if err != nil {
+
+		return err
+	}
+
+	filePublisher.mutex.Lock()
+	defer filePublisher.mutex.Unlock()
+
+	file, err := os.OpenFile(filePublisher.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(raw, '\n'))
+	return err
+}
+
+// ---------------- Multi Publisher ( Fan-Out ) ----------------
+
+// MultiPublisher Publishes Each Envelope To Every Inner Publisher In Order.
+// A Failure Stops The Fan-Out So The Relay Retries The Event ( Inner Publishers Must Be Idempotent ).
+type MultiPublisher struct {
+	publishers []Publisher
+}
+
+func NewMultiPublisher(publishers ...Publisher) *MultiPublisher {
+
+	return &MultiPublisher{publishers: publishers}
+}
+
+func (multiPublisher *MultiPublisher) Publish(context context.Context, envelope models.Envelope) error {
+
+	for _, publisher := range multiPublisher.publishers {
+
+		if err := publisher.Publish(context, envelope); err != nil {
+
+			return err
+		}
+	}
+
+	return nil
+}
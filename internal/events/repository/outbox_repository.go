@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"backend-task/internal/events/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Outbox Repository Interface :
+type OutboxRepository interface {
+	AppendTx(gormDB *gorm.DB, event *models.OutboxEvent) error
+	FetchPending(context context.Context, limit int) ([]*models.OutboxEvent, error)
+	MarkPublished(context context.Context, id uuid.UUID) error
+	MarkFailed(context context.Context, id uuid.UUID, cause error) error
+	ListSince(context context.Context, afterID uuid.UUID, limit int) ([]*models.OutboxEvent, error)
+}
+
+// OutboxRepositoryDB Implementation :
+type OutboxRepositoryDB struct {
+	gormDB *gorm.DB
+}
+
+// Constructor :
+func NewOutboxRepository(db *gorm.DB) OutboxRepository {
+
+	return &OutboxRepositoryDB{gormDB: db}
+}
+
+// AppendTx Stores The Event Using The Caller's Transaction So It Only Exists If The Change Commits :
+func (outboxRepositoryDB *OutboxRepositoryDB) AppendTx(gormDB *gorm.DB, event *models.OutboxEvent) error {
+
+	return gormDB.Create(event).Error
+}
+
+// FetchPending Returns Unpublished Events In Insert Order :
+func (outboxRepositoryDB *OutboxRepositoryDB) FetchPending(context context.Context, limit int) ([]*models.OutboxEvent, error) {
+
+	var events []*models.OutboxEvent
+	if err := outboxRepositoryDB.gormDB.WithContext(context).
+		Where("published_at IS NULL").
+		Order("sequence asc").
+		Limit(limit).
+		Find(&events).Error; err != nil {
+
+		return nil, fmt.Errorf("failed to fetch pending events: %w", err)
+	}
+
+	return events, nil
+}
+
+func (outboxRepositoryDB *OutboxRepositoryDB) MarkPublished(context context.Context, id uuid.UUID) error {
+
+	now := time.Now().UTC()
+	return outboxRepositoryDB.gormDB.WithContext(context).Model(&models.OutboxEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"published_at": &now, "attempts": gorm.Expr("attempts + 1"), "last_error": ""}).Error
+}
+
+func (outboxRepositoryDB *OutboxRepositoryDB) MarkFailed(context context.Context, id uuid.UUID, cause error) error {
+
+	message := cause.Error()
+	if len(message) > 1024 {
+
+		message = message[:1024]
+	}
+
+	return outboxRepositoryDB.gormDB.WithContext(context).Model(&models.OutboxEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"attempts": gorm.Expr("attempts + 1"), "last_error": message}).Error
+}
+
+// ListSince Returns Events Published After The Given Event, In Publish Order ( For Stream Resumption ).
+// Sequences Are Allocated Before Commit, So A Lower Sequence Can Be Published Later; Streams Deliver In Publish
+// Order, So The Cursor Is ( published_at, sequence ) And Ties At The Same Instant Are Neither Skipped Nor Repeated.
+func (outboxRepositoryDB *OutboxRepositoryDB) ListSince(context context.Context, afterID uuid.UUID, limit int) ([]*models.OutboxEvent, error) {
+
+	var anchor models.OutboxEvent
+	if err := outboxRepositoryDB.gormDB.WithContext(context).Select("sequence", "published_at").First(&anchor, "id = ?", afterID).Error; err != nil {
+
+		return nil, err
+	}
+
+	// Delivered But Not Yet Marked : The Relay Publishes In Order, So Nothing Has Been Published After It.
+	if anchor.PublishedAt == nil {
+
+		return nil, nil
+	}
+
+	var events []*models.OutboxEvent
+	if err := outboxRepositoryDB.gormDB.WithContext(context).
+		Where("published_at > ? OR (published_at = ? AND sequence > ?)", *anchor.PublishedAt, *anchor.PublishedAt, anchor.Sequence).
+		Order("published_at asc, sequence asc").
+		Limit(limit).
+		Find(&events).Error; err != nil {
+
+		return nil, fmt.Errorf("failed to list events since %s: %w", afterID, err)
+	}
+
+	return events, nil
+}
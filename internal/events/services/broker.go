@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"backend-task/internal/constants"
+	"backend-task/internal/events/models"
+)
+
+// Broker Fans Published Envelopes Out To Live Stream Subscribers ( SSE ).
+// It Keeps A Bounded Replay Buffer For Last-Event-ID Resumption. Publishing Never Blocks:
+// A Subscriber Whose Queue Is Full Is Disconnected And Must Reconnect To Resume.
+type Broker struct {
+	mutex       sync.Mutex
+	subscribers map[*StreamSubscription]struct{}
+	replay      []models.Envelope
+	replaySize  int
+	closed      bool
+}
+
+// Stream Subscription Is One Connected Client :
+type StreamSubscription struct {
+	Events <-chan models.Envelope
+	events chan models.Envelope
+	filter models.StreamFilter
+}
+
+func NewBroker(replaySize int) *Broker {
+
+	if replaySize <= 0 {
+
+		replaySize = constants.DefaultSSEReplayBuffer
+	}
+
+	return &Broker{subscribers: map[*StreamSubscription]struct{}{}, replaySize: replaySize}
+}
+
+// Publish Implements publisher.Publisher :
+func (broker *Broker) Publish(context context.Context, envelope models.Envelope) error {
+
+	broker.mutex.Lock()
+	defer broker.mutex.Unlock()
+
+	// Redeliveries From The At-Least-Once Relay Are Ignored.
+	for _, seen := range broker.replay {
+
+		if seen.ID == envelope.ID {
+
+			return nil
+		}
+	}
+
+	broker.replay = append(broker.replay, envelope)
+	if len(broker.replay) > broker.replaySize {
+
+		broker.replay = broker.replay[len(broker.replay)-broker.replaySize:]
+	}
+
+	for subscription := range broker.subscribers {
+
+		if !subscription.filter.Matches(envelope) {
+
+			continue
+		}
+
+		select {
+		case subscription.events <- envelope:
+
+		default:
+			// Too Slow : Drop The Subscriber Instead Of Blocking The Write Path.
+			broker.removeLocked(subscription)
+		}
+	}
+
+	return nil
+}
+
+// Subscribe Registers A Subscriber. When lastEventID Is Found In The Replay Buffer The Matching
+// Envelopes Published After It Are Returned ( found = true ), Atomically With The Registration.
+func (broker *Broker) Subscribe(filter models.StreamFilter, lastEventID string) (subscription *StreamSubscription, backlog []models.Envelope, found bool) {
+
+	events := make(chan models.Envelope, constants.SSESubscriberBuffer)
+	subscription = &StreamSubscription{Events: events, events: events, filter: filter}
+
+	broker.mutex.Lock()
+	defer broker.mutex.Unlock()
+
+	if broker.closed {
+
+		close(events)
+		return subscription, nil, false
+	}
+
+	broker.subscribers[subscription] = struct{}{}
+
+	if lastEventID == "" {
+
+		return subscription, nil, false
+	}
+
+	for index, envelope := range broker.replay {
+
+		if envelope.ID.String() != lastEventID {
+
+			continue
+		}
+
+		for _, next := range broker.replay[index+1:] {
+
+			if filter.Matches(next) {
+
+				backlog = append(backlog, next)
+			}
+		}
+
+		return subscription, backlog, true
+	}
+
+	return subscription, nil, false
+}
+
+// Unsubscribe Removes The Subscriber ( Safe To Call More Than Once ) :
+func (broker *Broker) Unsubscribe(subscription *StreamSubscription) {
+
+	broker.mutex.Lock()
+	defer broker.mutex.Unlock()
+
+	broker.removeLocked(subscription)
+}
+
+// Close Disconnects Every Subscriber ( Used On Shutdown ) :
+func (broker *Broker) Close() {
+
+	broker.mutex.Lock()
+	defer broker.mutex.Unlock()
+
+	broker.closed = true
+	for subscription := range broker.subscribers {
+
+		broker.removeLocked(subscription)
+	}
+}
+
+func (broker *Broker) removeLocked(subscription *StreamSubscription) {
+
+	if _, ok := broker.subscribers[subscription]; ok {
+
+		delete(broker.subscribers, subscription)
+		close(subscription.events)
+	}
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"backend-task/internal/constants"
+	"backend-task/internal/events/models"
+	"backend-task/internal/events/repository"
+	eventServiceInterface "backend-task/internal/events/services/interface"
+	"backend-task/internal/tenant"
+
+	"gorm.io/gorm"
+)
+
+type EventService struct {
+	outbox repository.OutboxRepository
+}
+
+func NewEventService(outbox repository.OutboxRepository) eventServiceInterface.EventService {
+
+	return &EventService{outbox: outbox}
+}
+
+// ---------------- Emit ----------------
+
+func (eventService *EventService) EmitTx(context context.Context, gormDB *gorm.DB, eventType string, payload any) error {
+
+	raw, err := json.Marshal(payload)
+	if err != nil {
+
+		return err
+	}
+
+	event := &models.OutboxEvent{
+		TenantID:   tenant.ID(context),
+		Type:       eventType,
+		Version:    constants.EventEnvelopeVersion,
+		OccurredAt: time.Now().UTC(),
+		Payload:    string(raw),
+	}
+
+	return eventService.outbox.AppendTx(gormDB, event)
+}
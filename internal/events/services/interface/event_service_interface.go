@@ -0,0 +1,14 @@
+package serviceInterface
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// Event Service Defines How Domain Events Are Emitted :
+type EventService interface {
+
+	// EmitTx Writes A Versioned Event Envelope To The Outbox Inside The Caller's Transaction.
+	EmitTx(context context.Context, gormDB *gorm.DB, eventType string, payload any) error
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"backend-task/internal/events/publisher"
+	"backend-task/internal/events/repository"
+	"backend-task/internal/tenant"
+	"backend-task/internal/utils"
+)
+
+// Relay Moves Committed Outbox Events To The Publisher.
+// An Event Is Only Marked Published After The Publisher Accepted It, So Delivery Is At Least Once.
+type Relay struct {
+	outbox    repository.OutboxRepository
+	publisher publisher.Publisher
+	interval  time.Duration
+	batchSize int
+}
+
+func NewRelay(outbox repository.OutboxRepository, publisher publisher.Publisher, interval time.Duration, batchSize int) *Relay {
+
+	return &Relay{outbox: outbox, publisher: publisher, interval: interval, batchSize: batchSize}
+}
+
+// Run Polls The Outbox Until The Context Is Cancelled :
+func (relay *Relay) Run(context context.Context) {
+
+	ticker := time.NewTicker(relay.interval)
+	defer ticker.Stop()
+
+	for {
+
+		if _, err := relay.RelayOnce(context); err != nil {
+
+			utils.Error(fmt.Sprintf("outbox relay: %v", err))
+		}
+
+		select {
+		case <-context.Done():
+			return
+
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayOnce Publishes One Batch Of Pending Events ( Of Every Tenant ) And Returns How Many Were Published.
+// It Stops At The First Failure To Preserve Ordering; The Event Is Retried On The Next Tick.
+func (relay *Relay) RelayOnce(context context.Context) (int, error) {
+
+	events, err := relay.outbox.FetchPending(tenant.AllTenants(context), relay.batchSize)
+	if err != nil {
+
+		return 0, err
+	}
+
+	published := 0
+	for _, event := range events {
+
+		// Each Event Is Handled In Its Own Tenant, So Fan-Out Only Reaches That Tenant's Subscribers :
+		eventContext := tenant.WithID(context, event.TenantID)
+
+		if err := relay.publisher.Publish(eventContext, event.Envelope()); err != nil {
+
+			if markErr := relay.outbox.MarkFailed(eventContext, event.ID, err); markErr != nil {
+
+				return published, markErr
+			}
+
+			return published, fmt.Errorf("publish %s ( %s ): %w", event.ID, event.Type, err)
+		}
+
+		if err := relay.outbox.MarkPublished(eventContext, event.ID); err != nil {
+
+			return published, err
+		}
+
+		published++
+	}
+
+	return published, nil
+}
@@ -0,0 +1,129 @@
+package i18n
+
+import "backend-task/internal/constants"
+
+// arabic Covers Every Registered Error Code ( Checked By The Tests ) :
+var arabic = Catalog{
+
+	// ---------------- Titles ----------------
+
+	constants.MessageTitlePrefix + "400": "طلب غير صالح",
+	constants.MessageTitlePrefix + "401": "غير مصرح",
+	constants.MessageTitlePrefix + "403": "ممنوع",
+	constants.MessageTitlePrefix + "404": "غير موجود",
+	constants.MessageTitlePrefix + "409": "تعارض",
+	constants.MessageTitlePrefix + "415": "نوع وسائط غير مدعوم",
+	constants.MessageTitlePrefix + "500": "خطأ داخلي في الخادم",
+
+	// ---------------- Validation Rules ----------------
+
+	constants.MessageRulePrefix + "required":      "الحقل {field} مطلوب",
+	constants.MessageRulePrefix + "notblank":      "الحقل {field} مطلوب",
+	constants.MessageRulePrefix + "email":         "يجب أن يكون الحقل {field} بريدًا إلكترونيًا صالحًا",
+	constants.MessageRulePrefix + "url":           "يجب أن يكون الحقل {field} رابطًا كاملًا",
+	constants.MessageRulePrefix + "uuid":          "يجب أن يكون الحقل {field} معرّف uuid صالحًا",
+	constants.MessageRulePrefix + "datetime":      "يجب أن يكون الحقل {field} بالصيغة yyyy-mm-dd",
+	constants.MessageRulePrefix + "pastdate":      "لا يمكن أن يكون الحقل {field} في المستقبل",
+	constants.MessageRulePrefix + "notdisposable": "لا يجوز أن يستخدم الحقل {field} نطاق بريد مؤقت",
+	constants.MessageRulePrefix + "unique":        "الحقل {field} مستخدم بالفعل",
+	constants.MessageRulePrefix + "min":           "يجب أن يحتوي الحقل {field} على {param} عنصر على الأقل",
+	constants.MessageRulePrefix + "max":           "يجب ألا يتجاوز الحقل {field} {param} حرفًا",
+	constants.MessageRulePrefix + "type":          "يجب أن يكون الحقل {field} من النوع {param}",
+	constants.MessageRulePrefix + "default":       "الحقل {field} غير صالح ( {rule} )",
+
+	// ---------------- Emails ----------------
+
+	constants.MessageEmailVerificationSubject: "تأكيد عنوان بريدك الإلكتروني",
+	constants.MessageEmailVerificationBody:    "مرحبًا {name}،\n\nيرجى تأكيد عنوان بريدك الإلكتروني بفتح هذا الرابط:\n\n{link}\n\nتنتهي صلاحية الرابط خلال {ttl}.\n",
+	constants.MessageConsentEmailSubject:      "موافقة مطلوبة لحساب جديد",
+	constants.MessageConsentEmailBody:         "مرحبًا {guardian}،\n\nتم تسجيل حساب باسم {name} ( {email} ) وهو قاصر. يبقى الحساب غير نشط حتى تمنح موافقتك بفتح هذا الرابط:\n\n{link}\n\nتنتهي صلاحية الرابط خلال {ttl}. إذا لم تكن تتوقع هذه الرسالة فيمكنك تجاهلها.\n",
+	constants.MessageEmailTakenSubject:        "محاولة تسجيل باستخدام حسابك",
+	constants.MessageEmailTakenBody:           "مرحبًا،\n\nحاول شخص ما إنشاء حساب جديد باستخدام {email}، وهو عنوان مرتبط بحسابك بالفعل. إذا كنت أنت، فسجّل الدخول بدلًا من ذلك. وإلا فيمكنك تجاهل هذه الرسالة.\n",
+	constants.MessageRegistrationAccepted:     "تحقق من بريدك الوارد لإكمال التسجيل",
+
+	// ---------------- Errors ----------------
+
+	"INVALID_REQUEST_BODY":               "محتوى الطلب غير صالح",
+	"INVALID_ID":                         "المعرّف غير صالح",
+	"USER_NOT_FOUND":                     "المستخدم غير موجود",
+	"USER_NAME_REQUIRED":                 "الاسم مطلوب",
+	"USER_EMAIL_INVALID":                 "صيغة البريد الإلكتروني غير صالحة",
+	"USER_DATE_OF_BIRTH_INVALID":         "يجب أن يكون تاريخ الميلاد بالصيغة yyyy-mm-dd",
+	"USER_EMAIL_TAKEN":                   "البريد الإلكتروني مستخدم بالفعل",
+	"USER_NAME_EMPTY":                    "لا يمكن أن يكون الاسم فارغًا",
+	"RECORD_NOT_FOUND":                   "السجل غير موجود",
+	"INTERNAL_ERROR":                     "خطأ داخلي في الخادم",
+	"USER_DATE_OF_BIRTH_IN_FUTURE":       "لا يمكن أن يكون تاريخ الميلاد في المستقبل",
+	"GROUP_LOOKUP_FAILED":                "تعذر العثور على المجموعة",
+	"GROUP_INDEX_FAILED":                 "تعذر الحصول على أعلى رقم للمجموعة",
+	"GROUP_CREATE_FAILED":                "تعذر إنشاء مجموعة جديدة",
+	"INVALID_TIME_RANGE":                 "يجب أن تكون قيم from و to بصيغة RFC 3339",
+	"WEBHOOK_NOT_FOUND":                  "اشتراك الـ webhook غير موجود",
+	"WEBHOOK_DELIVERY_NOT_FOUND":         "عملية تسليم الـ webhook غير موجودة",
+	"WEBHOOK_URL_INVALID":                "يجب أن يكون رابط الـ webhook رابط http أو https كاملًا",
+	"WEBHOOK_SECRET_TOO_SHORT":           "يجب ألا يقل سر الـ webhook عن 16 حرفًا",
+	"EVENT_TYPE_UNKNOWN":                 "نوع الحدث غير معروف",
+	"UNSUPPORTED_CONTENT_TYPE":           "يجب أن يكون نوع المحتوى text/csv",
+	"IMPORT_CSV_MALFORMED":               "ملف csv غير سليم",
+	"IMPORT_COLUMNS_MISSING":             "يجب أن يحتوي رأس ملف csv على name و email و date_of_birth",
+	"IMPORT_NO_ROWS":                     "لا يحتوي ملف csv على صفوف بيانات",
+	"IMPORT_TOO_MANY_ROWS":               "يتجاوز ملف csv الحد الأقصى لعدد الصفوف",
+	"IMPORT_DUPLICATE_EMAIL":             "بريد إلكتروني مكرر في الملف",
+	"IMPORT_DRY_RUN_INVALID":             "يجب أن تكون قيمة dry_run إما true أو false",
+	"JOB_NOT_FOUND":                      "المهمة غير موجودة",
+	"JOB_NOT_CANCELLABLE":                "انتهت المهمة بالفعل",
+	"EXPORT_FORMAT_UNSUPPORTED":          "يجب أن تكون الصيغة csv أو ndjson",
+	"EXPORT_GZIP_INVALID":                "يجب أن تكون قيمة gzip إما true أو false",
+	"DATA_EXPORT_FORMAT_UNSUPPORTED":     "يجب أن تكون الصيغة json أو zip",
+	"DATA_EXPORT_SIGNING_KEY_MISSING":    "مفتاح توقيع التصدير غير مُعد",
+	"DATA_EXPORT_SIGNATURE_INVALID":      "توقيع أرشيف التصدير غير صالح",
+	"AUTH_TOKEN_MISSING":                 "رمز الـ bearer مفقود",
+	"AUTH_TOKEN_INVALID":                 "الرمز غير صالح أو منتهي الصلاحية",
+	"AUTH_VERIFICATION_KEYS_MISSING":     "لم يتم إعداد مفاتيح التحقق من jwt",
+	"AUTH_JWK_UNSUPPORTED":               "مفتاح jwk غير مدعوم",
+	"PERMISSION_DENIED":                  "تم رفض الإذن",
+	"PERMISSION_UNKNOWN":                 "صلاحية غير معروفة",
+	"API_KEY_INVALID":                    "مفتاح api غير صالح أو ملغى",
+	"API_KEY_IP_NOT_ALLOWED":             "مفتاح api غير مسموح به من عنوان ip هذا",
+	"API_KEY_NOT_FOUND":                  "مفتاح api غير موجود",
+	"API_KEY_REVOKED":                    "مفتاح api ملغى",
+	"API_KEY_NAME_INVALID":               "اسم مفتاح api مطلوب ( 128 حرفًا كحد أقصى )",
+	"API_KEY_ALLOWED_IP_INVALID":         "يجب أن تكون عناوين ip المسموح بها عناوين ip أو نطاقات cidr",
+	"API_KEY_PERMISSION_REQUIRES_MFA":    "الصلاحية تتطلب المصادقة متعددة العوامل ولا يمكن منحها لمفتاح api",
+	"AUTH_INVALID_CREDENTIALS":           "البريد الإلكتروني أو كلمة المرور غير صحيحة",
+	"AUTH_REFRESH_TOKEN_INVALID":         "رمز التحديث غير صالح أو منتهي الصلاحية",
+	"AUTH_SESSION_REVOKED":               "تم إلغاء الجلسة",
+	"AUTH_PASSWORD_WEAK":                 "يجب أن تتكون كلمة المرور من 10 إلى 128 حرفًا",
+	"AUTH_CURRENT_PASSWORD_MISMATCH":     "كلمة المرور الحالية غير صحيحة",
+	"AUTH_SIGNING_KEY_MISSING":           "مفتاح توقيع الرموز غير مُعد",
+	"AUTH_PASSWORD_HASH_MALFORMED":       "تجزئة كلمة المرور غير سليمة",
+	"MFA_REQUIRED":                       "المصادقة متعددة العوامل مطلوبة",
+	"MFA_ALREADY_ENABLED":                "المصادقة متعددة العوامل مفعّلة بالفعل",
+	"MFA_NOT_ENROLLED":                   "لم يبدأ تسجيل المصادقة متعددة العوامل",
+	"MFA_CODE_INVALID":                   "رمز المصادقة متعددة العوامل غير صالح",
+	"MFA_CHALLENGE_INVALID":              "تحدي المصادقة متعددة العوامل غير صالح أو منتهي الصلاحية",
+	"MFA_CODE_REQUIRED":                  "يجب إدخال code أو recovery_code",
+	"ACCOUNT_NOT_FOUND":                  "الحساب غير موجود",
+	"ACCOUNT_REQUIRED":                   "المستدعي ليس حسابًا بكلمة مرور",
+	"ACCOUNT_ROLES_INVALID":              "يجب أن تكون الأدوار أسماء غير فارغة",
+	"EMAIL_VERIFICATION_TOKEN_INVALID":   "رمز التحقق غير صالح أو منتهي الصلاحية",
+	"USER_GUARDIAN_REQUIRED":             "اسم وبريد ولي الأمر مطلوبان للمستخدمين دون 18 عامًا",
+	"USER_GUARDIAN_EMAIL_INVALID":        "يجب أن يكون بريد ولي الأمر عنوانًا صالحًا مختلفًا عن بريد المستخدم",
+	"CONSENT_TOKEN_INVALID":              "رمز الموافقة غير صالح أو منتهي الصلاحية",
+	"CONSENT_NOT_FOUND":                  "الموافقة غير موجودة",
+	"CONSENT_ALREADY_REVOKED":            "تم سحب الموافقة بالفعل",
+	"CONSENT_STATUS_INVALID":             "يجب أن تكون الحالة pending أو granted أو revoked",
+	"CONSENT_REASON_TOO_LONG":            "يجب ألا يتجاوز السبب 512 حرفًا",
+	"USER_STATUS_INVALID":                "يجب أن تكون الحالة pending_consent أو active أو suspended أو deactivated",
+	"USER_STATUS_TRANSITION_NOT_ALLOWED": "تغيير الحالة هذا غير مسموح به",
+	"USER_STATUS_REASON_REQUIRED":        "السبب مطلوب ( 512 حرفًا كحد أقصى )",
+	"USER_GUARDIAN_CONSENT_REQUIRED":     "لا يمكن تفعيل حساب القاصر إلا بعد موافقة ولي الأمر",
+	"USER_NOT_ACTIVE":                    "المستخدم موقوف أو معطّل",
+	"TENANT_ID_INVALID":                  "يجب أن يتكون معرّف المستأجر من 1 إلى 64 حرفًا من الأحرف الصغيرة أو الأرقام أو '-' أو '_'",
+	"TENANT_MISMATCH":                    "المستأجر لا يطابق مستأجر المستدعي",
+	"VALIDATION_FAILED":                  "فشل التحقق من صحة الطلب",
+	"USER_EMAIL_DISPOSABLE":              "عناوين البريد الإلكتروني المؤقتة غير مسموح بها",
+	"INVALID_MIN_SCORE":                  "يجب أن تكون قيمة min_score رقمًا بين 0 و 1",
+	"USER_MERGE_SAME":                    "لا يمكن دمج المستخدم مع نفسه",
+	"SEARCH_QUERY_INVALID":               "يجب أن يتراوح طول q بين 3 و 100 حرف",
+}
@@ -0,0 +1,129 @@
+package i18n
+
+import "backend-task/internal/constants"
+
+// german Covers Every Registered Error Code ( Checked By The Tests ) :
+var german = Catalog{
+
+	// ---------------- Titles ----------------
+
+	constants.MessageTitlePrefix + "400": "Ungültige Anfrage",
+	constants.MessageTitlePrefix + "401": "Nicht autorisiert",
+	constants.MessageTitlePrefix + "403": "Verboten",
+	constants.MessageTitlePrefix + "404": "Nicht gefunden",
+	constants.MessageTitlePrefix + "409": "Konflikt",
+	constants.MessageTitlePrefix + "415": "Nicht unterstützter Medientyp",
+	constants.MessageTitlePrefix + "500": "Interner Serverfehler",
+
+	// ---------------- Validation Rules ----------------
+
+	constants.MessageRulePrefix + "required":      "{field} ist erforderlich",
+	constants.MessageRulePrefix + "notblank":      "{field} ist erforderlich",
+	constants.MessageRulePrefix + "email":         "{field} muss eine gültige E-Mail-Adresse sein",
+	constants.MessageRulePrefix + "url":           "{field} muss eine absolute URL sein",
+	constants.MessageRulePrefix + "uuid":          "{field} muss eine gültige UUID sein",
+	constants.MessageRulePrefix + "datetime":      "{field} muss das Format yyyy-mm-dd haben",
+	constants.MessageRulePrefix + "pastdate":      "{field} darf nicht in der Zukunft liegen",
+	constants.MessageRulePrefix + "notdisposable": "{field} darf keine Wegwerf-E-Mail-Domain verwenden",
+	constants.MessageRulePrefix + "unique":        "{field} ist bereits vergeben",
+	constants.MessageRulePrefix + "min":           "{field} muss mindestens {param} Einträge enthalten",
+	constants.MessageRulePrefix + "max":           "{field} darf höchstens {param} Zeichen lang sein",
+	constants.MessageRulePrefix + "type":          "{field} muss vom Typ {param} sein",
+	constants.MessageRulePrefix + "default":       "{field} ist ungültig ( {rule} )",
+
+	// ---------------- Emails ----------------
+
+	constants.MessageEmailVerificationSubject: "Bestätigen Sie Ihre E-Mail-Adresse",
+	constants.MessageEmailVerificationBody:    "Hallo {name},\n\nbitte bestätigen Sie Ihre E-Mail-Adresse, indem Sie diesen Link öffnen:\n\n{link}\n\nDer Link läuft in {ttl} ab.\n",
+	constants.MessageConsentEmailSubject:      "Einwilligung für ein neues Konto erforderlich",
+	constants.MessageConsentEmailBody:         "Hallo {guardian},\n\nfür {name} ( {email} ), eine minderjährige Person, wurde ein Konto registriert. Das Konto bleibt inaktiv, bis Sie Ihre Einwilligung geben, indem Sie diesen Link öffnen:\n\n{link}\n\nDer Link läuft in {ttl} ab. Wenn Sie diese E-Mail nicht erwartet haben, können Sie sie ignorieren.\n",
+	constants.MessageEmailTakenSubject:        "Registrierungsversuch für Ihr Konto",
+	constants.MessageEmailTakenBody:           "Hallo,\n\njemand hat versucht, mit {email} ein neues Konto anzulegen. Diese Adresse gehört bereits zu Ihrem Konto. Wenn Sie das waren, melden Sie sich stattdessen an. Andernfalls können Sie diese E-Mail ignorieren.\n",
+	constants.MessageRegistrationAccepted:     "Prüfen Sie Ihr Postfach, um die Registrierung abzuschließen",
+
+	// ---------------- Errors ----------------
+
+	"INVALID_REQUEST_BODY":               "Ungültiger Anfrageinhalt",
+	"INVALID_ID":                         "Ungültige ID",
+	"USER_NOT_FOUND":                     "Benutzer nicht gefunden",
+	"USER_NAME_REQUIRED":                 "Name ist erforderlich",
+	"USER_EMAIL_INVALID":                 "Ungültiges E-Mail-Format",
+	"USER_DATE_OF_BIRTH_INVALID":         "date_of_birth muss das Format yyyy-mm-dd haben",
+	"USER_EMAIL_TAKEN":                   "E-Mail-Adresse ist bereits vergeben",
+	"USER_NAME_EMPTY":                    "Name darf nicht leer sein",
+	"RECORD_NOT_FOUND":                   "Datensatz nicht gefunden",
+	"INTERNAL_ERROR":                     "Interner Serverfehler",
+	"USER_DATE_OF_BIRTH_IN_FUTURE":       "date_of_birth darf nicht in der Zukunft liegen",
+	"GROUP_LOOKUP_FAILED":                "Gruppe konnte nicht gefunden werden",
+	"GROUP_INDEX_FAILED":                 "Höchster Gruppenindex konnte nicht ermittelt werden",
+	"GROUP_CREATE_FAILED":                "Neue Gruppe konnte nicht angelegt werden",
+	"INVALID_TIME_RANGE":                 "from / to müssen RFC-3339-Zeitstempel sein",
+	"WEBHOOK_NOT_FOUND":                  "Webhook-Abonnement nicht gefunden",
+	"WEBHOOK_DELIVERY_NOT_FOUND":         "Webhook-Zustellung nicht gefunden",
+	"WEBHOOK_URL_INVALID":                "Die Webhook-URL muss eine absolute http- oder https-URL sein",
+	"WEBHOOK_SECRET_TOO_SHORT":           "Das Webhook-Geheimnis muss mindestens 16 Zeichen lang sein",
+	"EVENT_TYPE_UNKNOWN":                 "Unbekannter Ereignistyp",
+	"UNSUPPORTED_CONTENT_TYPE":           "Der Content-Type muss text/csv sein",
+	"IMPORT_CSV_MALFORMED":               "Fehlerhafte CSV-Datei",
+	"IMPORT_COLUMNS_MISSING":             "Die CSV-Kopfzeile muss name, email und date_of_birth enthalten",
+	"IMPORT_NO_ROWS":                     "Die CSV-Datei enthält keine Datenzeilen",
+	"IMPORT_TOO_MANY_ROWS":               "Die CSV-Datei überschreitet die maximale Zeilenanzahl",
+	"IMPORT_DUPLICATE_EMAIL":             "Doppelte E-Mail-Adresse in der Datei",
+	"IMPORT_DRY_RUN_INVALID":             "dry_run muss true oder false sein",
+	"JOB_NOT_FOUND":                      "Auftrag nicht gefunden",
+	"JOB_NOT_CANCELLABLE":                "Der Auftrag ist bereits abgeschlossen",
+	"EXPORT_FORMAT_UNSUPPORTED":          "format muss csv oder ndjson sein",
+	"EXPORT_GZIP_INVALID":                "gzip muss true oder false sein",
+	"DATA_EXPORT_FORMAT_UNSUPPORTED":     "format muss json oder zip sein",
+	"DATA_EXPORT_SIGNING_KEY_MISSING":    "Der Signaturschlüssel für Exporte ist nicht konfiguriert",
+	"DATA_EXPORT_SIGNATURE_INVALID":      "Die Signatur des Exportarchivs ist ungültig",
+	"AUTH_TOKEN_MISSING":                 "Bearer-Token fehlt",
+	"AUTH_TOKEN_INVALID":                 "Ungültiges oder abgelaufenes Token",
+	"AUTH_VERIFICATION_KEYS_MISSING":     "Keine JWT-Prüfschlüssel konfiguriert",
+	"AUTH_JWK_UNSUPPORTED":               "Nicht unterstützter JWK",
+	"PERMISSION_DENIED":                  "Zugriff verweigert",
+	"PERMISSION_UNKNOWN":                 "Unbekannte Berechtigung",
+	"API_KEY_INVALID":                    "Ungültiger oder widerrufener API-Schlüssel",
+	"API_KEY_IP_NOT_ALLOWED":             "Der API-Schlüssel ist von dieser IP-Adresse nicht zugelassen",
+	"API_KEY_NOT_FOUND":                  "API-Schlüssel nicht gefunden",
+	"API_KEY_REVOKED":                    "Der API-Schlüssel ist widerrufen",
+	"API_KEY_NAME_INVALID":               "Ein Name für den API-Schlüssel ist erforderlich ( max. 128 Zeichen )",
+	"API_KEY_ALLOWED_IP_INVALID":         "Zulässige IPs müssen IP-Adressen oder CIDR-Bereiche sein",
+	"API_KEY_PERMISSION_REQUIRES_MFA":    "Die Berechtigung erfordert eine Multi-Faktor-Authentifizierung und kann keinem API-Schlüssel erteilt werden",
+	"AUTH_INVALID_CREDENTIALS":           "Ungültige E-Mail-Adresse oder ungültiges Passwort",
+	"AUTH_REFRESH_TOKEN_INVALID":         "Ungültiges oder abgelaufenes Refresh-Token",
+	"AUTH_SESSION_REVOKED":               "Die Sitzung wurde widerrufen",
+	"AUTH_PASSWORD_WEAK":                 "Das Passwort muss 10 bis 128 Zeichen lang sein",
+	"AUTH_CURRENT_PASSWORD_MISMATCH":     "Das aktuelle Passwort ist falsch",
+	"AUTH_SIGNING_KEY_MISSING":           "Der Token-Signaturschlüssel ist nicht konfiguriert",
+	"AUTH_PASSWORD_HASH_MALFORMED":       "Fehlerhafter Passwort-Hash",
+	"MFA_REQUIRED":                       "Multi-Faktor-Authentifizierung erforderlich",
+	"MFA_ALREADY_ENABLED":                "MFA ist bereits aktiviert",
+	"MFA_NOT_ENROLLED":                   "Die MFA-Einrichtung wurde nicht gestartet",
+	"MFA_CODE_INVALID":                   "Ungültiger MFA-Code",
+	"MFA_CHALLENGE_INVALID":              "Ungültige oder abgelaufene MFA-Challenge",
+	"MFA_CODE_REQUIRED":                  "Entweder code oder recovery_code ist erforderlich",
+	"ACCOUNT_NOT_FOUND":                  "Konto nicht gefunden",
+	"ACCOUNT_REQUIRED":                   "Der Aufrufer ist kein Passwort-Konto",
+	"ACCOUNT_ROLES_INVALID":              "Rollen müssen nicht-leere Namen sein",
+	"EMAIL_VERIFICATION_TOKEN_INVALID":   "Ungültiges oder abgelaufenes Bestätigungs-Token",
+	"USER_GUARDIAN_REQUIRED":             "Name und E-Mail-Adresse eines Erziehungsberechtigten sind für Benutzer unter 18 Jahren erforderlich",
+	"USER_GUARDIAN_EMAIL_INVALID":        "Die E-Mail-Adresse des Erziehungsberechtigten muss gültig sein und sich von der des Benutzers unterscheiden",
+	"CONSENT_TOKEN_INVALID":              "Ungültiges oder abgelaufenes Einwilligungs-Token",
+	"CONSENT_NOT_FOUND":                  "Einwilligung nicht gefunden",
+	"CONSENT_ALREADY_REVOKED":            "Die Einwilligung wurde bereits widerrufen",
+	"CONSENT_STATUS_INVALID":             "status muss pending, granted oder revoked sein",
+	"CONSENT_REASON_TOO_LONG":            "Die Begründung darf höchstens 512 Zeichen lang sein",
+	"USER_STATUS_INVALID":                "status muss pending_consent, active, suspended oder deactivated sein",
+	"USER_STATUS_TRANSITION_NOT_ALLOWED": "Dieser Statuswechsel ist nicht erlaubt",
+	"USER_STATUS_REASON_REQUIRED":        "Eine Begründung ist erforderlich ( max. 512 Zeichen )",
+	"USER_GUARDIAN_CONSENT_REQUIRED":     "Minderjährige können nur mit Einwilligung eines Erziehungsberechtigten aktiviert werden",
+	"USER_NOT_ACTIVE":                    "Der Benutzer ist gesperrt oder deaktiviert",
+	"TENANT_ID_INVALID":                  "Die Mandanten-ID muss aus 1 bis 64 Kleinbuchstaben, Ziffern, '-' oder '_' bestehen",
+	"TENANT_MISMATCH":                    "Der Mandant stimmt nicht mit dem Mandanten des Aufrufers überein",
+	"VALIDATION_FAILED":                  "Die Validierung der Anfrage ist fehlgeschlagen",
+	"USER_EMAIL_DISPOSABLE":              "Wegwerf-E-Mail-Adressen sind nicht erlaubt",
+	"INVALID_MIN_SCORE":                  "min_score muss eine Zahl zwischen 0 und 1 sein",
+	"USER_MERGE_SAME":                    "Ein Benutzer kann nicht mit sich selbst zusammengeführt werden",
+	"SEARCH_QUERY_INVALID":               "q muss zwischen 3 und 100 Zeichen lang sein",
+}
@@ -0,0 +1,34 @@
+package i18n
+
+import "backend-task/internal/constants"
+
+// english Is The Fallback Catalog. Error Messages Are Not Repeated Here: The Registered Errors
+// ( utils/errors.go ) Are The English Text, And Titles Are http.StatusText.
+var english = Catalog{
+
+	// ---------------- Validation Rules ----------------
+
+	constants.MessageRulePrefix + "required":      "{field} is required",
+	constants.MessageRulePrefix + "notblank":      "{field} is required",
+	constants.MessageRulePrefix + "email":         "{field} must be a valid email address",
+	constants.MessageRulePrefix + "url":           "{field} must be an absolute url",
+	constants.MessageRulePrefix + "uuid":          "{field} must be a valid uuid",
+	constants.MessageRulePrefix + "datetime":      "{field} must be yyyy-mm-dd",
+	constants.MessageRulePrefix + "pastdate":      "{field} cannot be in the future",
+	constants.MessageRulePrefix + "notdisposable": "{field} must not use a disposable email domain",
+	constants.MessageRulePrefix + "unique":        "{field} is already taken",
+	constants.MessageRulePrefix + "min":           "{field} must have at least {param} item(s)",
+	constants.MessageRulePrefix + "max":           "{field} must have at most {param} character(s)",
+	constants.MessageRulePrefix + "type":          "{field} must be of type {param}",
+	constants.MessageRulePrefix + "default":       "{field} is invalid ( {rule} )",
+
+	// ---------------- Emails ----------------
+
+	constants.MessageEmailVerificationSubject: "Confirm your email address",
+	constants.MessageEmailVerificationBody:    "Hello {name},\n\nPlease confirm your email address by opening this link:\n\n{link}\n\nThe link expires in {ttl}.\n",
+	constants.MessageConsentEmailSubject:      "Consent required for a new account",
+	constants.MessageConsentEmailBody:         "Hello {guardian},\n\nAn account was registered for {name} ( {email} ), who is a minor. The account stays inactive until you give your consent by opening this link:\n\n{link}\n\nThe link expires in {ttl}. If you did not expect this email, you can ignore it.\n",
+	constants.MessageEmailTakenSubject:        "Sign-up attempt for your account",
+	constants.MessageEmailTakenBody:           "Hello,\n\nSomeone tried to create a new account with {email}, which already belongs to your account. If this was you, sign in instead. Otherwise you can ignore this email.\n",
+	constants.MessageRegistrationAccepted:     "Check your inbox to finish signing up",
+}
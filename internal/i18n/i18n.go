@@ -0,0 +1,102 @@
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"backend-task/internal/constants"
+)
+
+// Catalog Maps Message Keys ( Error Codes Or constants.Message* Keys ) To The Text Of One Language :
+type Catalog map[string]string
+
+var catalogs = map[string]Catalog{
+	constants.LanguageEnglish: english,
+	constants.LanguageArabic:  arabic,
+	constants.LanguageGerman:  german,
+}
+
+// Languages Returns The Supported Language Tags :
+func Languages() []string {
+
+	languages := make([]string, 0, len(catalogs))
+	for language := range catalogs {
+
+		languages = append(languages, language)
+	}
+
+	sort.Strings(languages)
+	return languages
+}
+
+// Lookup Returns The Message Of The Language Without Falling Back To English :
+func Lookup(language, key string) (string, bool) {
+
+	message, found := catalogs[language][key]
+	return message, found
+}
+
+// Text Returns The Message In The Language ( English When Missing ) With Its Placeholders Filled.
+// Replacements Are Name / Value Pairs, e.g. Text( "de", key, "name", "Jane" ) Fills {name} :
+func Text(language, key string, replacements ...string) string {
+
+	message, found := Lookup(language, key)
+	if !found {
+
+		message, found = Lookup(constants.DefaultLanguage, key)
+	}
+
+	if !found {
+
+		message = key
+	}
+
+	return Format(message, replacements...)
+}
+
+// Format Fills The {name} Placeholders Of message :
+func Format(message string, replacements ...string) string {
+
+	pairs := make([]string, 0, len(replacements))
+	for index := 0; index+1 < len(replacements); index += 2 {
+
+		pairs = append(pairs, "{"+replacements[index]+"}", replacements[index+1])
+	}
+
+	return strings.NewReplacer(pairs...).Replace(message)
+}
+
+// ---------------- Negotiation ----------------
+
+// Negotiate Picks The Supported Language The Accept-Language Header Prefers Most, e.g.
+// "ar-JO,ar;q=0.9,en;q=0.8" -> "ar". Regions Are Ignored; Anything Unsupported Gets The Default Language.
+func Negotiate(acceptLanguage string) string {
+
+	best, bestQuality := constants.DefaultLanguage, 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+
+				continue
+			}
+
+			quality = parsed
+		}
+
+		primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if _, supported := catalogs[primary]; !supported || quality <= bestQuality {
+
+			continue
+		}
+
+		best, bestQuality = primary, quality
+	}
+
+	return best
+}
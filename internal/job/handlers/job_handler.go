@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	constants "backend-task/internal/constants"
+	JobServiceInterface "backend-task/internal/job/services/interface"
+	userServices "backend-task/internal/user/services"
+	"backend-task/internal/utils"
+)
+
+type JobHandler struct {
+	Service JobServiceInterface.JobService
+}
+
+func NewJobHandler(s JobServiceInterface.JobService) *JobHandler {
+
+	return &JobHandler{Service: s}
+}
+
+// SubmitUserImport godoc
+// @Summary Submit a CSV user import as a background job.
+// @Description Accepts the same CSV as POST /users/import but processes it in the background. Returns 202 with the job; poll GET /jobs/{id} for progress. Duplicate emails are detected across the whole file.
+// @Tags jobs
+// @Accept text/csv
+// @Produce json
+// @Param dry_run query bool false "Validate only, without creating users"
+// @Param file body string true "CSV with a header row: name,email,date_of_birth"
+// @Success 202 {object} models.Job
+// @Header 202 {string} Location "URL of the job"
+// @Failure 400 {object} models.ErrorResponse "Invalid request. Possible reasons: malformed csv, missing columns, no data rows, too many rows, or invalid dry_run."
+// @Failure 415 {object} models.ErrorResponse "Content type must be text/csv"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /jobs/user-imports [post]
+func (jobHandler *JobHandler) SubmitUserImport(context *gin.Context) {
+
+	if context.ContentType() != constants.ContentTypeCSV {
+
+		utils.RespondError(context, utils.NewUnsupportedMediaType(utils.ErrUnsupportedContentType))
+		return
+	}
+
+	dryRun, err := strconv.ParseBool(context.DefaultQuery("dry_run", "false"))
+	if err != nil {
+
+		utils.RespondError(context, utils.NewBadRequest(utils.ErrInvalidDryRun))
+		return
+	}
+
+	rows, err := userServices.ParseImportCSV(http.MaxBytesReader(context.Writer, context.Request.Body, constants.ImportMaxBodyBytes))
+	if err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	job, err := jobHandler.Service.SubmitUserImport(context.Request.Context(), rows, dryRun)
+	if err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	context.Header(constants.HeaderLocation, fmt.Sprintf("/api/v1/jobs/%s", job.ID))
+	context.JSON(constants.StatusAccepted, job)
+}
+
+// GetJob godoc
+// @Summary Get job progress.
+// @Description Returns the job state ( queued, running, completed, failed, cancelled ) with processed / succeeded / failed counts, and a link to the error file once rows were rejected.
+// @Tags jobs
+// @Produce json
+// @Param id path string true "Job ID"
+// @Success 200 {object} models.Job
+// @Failure 400 {object} models.ErrorResponse "Invalid ID"
+// @Failure 404 {object} models.ErrorResponse "Job not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /jobs/{id} [get]
+func (jobHandler *JobHandler) GetJob(context *gin.Context) {
+
+	job, err := jobHandler.Service.GetJob(context.Request.Context(), context.Param("id"))
+	if err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	context.JSON(constants.StatusOK, job)
+}
+
+// CancelJob godoc
+// @Summary Cancel a job.
+// @Description Stops a queued or running job before its next chunk. Rows already committed are kept.
+// @Tags jobs
+// @Produce json
+// @Param id path string true "Job ID"
+// @Success 200 {object} models.Job
+// @Failure 400 {object} models.ErrorResponse "Invalid ID"
+// @Failure 404 {object} models.ErrorResponse "Job not found"
+// @Failure 409 {object} models.ErrorResponse "Job has already finished"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /jobs/{id}/cancel [post]
+func (jobHandler *JobHandler) CancelJob(context *gin.Context) {
+
+	job, err := jobHandler.Service.CancelJob(context.Request.Context(), context.Param("id"))
+	if err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	context.JSON(constants.StatusOK, job)
+}
+
+// DownloadErrors godoc
+// @Summary Download the rejected rows of a job.
+// @Description Streams a CSV of every invalid or failed row with its line number and error.
+// @Tags jobs
+// @Produce text/csv
+// @Param id path string true "Job ID"
+// @Success 200 {string} string "CSV: row,name,email,date_of_birth,status,error"
+// @Failure 400 {object} models.ErrorResponse "Invalid ID"
+// @Failure 404 {object} models.ErrorResponse "Job not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /jobs/{id}/errors [get]
+func (jobHandler *JobHandler) DownloadErrors(context *gin.Context) {
+
+	// Resolve The Job First, So Lookup Errors Are Still Sent As JSON :
+	job, err := jobHandler.Service.GetJob(context.Request.Context(), context.Param("id"))
+	if err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	context.Header("Content-Type", constants.ContentTypeCSV)
+	context.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"job-%s-errors.csv\"", job.ID))
+	context.Status(constants.StatusOK)
+
+	if err := jobHandler.Service.WriteErrorFile(context.Request.Context(), job.ID.String(), context.Writer); err != nil {
+
+		// Headers Are Already Sent; Log And Abort The Stream.
+		utils.Error(fmt.Sprintf("job error file: %v", err))
+		context.Abort()
+	}
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Job Is A Background Task ( e.g., A User Import ) Processed By The Job Workers.
+//
+// @Description Background Job State And Progress.
+type Job struct {
+
+	// Job Unique Identifier ( UUID ).
+	ID uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000" gorm:"type:uuid;primaryKey"`
+
+	// Tenant The Job Imports Into.
+	TenantID string `json:"tenant_id" example:"default" gorm:"not null;default:default;size:64"`
+
+	// Job Kind ( user_import ).
+	Kind string `json:"kind" example:"user_import" gorm:"not null;size:32"`
+
+	// Job State ( queued, running, completed, failed, cancelled ).
+	State string `json:"state" example:"running" gorm:"not null;index;size:16"`
+
+	// Validate Only, Without Creating Users.
+	DryRun bool `json:"dry_run" example:"false" gorm:"not null;default:false"`
+
+	// Number Of Rows In The Job.
+	Total int `json:"total" example:"5000"`
+
+	// Number Of Rows Processed So Far.
+	Processed int `json:"processed" example:"1500"`
+
+	// Rows Created ( Or Valid, For A Dry Run ).
+	Succeeded int `json:"succeeded" example:"1490"`
+
+	// Rows Rejected Or Not Committed ( See The Error File ).
+	Failed int `json:"failed" example:"10"`
+
+	// Last Finalized Source Line; Processing Resumes After It.
+	Checkpoint int `json:"-" gorm:"not null;default:0"`
+
+	// Reason The Job Failed.
+	Error string `json:"error,omitempty" gorm:"size:1024"`
+
+	// Download Link For The Rejected Rows ( Only When Failed > 0 ).
+	ErrorFile string `json:"error_file,omitempty" example:"/api/v1/jobs/550e8400-e29b-41d4-a716-446655440000/errors" gorm:"-"`
+
+	// Timestamp When The Job Was Submitted.
+	CreatedAt time.Time `json:"created_at" example:"2025-09-01T12:00:00Z"`
+
+	// Timestamp When A Worker First Picked The Job Up.
+	StartedAt *time.Time `json:"started_at,omitempty" example:"2025-09-01T12:00:01Z"`
+
+	// Timestamp When The Job Reached A Final State.
+	FinishedAt *time.Time `json:"finished_at,omitempty" example:"2025-09-01T12:03:00Z"`
+
+	// Timestamp When The Record Was Last Updated.
+	UpdatedAt time.Time `json:"updated_at" example:"2025-09-01T12:02:00Z"`
+}
+
+// Before Create Ensures UUID Is Set Automatically :
+func (job *Job) BeforeCreate(tx *gorm.DB) (err error) {
+
+	if job.ID == uuid.Nil {
+
+		job.ID = uuid.New()
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// Job Row Is One Input Row Of An Import Job, Persisted So The Job Survives Restarts
+// And Its Rejected Rows Can Be Downloaded Afterwards.
+type JobRow struct {
+
+	// Owning Job.
+	JobID uuid.UUID `gorm:"type:uuid;primaryKey"`
+
+	// Line Number In The Source File ( Increasing, Used As Checkpoint ).
+	Line int `gorm:"primaryKey;autoIncrement:false"`
+
+	Name        string `gorm:"size:255"`
+	Email       string `gorm:"size:320"`
+	DateOfBirth string `gorm:"size:32"`
+
+	// Optional Guardian Of A Minor.
+	GuardianName  string `gorm:"size:255"`
+	GuardianEmail string `gorm:"size:320"`
+
+	// Row Status ( pending, valid, invalid, created, failed ).
+	Status string `gorm:"not null;index;size:16"`
+
+	// Validation Or Commit Errors, Separated By "; ".
+	Error string `gorm:"size:1024"`
+
+	// Created User ( Status "created" Only ).
+	UserID *uuid.UUID `gorm:"type:uuid"`
+}
@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"backend-task/internal/constants"
+	"backend-task/internal/job/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Job Repository Interface :
+type JobRepository interface {
+	CreateJob(context context.Context, job *models.Job, rows []*models.JobRow) error
+	GetJob(context context.Context, id uuid.UUID) (*models.Job, error)
+	ClaimNextJob(context context.Context, now time.Time) (*models.Job, error)
+	RequeueRunningJobs(context context.Context) (int64, error)
+	FinishJob(context context.Context, id uuid.UUID, from []string, state, reason string, now time.Time) (bool, error)
+
+	FetchPendingRows(context context.Context, jobID uuid.UUID, afterLine, limit int) ([]*models.JobRow, error)
+	MarkRowsCreatedTx(gormDB *gorm.DB, jobID uuid.UUID, rows []*models.JobRow) error
+	SaveProgress(context context.Context, jobID uuid.UUID, rows []*models.JobRow, checkpoint int) error
+	ListErrorRows(context context.Context, jobID uuid.UUID, afterLine, limit int) ([]*models.JobRow, error)
+}
+
+// JobRepositoryDB Implementation :
+type JobRepositoryDB struct {
+	gormDB *gorm.DB
+}
+
+// Constructor :
+func NewJobRepository(db *gorm.DB) JobRepository {
+
+	return &JobRepositoryDB{gormDB: db}
+}
+
+// ---------------- Jobs ----------------
+
+// CreateJob Stores The Job Together With Its Input Rows :
+func (jobRepositoryDB *JobRepositoryDB) CreateJob(context context.Context, job *models.Job, rows []*models.JobRow) error {
+
+	return jobRepositoryDB.gormDB.WithContext(context).Transaction(func(gormDB *gorm.DB) error {
+
+		if err := gormDB.Create(job).Error; err != nil {
+
+			return err
+		}
+
+		for _, row := range rows {
+
+			row.JobID = job.ID
+		}
+
+		return gormDB.CreateInBatches(rows, constants.JobRowPageSize).Error
+	})
+}
+
+func (jobRepositoryDB *JobRepositoryDB) GetJob(context context.Context, id uuid.UUID) (*models.Job, error) {
+
+	var job models.Job
+	if err := jobRepositoryDB.gormDB.WithContext(context).First(&job, "id = ?", id).Error; err != nil {
+
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// ClaimNextJob Moves The Oldest Queued Job To "running" And Returns It ( nil When There Is None ).
+// The Conditional Update Ensures Only One Worker Wins A Job :
+func (jobRepositoryDB *JobRepositoryDB) ClaimNextJob(context context.Context, now time.Time) (*models.Job, error) {
+
+	gormDB := jobRepositoryDB.gormDB.WithContext(context)
+
+	var job models.Job
+	if err := gormDB.Where("state = ?", constants.JobQueued).Order("created_at ASC").First(&job).Error; err != nil {
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to find queued job: %w", err)
+	}
+
+	result := gormDB.Model(&models.Job{}).
+		Where("id = ? AND state = ?", job.ID, constants.JobQueued).
+		Updates(map[string]any{"state": constants.JobRunning, "started_at": gorm.Expr("COALESCE(started_at, ?)", now)})
+
+	if result.Error != nil {
+
+		return nil, fmt.Errorf("failed to claim job: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+
+		return nil, nil // Claimed Or Cancelled Concurrently.
+	}
+
+	return jobRepositoryDB.GetJob(context, job.ID)
+}
+
+// RequeueRunningJobs Returns Jobs Interrupted By A Shutdown Or Crash To The Queue :
+func (jobRepositoryDB *JobRepositoryDB) RequeueRunningJobs(context context.Context) (int64, error) {
+
+	result := jobRepositoryDB.gormDB.WithContext(context).Model(&models.Job{}).
+		Where("state = ?", constants.JobRunning).
+		Update("state", constants.JobQueued)
+
+	return result.RowsAffected, result.Error
+}
+
+// FinishJob Moves A Job From One Of The Given States To A Final State, Reporting Whether It Did :
+func (jobRepositoryDB *JobRepositoryDB) FinishJob(context context.Context, id uuid.UUID, from []string, state, reason string, now time.Time) (bool, error) {
+
+	result := jobRepositoryDB.gormDB.WithContext(context).Model(&models.Job{}).
+		Where("id = ? AND state IN ?", id, from).
+		Updates(map[string]any{"state": state, "error": reason, "finished_at": now})
+
+	return result.RowsAffected > 0, result.Error
+}
+
+// ---------------- Rows ----------------
+
+func (jobRepositoryDB *JobRepositoryDB) FetchPendingRows(context context.Context, jobID uuid.UUID, afterLine, limit int) ([]*models.JobRow, error) {
+
+	var rows []*models.JobRow
+	err := jobRepositoryDB.gormDB.WithContext(context).
+		Where("job_id = ? AND line > ? AND status = ?", jobID, afterLine, constants.ImportRowPending).
+		Order("line ASC").
+		Limit(limit).
+		Find(&rows).Error
+
+	return rows, err
+}
+
+// MarkRowsCreatedTx Records Created Rows Inside The Transaction That Created The Users,
+// So A Resumed Job Never Imports Them Twice :
+func (jobRepositoryDB *JobRepositoryDB) MarkRowsCreatedTx(gormDB *gorm.DB, jobID uuid.UUID, rows []*models.JobRow) error {
+
+	for _, row := range rows {
+
+		err := gormDB.Model(&models.JobRow{}).
+			Where("job_id = ? AND line = ?", jobID, row.Line).
+			Updates(map[string]any{"status": constants.ImportRowCreated, "error": "", "user_id": row.UserID}).Error
+
+		if err != nil {
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SaveProgress Stores The Outcome Of The Remaining Rows Of A Chunk, Advances The Checkpoint
+// And Recomputes The Job Counters From The Row Statuses :
+func (jobRepositoryDB *JobRepositoryDB) SaveProgress(context context.Context, jobID uuid.UUID, rows []*models.JobRow, checkpoint int) error {
+
+	return jobRepositoryDB.gormDB.WithContext(context).Transaction(func(gormDB *gorm.DB) error {
+
+		for _, row := range rows {
+
+			err := gormDB.Model(&models.JobRow{}).
+				Where("job_id = ? AND line = ?", jobID, row.Line).
+				Updates(map[string]any{"status": row.Status, "error": row.Error}).Error
+
+			if err != nil {
+
+				return err
+			}
+		}
+
+		var counts []struct {
+			Status string
+			Count  int
+		}
+
+		if err := gormDB.Model(&models.JobRow{}).Select("status, COUNT(*) AS count").Where("job_id = ?", jobID).Group("status").Scan(&counts).Error; err != nil {
+
+			return err
+		}
+
+		var processed, succeeded, failed int
+		for _, count := range counts {
+
+			switch count.Status {
+			case constants.ImportRowCreated, constants.ImportRowValid:
+				succeeded += count.Count
+
+			case constants.ImportRowInvalid, constants.ImportRowFailed:
+				failed += count.Count
+			}
+
+			if count.Status != constants.ImportRowPending {
+
+				processed += count.Count
+			}
+		}
+
+		return gormDB.Model(&models.Job{}).Where("id = ?", jobID).Updates(map[string]any{
+			"checkpoint": checkpoint,
+			"processed":  processed,
+			"succeeded":  succeeded,
+			"failed":     failed,
+		}).Error
+	})
+}
+
+// ListErrorRows Pages Through The Invalid And Failed Rows Of A Job :
+func (jobRepositoryDB *JobRepositoryDB) ListErrorRows(context context.Context, jobID uuid.UUID, afterLine, limit int) ([]*models.JobRow, error) {
+
+	var rows []*models.JobRow
+	err := jobRepositoryDB.gormDB.WithContext(context).
+		Where("job_id = ? AND line > ? AND status IN ?", jobID, afterLine, []string{constants.ImportRowInvalid, constants.ImportRowFailed}).
+		Order("line ASC").
+		Limit(limit).
+		Find(&rows).Error
+
+	return rows, err
+}
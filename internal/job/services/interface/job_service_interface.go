@@ -0,0 +1,25 @@
+package serviceInterface
+
+import (
+	"context"
+	"io"
+
+	"backend-task/internal/job/models"
+	userModels "backend-task/internal/user/models"
+)
+
+// Job Service Defines Submission And Tracking Of Background Jobs :
+type JobService interface {
+
+	// SubmitUserImport Queues The Rows Of An Import File For Background Processing.
+	SubmitUserImport(context context.Context, rows []userModels.ImportRow, dryRun bool) (*models.Job, error)
+
+	// GetJob Retrieves A Job With Its Progress By UUID.
+	GetJob(context context.Context, id string) (*models.Job, error)
+
+	// CancelJob Stops A Queued Or Running Job; Rows Already Committed Are Kept.
+	CancelJob(context context.Context, id string) (*models.Job, error)
+
+	// WriteErrorFile Writes The Rejected Rows Of A Job As CSV.
+	WriteErrorFile(context context.Context, id string, writer io.Writer) error
+}
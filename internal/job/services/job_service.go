@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"backend-task/internal/constants"
+	"backend-task/internal/job/models"
+	"backend-task/internal/job/repository"
+	jobServiceInterface "backend-task/internal/job/services/interface"
+	"backend-task/internal/tenant"
+	userModels "backend-task/internal/user/models"
+	userServices "backend-task/internal/user/services"
+	"backend-task/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type JobService struct {
+	jobs   repository.JobRepository
+	emails userServices.EmailPolicy // Same Canonical Form As The Import Itself.
+	now    func() time.Time
+}
+
+func NewJobService(jobs repository.JobRepository, emails userServices.EmailPolicy) jobServiceInterface.JobService {
+
+	return &JobService{jobs: jobs, emails: emails, now: time.Now}
+}
+
+// ---------------- Submit ----------------
+
+// SubmitUserImport Persists The Rows And Queues The Job. Duplicate Emails Are Detected Here,
+// Across The Whole File, Because Workers Only See One Chunk At A Time :
+func (jobService *JobService) SubmitUserImport(context context.Context, rows []userModels.ImportRow, dryRun bool) (*models.Job, error) {
+
+	job := &models.Job{TenantID: tenant.ID(context), Kind: constants.JobKindUserImport, State: constants.JobQueued, DryRun: dryRun, Total: len(rows)}
+	jobRows := make([]*models.JobRow, len(rows))
+	firstRowByEmail := make(map[string]int, len(rows))
+
+	for index, row := range rows {
+
+		jobRow := &models.JobRow{Line: row.Row, Name: row.Name, Email: row.Email, DateOfBirth: row.DateOfBirth, GuardianName: row.GuardianName, GuardianEmail: row.GuardianEmail, Status: constants.ImportRowPending}
+
+		email := jobService.emails.Canonical(row.Email)
+		firstRow, duplicate := firstRowByEmail[email]
+
+		switch {
+		case email == "":
+			// Malformed, Rejected By Validation Instead.
+
+		case duplicate:
+			jobRow.Status = constants.ImportRowInvalid
+			jobRow.Error = userServices.DuplicateEmailMessage(firstRow)
+			job.Processed++
+			job.Failed++
+
+		default:
+			firstRowByEmail[email] = row.Row
+		}
+
+		jobRows[index] = jobRow
+	}
+
+	if err := jobService.jobs.CreateJob(context, job, jobRows); err != nil {
+
+		return nil, err
+	}
+
+	return withErrorFile(job), nil
+}
+
+// ---------------- Query ----------------
+
+func (jobService *JobService) GetJob(context context.Context, id string) (*models.Job, error) {
+
+	uid, err := uuid.Parse(id)
+	if err != nil {
+
+		return nil, utils.NewBadRequest(utils.ErrInvalidID)
+	}
+
+	job, err := jobService.jobs.GetJob(context, uid)
+	if err != nil {
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+
+			return nil, utils.NewNotFound(utils.ErrJobNotFound)
+		}
+
+		return nil, err
+	}
+
+	// Jobs Of Other Tenants Do Not Exist For The Caller :
+	if job.TenantID != tenant.ID(context) {
+
+		return nil, utils.NewNotFound(utils.ErrJobNotFound)
+	}
+
+	return withErrorFile(job), nil
+}
+
+// ---------------- Cancel ----------------
+
+func (jobService *JobService) CancelJob(context context.Context, id string) (*models.Job, error) {
+
+	job, err := jobService.GetJob(context, id)
+	if err != nil {
+
+		return nil, err
+	}
+
+	// A Running Job Notices The State Change Before Its Next Chunk :
+	cancelled, err := jobService.jobs.FinishJob(context, job.ID, []string{constants.JobQueued, constants.JobRunning}, constants.JobCancelled, "", jobService.now())
+	if err != nil {
+
+		return nil, err
+	}
+
+	if !cancelled {
+
+		return nil, utils.NewConflict(utils.ErrJobNotCancellable)
+	}
+
+	return jobService.GetJob(context, id)
+}
+
+// ---------------- Error File ----------------
+
+func (jobService *JobService) WriteErrorFile(context context.Context, id string, writer io.Writer) error {
+
+	job, err := jobService.GetJob(context, id)
+	if err != nil {
+
+		return err
+	}
+
+	csvWriter := csv.NewWriter(writer)
+	if err := csvWriter.Write([]string{"row", constants.ImportColumnName, constants.ImportColumnEmail, constants.ImportColumnDateOfBirth, "status", "error"}); err != nil {
+
+		return err
+	}
+
+	// Page Through The Rejected Rows To Keep Memory Constant :
+	afterLine := 0
+	for {
+
+		rows, err := jobService.jobs.ListErrorRows(context, job.ID, afterLine, constants.JobRowPageSize)
+		if err != nil {
+
+			return err
+		}
+
+		for _, row := range rows {
+
+			if err := csvWriter.Write([]string{strconv.Itoa(row.Line), row.Name, row.Email, row.DateOfBirth, row.Status, row.Error}); err != nil {
+
+				return err
+			}
+		}
+
+		csvWriter.Flush()
+		if err := csvWriter.Error(); err != nil {
+
+			return err
+		}
+
+		if len(rows) < constants.JobRowPageSize {
+
+			return nil
+		}
+
+		afterLine = rows[len(rows)-1].Line
+	}
+}
+
+// ---------------- Helper ----------------
+
+// withErrorFile Links The Error File Once The Job Has Rejected Rows :
+func withErrorFile(job *models.Job) *models.Job {
+
+	if job.Failed > 0 {
+
+		job.ErrorFile = fmt.Sprintf("/api/v1/jobs/%s/errors", job.ID)
+	}
+
+	return job
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"backend-task/internal/constants"
+	"backend-task/internal/job/models"
+	"backend-task/internal/job/repository"
+	"backend-task/internal/tenant"
+	userModels "backend-task/internal/user/models"
+	userServiceInterface "backend-task/internal/user/services/interface"
+	"backend-task/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+// Worker Config Tunes Job Processing :
+type WorkerConfig struct {
+	Workers      int // Jobs Processed Concurrently.
+	PollInterval time.Duration
+	ChunkSize    int              // Rows Per Checkpoint.
+	Now          func() time.Time // Injectable Clock.
+}
+
+// Worker Processes Queued Jobs Chunk By Chunk Against The User Service. Each Chunk Is Committed In One
+// Transaction Together With Its Row Statuses, So An Interrupted Job Resumes Without Duplicates :
+type Worker struct {
+	jobs   repository.JobRepository
+	users  userServiceInterface.UserService
+	config WorkerConfig
+}
+
+func NewWorker(jobs repository.JobRepository, users userServiceInterface.UserService, config WorkerConfig) *Worker {
+
+	if config.Now == nil {
+
+		config.Now = time.Now
+	}
+
+	if config.Workers <= 0 {
+
+		config.Workers = constants.DefaultJobWorkers
+	}
+
+	if config.ChunkSize <= 0 {
+
+		config.ChunkSize = constants.DefaultJobChunkSize
+	}
+
+	return &Worker{jobs: jobs, users: users, config: config}
+}
+
+// Run Resumes Interrupted Jobs, Then Processes Jobs Until The Context Is Cancelled :
+func (worker *Worker) Run(context context.Context) {
+
+	if requeued, err := worker.Resume(context); err != nil {
+
+		utils.Error(fmt.Sprintf("job worker: %v", err))
+	} else if requeued > 0 {
+
+		utils.Info(fmt.Sprintf("job worker: resuming %d interrupted job(s)", requeued))
+	}
+
+	var waitGroup sync.WaitGroup
+	for range worker.config.Workers {
+
+		waitGroup.Add(1)
+		go func() {
+
+			defer waitGroup.Done()
+			worker.loop(context)
+		}()
+	}
+
+	waitGroup.Wait()
+}
+
+func (worker *Worker) loop(context context.Context) {
+
+	ticker := time.NewTicker(worker.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+
+		processed, err := worker.RunNext(context)
+		if context.Err() != nil {
+
+			return
+		}
+
+		if err != nil {
+
+			utils.Error(fmt.Sprintf("job worker: %v", err))
+		}
+
+		// Drain The Queue Before Sleeping :
+		if processed && err == nil {
+
+			continue
+		}
+
+		select {
+		case <-context.Done():
+			return
+
+		case <-ticker.C:
+		}
+	}
+}
+
+// Resume Requeues Jobs Left "running" By A Previous Shutdown Or Crash; They Continue From Their Checkpoint.
+// This Assumes A Single Application Instance Owns The Job Tables :
+func (worker *Worker) Resume(context context.Context) (int64, error) {
+
+	return worker.jobs.RequeueRunningJobs(context)
+}
+
+// RunNext Claims The Oldest Queued Job And Processes It, Reporting Whether There Was One :
+func (worker *Worker) RunNext(context context.Context) (bool, error) {
+
+	job, err := worker.jobs.ClaimNextJob(context, worker.config.Now().UTC())
+	if err != nil || job == nil {
+
+		return false, err
+	}
+
+	// Rows Are Imported Into The Tenant That Submitted The Job :
+	if err := worker.process(tenant.WithID(context, job.TenantID), job); err != nil {
+
+		// On Shutdown The Job Stays "running" And Is Resumed On Restart :
+		if context.Err() != nil {
+
+			return true, nil
+		}
+
+		if _, finishErr := worker.jobs.FinishJob(context, job.ID, []string{constants.JobRunning}, constants.JobFailed, err.Error(), worker.config.Now().UTC()); finishErr != nil {
+
+			return true, finishErr
+		}
+
+		return true, fmt.Errorf("job %s failed: %w", job.ID, err)
+	}
+
+	return true, nil
+}
+
+func (worker *Worker) process(context context.Context, job *models.Job) error {
+
+	checkpoint := job.Checkpoint
+	for {
+
+		if err := context.Err(); err != nil {
+
+			return err
+		}
+
+		// Stop Between Chunks Once The Job Was Cancelled :
+		current, err := worker.jobs.GetJob(context, job.ID)
+		if err != nil {
+
+			return err
+		}
+
+		if current.State != constants.JobRunning {
+
+			return nil
+		}
+
+		rows, err := worker.jobs.FetchPendingRows(context, job.ID, checkpoint, worker.config.ChunkSize)
+		if err != nil {
+
+			return err
+		}
+
+		if len(rows) == 0 {
+
+			// Refresh The Counters In Case The Last Chunk Committed Before An Interruption :
+			if err := worker.jobs.SaveProgress(context, job.ID, nil, checkpoint); err != nil {
+
+				return err
+			}
+
+			_, err := worker.jobs.FinishJob(context, job.ID, []string{constants.JobRunning}, constants.JobCompleted, "", worker.config.Now().UTC())
+			return err
+		}
+
+		checkpoint = rows[len(rows)-1].Line
+		if err := worker.processChunk(context, job, rows, checkpoint); err != nil {
+
+			return err
+		}
+	}
+}
+
+// processChunk Imports One Chunk In A Single Transaction ( Row By Row When It Fails ); Created Rows Are Marked Inside It :
+func (worker *Worker) processChunk(context context.Context, job *models.Job, rows []*models.JobRow, checkpoint int) error {
+
+	importRows := make([]userModels.ImportRow, len(rows))
+	for index, row := range rows {
+
+		importRows[index] = userModels.ImportRow{Row: row.Line, Name: row.Name, Email: row.Email, DateOfBirth: row.DateOfBirth, GuardianName: row.GuardianName, GuardianEmail: row.GuardianEmail}
+	}
+
+	options := userModels.ImportOptions{
+
+		DryRun:    job.DryRun,
+		BatchSize: len(rows),
+		OnBatchTx: func(gormDB *gorm.DB, batch []userModels.ImportRowResult) error {
+
+			created := make([]*models.JobRow, len(batch))
+			for index, result := range batch {
+
+				created[index] = &models.JobRow{Line: result.Row, UserID: result.UserID}
+			}
+
+			return worker.jobs.MarkRowsCreatedTx(gormDB, job.ID, created)
+		},
+	}
+
+	report, err := worker.users.ImportUsers(context, importRows, options)
+	if err != nil {
+
+		return err
+	}
+
+	// Persist Everything Not Already Marked Inside The Batch Transaction :
+	var remaining []*models.JobRow
+	for _, result := range report.Rows {
+
+		if result.Status == constants.ImportRowCreated {
+
+			continue
+		}
+
+		remaining = append(remaining, &models.JobRow{Line: result.Row, Status: result.Status, Error: strings.Join(result.Errors, "; ")})
+	}
+
+	return worker.jobs.SaveProgress(context, job.ID, remaining, checkpoint)
+}
@@ -0,0 +1,380 @@
+package mail
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+	"golang.org/x/text/unicode/norm"
+)
+
+// ---------------- Address Parsing ( RFC 5321 / RFC 6531 ) ----------------
+
+const (
+	maxLocalLength   = 64  // Octets, RFC 5321 Section 4.5.3.1.1.
+	maxAddressLength = 254 // Octets Of local@domain That Fit In A Forward-Path.
+)
+
+// atextSpecials Are The Printable ASCII Characters Allowed In An Unquoted Local Part Besides Letters And Digits :
+const atextSpecials = "!#$%&'*+-/=?^_`{|}~"
+
+var errInvalidAddress = errors.New("invalid email address")
+
+// domainProfile Maps Unicode Domains To Lower-Case ASCII ( Punycode ) And Enforces DNS Label Rules :
+var domainProfile = idna.New(idna.MapForLookup(), idna.BidiRule(), idna.VerifyDNSLength(true), idna.Transitional(false))
+
+// Address Is A Parsed Mailbox. Local Is Kept As Written ( Including Quotes );
+// Domain Is Lower-Case ASCII, With Internationalized Labels In Punycode.
+type Address struct {
+	Local  string
+	Domain string
+}
+
+// ParseAddress Accepts Dot-Atom And Quoted Local Parts, UTF-8 Included ( RFC 6531 ),
+// And Internationalized Domain Names. Address Literals ( user@[192.0.2.1] ) Are Refused.
+func ParseAddress(raw string) (Address, error) {
+
+	raw = strings.TrimSpace(raw)
+	if !utf8.ValidString(raw) {
+
+		return Address{}, errInvalidAddress
+	}
+
+	local, domain, err := splitAddress(raw)
+	if err != nil {
+
+		return Address{}, err
+	}
+
+	if len(local) > maxLocalLength {
+
+		return Address{}, fmt.Errorf("%w: local part longer than %d octets", errInvalidAddress, maxLocalLength)
+	}
+
+	asciiDomain, err := parseDomain(domain)
+	if err != nil {
+
+		return Address{}, err
+	}
+
+	address := Address{Local: local, Domain: asciiDomain}
+	if len(address.String()) > maxAddressLength {
+
+		return Address{}, fmt.Errorf("%w: longer than %d octets", errInvalidAddress, maxAddressLength)
+	}
+
+	return address, nil
+}
+
+// String Returns local@domain With The Domain In ASCII Form :
+func (address Address) String() string {
+
+	return address.Local + "@" + address.Domain
+}
+
+// Canonical Returns The Form Used To Detect Duplicates: Redundant Quotes Removed, Unicode In NFC,
+// Lower-Cased, And ( When foldAliases Is Set ) Provider Aliases Such As Gmail Dots And +tags Folded.
+func (address Address) Canonical(foldAliases bool) string {
+
+	local := strings.ToLower(norm.NFC.String(unquote(address.Local)))
+	domain := address.Domain
+
+	if provider, known := providers[domain]; known && foldAliases {
+
+		local, domain = provider.fold(local), provider.domain
+	}
+
+	if !isDotAtom(local) {
+
+		local = quote(local)
+	}
+
+	return local + "@" + domain
+}
+
+func splitAddress(raw string) (string, string, error) {
+
+	if strings.HasPrefix(raw, `"`) {
+
+		end := closingQuote(raw)
+		if end < 0 || end+1 >= len(raw) || raw[end+1] != '@' {
+
+			return "", "", errInvalidAddress
+		}
+
+		return raw[:end+1], raw[end+2:], nil
+	}
+
+	local, domain, found := strings.Cut(raw, "@")
+	if !found || !isDotAtom(local) {
+
+		return "", "", errInvalidAddress
+	}
+
+	return local, domain, nil
+}
+
+// closingQuote Returns The Index Of The Quote Ending The Quoted String At The Start Of raw, Or -1 :
+func closingQuote(raw string) int {
+
+	escaped := false
+	for index, char := range raw[1:] {
+
+		switch {
+		case escaped:
+			if !isQuotedChar(char) && char != '"' && char != '\\' {
+
+				return -1
+			}
+			escaped = false
+
+		case char == '\\':
+			escaped = true
+
+		case char == '"':
+			return index + 1
+
+		case !isQuotedChar(char):
+			return -1
+		}
+	}
+
+	return -1
+}
+
+func parseDomain(domain string) (string, error) {
+
+	if domain == "" || strings.HasPrefix(domain, "[") || strings.HasSuffix(domain, ".") {
+
+		return "", errInvalidAddress
+	}
+
+	ascii, err := domainProfile.ToASCII(domain)
+	if err != nil {
+
+		return "", fmt.Errorf("%w: %v", errInvalidAddress, err)
+	}
+
+	// A Public Mailbox Needs A Dotted Name Whose Last Label Is Not Numeric :
+	labels := strings.Split(ascii, ".")
+	tld := labels[len(labels)-1]
+	if len(labels) < 2 || len(tld) < 2 || strings.Trim(tld, "0123456789") == "" {
+
+		return "", errInvalidAddress
+	}
+
+	return ascii, nil
+}
+
+func isDotAtom(local string) bool {
+
+	if local == "" {
+
+		return false
+	}
+
+	for _, atom := range strings.Split(local, ".") {
+
+		if atom == "" {
+
+			return false
+		}
+
+		for _, char := range atom {
+
+			if !isAtext(char) {
+
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func isAtext(char rune) bool {
+
+	if char >= utf8.RuneSelf {
+
+		return unicode.IsGraphic(char) && !unicode.IsSpace(char)
+	}
+
+	return 'a' <= char && char <= 'z' || 'A' <= char && char <= 'Z' || '0' <= char && char <= '9' || strings.ContainsRune(atextSpecials, char)
+}
+
+// isQuotedChar Reports Whether char May Appear Unescaped Inside A Quoted Local Part :
+func isQuotedChar(char rune) bool {
+
+	if char >= utf8.RuneSelf {
+
+		return unicode.IsGraphic(char)
+	}
+
+	return char == ' ' || char >= '!' && char <= '~' && char != '"' && char != '\\'
+}
+
+func unquote(local string) string {
+
+	if !strings.HasPrefix(local, `"`) {
+
+		return local
+	}
+
+	var builder strings.Builder
+	escaped := false
+	for _, char := range local[1 : len(local)-1] {
+
+		if char == '\\' && !escaped {
+
+			escaped = true
+			continue
+		}
+
+		escaped = false
+		builder.WriteRune(char)
+	}
+
+	return builder.String()
+}
+
+func quote(local string) string {
+
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + replacer.Replace(local) + `"`
+}
+
+// ---------------- Provider Aliases ----------------
+
+// provider Describes How A Mailbox Provider Routes Variants Of One Address To The Same Inbox :
+type provider struct {
+	domain   string // Canonical Domain ( e.g., googlemail.com Is gmail.com ).
+	dropTag  bool   // "jane+news" Delivers To "jane".
+	dropDots bool   // "j.a.n.e" Delivers To "jane".
+}
+
+var providers = map[string]provider{
+	"gmail.com":      {domain: "gmail.com", dropTag: true, dropDots: true},
+	"googlemail.com": {domain: "gmail.com", dropTag: true, dropDots: true},
+	"outlook.com":    {domain: "outlook.com", dropTag: true},
+	"hotmail.com":    {domain: "hotmail.com", dropTag: true},
+	"live.com":       {domain: "live.com", dropTag: true},
+	"icloud.com":     {domain: "icloud.com", dropTag: true},
+	"me.com":         {domain: "icloud.com", dropTag: true},
+	"mac.com":        {domain: "icloud.com", dropTag: true},
+	"fastmail.com":   {domain: "fastmail.com", dropTag: true},
+	"protonmail.com": {domain: "proton.me", dropTag: true},
+	"proton.me":      {domain: "proton.me", dropTag: true},
+}
+
+func (provider provider) fold(local string) string {
+
+	folded := local
+	if before, _, tagged := strings.Cut(folded, "+"); tagged && provider.dropTag {
+
+		folded = before
+	}
+
+	if provider.dropDots {
+
+		folded = strings.ReplaceAll(folded, ".", "")
+	}
+
+	// "+news@gmail.com" Has No Mailbox Left To Fold Into :
+	if folded == "" {
+
+		return local
+	}
+
+	return folded
+}
+
+// ---------------- Disposable Domains ----------------
+
+// DomainBlocklist Holds Domains Whose Addresses Are Refused; Subdomains Of A Listed Domain Match Too.
+// A nil List Blocks Nothing.
+type DomainBlocklist struct {
+	domains map[string]struct{}
+}
+
+// NewDomainBlocklist Builds A List From Domain Names ( Unicode Or Punycode ) :
+func NewDomainBlocklist(domains ...string) (*DomainBlocklist, error) {
+
+	list := &DomainBlocklist{domains: make(map[string]struct{}, len(domains))}
+	for _, domain := range domains {
+
+		ascii, err := domainProfile.ToASCII(strings.TrimSpace(domain))
+		if err != nil {
+
+			return nil, fmt.Errorf("blocklist domain %q: %w", domain, err)
+		}
+
+		list.domains[ascii] = struct{}{}
+	}
+
+	return list, nil
+}
+
+// LoadDomainBlocklist Reads One Domain Per Line; Blank Lines And "#" Comments Are Skipped.
+// An Empty Path Means No Blocklist.
+func LoadDomainBlocklist(path string) (*DomainBlocklist, error) {
+
+	if path == "" {
+
+		return nil, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+
+		return nil, err
+	}
+	defer file.Close()
+
+	var domains []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if line = strings.TrimSpace(line); line != "" {
+
+			domains = append(domains, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+
+		return nil, err
+	}
+
+	return NewDomainBlocklist(domains...)
+}
+
+// Blocks Reports Whether The ASCII Domain Or One Of Its Parents Is Listed :
+func (list *DomainBlocklist) Blocks(domain string) bool {
+
+	if list == nil {
+
+		return false
+	}
+
+	for {
+
+		if _, listed := list.domains[domain]; listed {
+
+			return true
+		}
+
+		_, parent, found := strings.Cut(domain, ".")
+		if !found {
+
+			return false
+		}
+
+		domain = parent
+	}
+}
@@ -0,0 +1,146 @@
+package mail
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/smtp"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Message Is A Plain Text Email :
+type Message struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// Mailer Sends Transactional Emails ( e.g., Verification Links ) :
+type Mailer interface {
+	Send(context context.Context, message Message) error
+}
+
+// ---------------- In-Memory Mailer ( Tests ) ----------------
+
+// InMemoryMailer Keeps Every Sent Message In Memory :
+type InMemoryMailer struct {
+	mutex    sync.Mutex
+	messages []Message
+}
+
+func NewInMemoryMailer() *InMemoryMailer {
+
+	return &InMemoryMailer{}
+}
+
+func (inMemoryMailer *InMemoryMailer) Send(context context.Context, message Message) error {
+
+	inMemoryMailer.mutex.Lock()
+	defer inMemoryMailer.mutex.Unlock()
+
+	inMemoryMailer.messages = append(inMemoryMailer.messages, message)
+	return nil
+}
+
+// Messages Returns A Copy Of Everything Sent So Far :
+func (inMemoryMailer *InMemoryMailer) Messages() []Message {
+
+	inMemoryMailer.mutex.Lock()
+	defer inMemoryMailer.mutex.Unlock()
+
+	return append([]Message(nil), inMemoryMailer.messages...)
+}
+
+// ---------------- File Mailer ( Local Development ) ----------------
+
+// FileMailer Appends Every Message As One JSON Line ( NDJSON ) To A File :
+type FileMailer struct {
+	mutex sync.Mutex
+	path  string
+}
+
+func NewFileMailer(path string) *FileMailer {
+
+	return &FileMailer{path: path}
+}
+
+func (fileMailer *FileMailer) Send(context context.Context, message Message) error {
+
+	raw, err := json.Marshal(message)
+	if err != nil {
+
+		return err
+	}
+
+	fileMailer.mutex.Lock()
+	defer fileMailer.mutex.Unlock()
+
+	file, err := os.OpenFile(fileMailer.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(raw, '\n'))
+	return err
+}
+
+// ---------------- SMTP Mailer ----------------
+
+// SMTPConfig Holds The Relay Settings; Authentication Is Skipped Without A Username :
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// SMTPMailer Sends Through An SMTP Relay ( STARTTLS When The Server Offers It ) :
+type SMTPMailer struct {
+	config SMTPConfig
+}
+
+func NewSMTPMailer(config SMTPConfig) *SMTPMailer {
+
+	return &SMTPMailer{config: config}
+}
+
+func (smtpMailer *SMTPMailer) Send(context context.Context, message Message) error {
+
+	var auth smtp.Auth
+	if smtpMailer.config.Username != "" {
+
+		auth = smtp.PlainAuth("", smtpMailer.config.Username, smtpMailer.config.Password, smtpMailer.config.Host)
+	}
+
+	address := net.JoinHostPort(smtpMailer.config.Host, smtpMailer.config.Port)
+	if err := smtp.SendMail(address, auth, smtpMailer.config.From, []string{message.To}, smtpMailer.compose(message)); err != nil {
+
+		return fmt.Errorf("smtp send to %s: %w", address, err)
+	}
+
+	return nil
+}
+
+// compose Builds The RFC 5322 Message; Header Values Are Stripped Of Line Breaks :
+func (smtpMailer *SMTPMailer) compose(message Message) []byte {
+
+	header := strings.NewReplacer("\r", "", "\n", "")
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "From: %s\r\n", header.Replace(smtpMailer.config.From))
+	fmt.Fprintf(&builder, "To: %s\r\n", header.Replace(message.To))
+	fmt.Fprintf(&builder, "Subject: %s\r\n", header.Replace(message.Subject))
+	fmt.Fprintf(&builder, "Date: %s\r\n", time.Now().UTC().Format(time.RFC1123Z))
+	builder.WriteString("MIME-Version: 1.0\r\n")
+	builder.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	builder.WriteString(strings.ReplaceAll(message.Body, "\n", "\r\n"))
+
+	return []byte(builder.String())
+}
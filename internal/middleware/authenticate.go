@@ -0,0 +1,173 @@
+package middleware
+
+import (
+	"strings"
+
+	"backend-task/internal/auth/models"
+	service "backend-task/internal/auth/services"
+	serviceInterface "backend-task/internal/auth/services/interface"
+	"backend-task/internal/constants"
+	"backend-task/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Authenticators Holds What Authenticate Needs To Identify A Caller :
+type Authenticators struct {
+	Verifier   *service.JWTVerifier            // Bearer Tokens.
+	Authorizer *service.Authorizer             // Resolves Token Roles Into Permissions.
+	Sessions   serviceInterface.AccountService // Revocation Of Tokens Issued By Password Login ( Optional ).
+	APIKeys    serviceInterface.APIKeyService  // X-API-Key Header ( Optional ).
+	Bypass     []string                        // Public Paths.
+}
+
+// Authenticate Requires A Valid Bearer Token Or API Key On Every Path Outside The Bypass List.
+// Verified Claims Are Stored In The Gin Context, The Resolved Principal In The Request Context,
+// And The Subject Becomes The Audit Actor.
+func Authenticate(authenticators Authenticators) gin.HandlerFunc {
+
+	verifier, authorizer := authenticators.Verifier, authenticators.Authorizer
+
+	return func(context *gin.Context) {
+
+		if isBypassed(context.Request.URL.Path, authenticators.Bypass) {
+
+			context.Next()
+			return
+		}
+
+		// Machine Clients Send An API Key Instead Of A Token :
+		if key := context.GetHeader(constants.HeaderAPIKey); key != "" && authenticators.APIKeys != nil {
+
+			principal, err := authenticators.APIKeys.Authenticate(context.Request.Context(), key, context.ClientIP())
+			if err != nil {
+
+				utils.RespondError(context, err)
+				context.Abort()
+				return
+			}
+
+			setPrincipal(context, principal)
+			context.Next()
+			return
+		}
+
+		header := context.GetHeader(constants.HeaderAuthorization)
+		if len(header) <= len(constants.BearerPrefix) || !strings.EqualFold(header[:len(constants.BearerPrefix)], constants.BearerPrefix) {
+
+			rejectUnauthorized(context, utils.ErrMissingBearerToken)
+			return
+		}
+
+		claims, err := verifier.Verify(strings.TrimSpace(header[len(constants.BearerPrefix):]))
+		if err != nil {
+
+			utils.Info("rejected bearer token: " + err.Error())
+			rejectUnauthorized(context, utils.ErrInvalidToken)
+			return
+		}
+
+		// Tokens From Password Login Die With Their Session ( Logout, Reuse, Password Change ) :
+		if claims.SessionID != "" && authenticators.Sessions != nil {
+
+			active, err := authenticators.Sessions.SessionActive(context.Request.Context(), claims.SessionID)
+			if err != nil {
+
+				utils.RespondError(context, err)
+				context.Abort()
+				return
+			}
+
+			if !active {
+
+				rejectUnauthorized(context, utils.ErrSessionRevoked)
+				return
+			}
+		}
+
+		context.Set(constants.ContextKeyClaims, claims)
+		setPrincipal(context, authorizer.Principal(claims))
+
+		context.Next()
+	}
+}
+
+// AllowAll Treats Every Caller As An Anonymous Admin ( Used When Authentication Is Disabled ) :
+func AllowAll() gin.HandlerFunc {
+
+	principal := &models.Principal{Subject: constants.AuditAnonymousActor, Roles: []string{constants.RoleAdmin}, Permissions: map[string]bool{}, MFA: true}
+	for _, permission := range constants.AllPermissions {
+
+		principal.Permissions[permission] = true
+	}
+
+	return func(context *gin.Context) {
+
+		setPrincipal(context, principal)
+		context.Next()
+	}
+}
+
+func setPrincipal(context *gin.Context, principal *models.Principal) {
+
+	ctx := models.WithPrincipal(context.Request.Context(), principal)
+
+	meta := utils.RequestMetaFromContext(ctx)
+	meta.ActorID = principal.Subject
+	context.Request = context.Request.WithContext(utils.WithRequestMeta(ctx, meta))
+}
+
+// ClaimsFromContext Returns The Verified Claims Of The Caller ( nil When Unauthenticated ) :
+func ClaimsFromContext(context *gin.Context) *models.Claims {
+
+	claims, _ := context.Get(constants.ContextKeyClaims)
+	verified, _ := claims.(*models.Claims)
+
+	return verified
+}
+
+// ParseList Splits A Comma Separated Setting ( e.g., Bypass Paths, Origins ) :
+func ParseList(raw string) []string {
+
+	var paths []string
+	for _, path := range strings.Split(raw, ",") {
+
+		if path = strings.TrimSpace(path); path != "" {
+
+			paths = append(paths, path)
+		}
+	}
+
+	return paths
+}
+
+// isBypassed Matches Exact Paths, Or Prefixes When The Entry Ends With "*" :
+func isBypassed(path string, bypass []string) bool {
+
+	for _, entry := range bypass {
+
+		if prefix, ok := strings.CutSuffix(entry, "*"); ok {
+
+			if strings.HasPrefix(path, prefix) {
+
+				return true
+			}
+
+			continue
+		}
+
+		if path == entry {
+
+			return true
+		}
+	}
+
+	return false
+}
+
+func rejectUnauthorized(context *gin.Context, err error) {
+
+	context.Header(constants.HeaderWWWAuthenticate, `Bearer realm="api"`)
+	utils.RespondError(context, utils.NewUnauthorized(err))
+	context.Abort()
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"time"
+
+	"backend-task/internal/constants"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+)
+
+// CORS Allows The Given Origins ( "*" Allows Any ) And The Headers The API Reads.
+// Without Origins No CORS Headers Are Sent, So Browsers Only Allow Same-Origin Calls.
+func CORS(origins []string) gin.HandlerFunc {
+
+	if len(origins) == 0 {
+
+		return func(context *gin.Context) {
+
+			context.Next()
+		}
+	}
+
+	config := cors.Config{
+		AllowMethods:  []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"},
+		AllowHeaders:  []string{"Origin", "Content-Type", "Accept", constants.HeaderAuthorization, constants.HeaderRequestID, constants.HeaderLastEventID, constants.HeaderAPIKey, constants.HeaderTenantID},
+		ExposeHeaders: []string{constants.HeaderRequestID, constants.HeaderLocation, constants.HeaderWWWAuthenticate},
+		MaxAge:        12 * time.Hour,
+	}
+
+	if len(origins) == 1 && origins[0] == "*" {
+
+		config.AllowAllOrigins = true
+	} else {
+
+		config.AllowOrigins = origins
+	}
+
+	return cors.New(config)
+}
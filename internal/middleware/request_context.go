@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"backend-task/internal/constants"
+	"backend-task/internal/i18n"
+	"backend-task/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestContext Assigns A Request ID ( Or Reuses The Caller's One ), Negotiates The Language And Stores
+// The Request Meta In The Request Context So Lower Layers ( e.g., Audit, Emails ) Can Read It.
+func RequestContext() gin.HandlerFunc {
+
+	return func(context *gin.Context) {
+
+		requestID := context.GetHeader(constants.HeaderRequestID)
+		if requestID == "" || len(requestID) > 64 {
+
+			requestID = uuid.NewString()
+		}
+
+		meta := utils.RequestMeta{
+			RequestID: requestID,
+			ActorID:   constants.AuditAnonymousActor,
+			ClientIP:  context.ClientIP(),
+			Language:  i18n.Negotiate(context.GetHeader(constants.HeaderAcceptLanguage)),
+		}
+
+		context.Request = context.Request.WithContext(utils.WithRequestMeta(context.Request.Context(), meta))
+		context.Header(constants.HeaderRequestID, requestID)
+
+		context.Next()
+	}
+}
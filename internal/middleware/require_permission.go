@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"fmt"
+
+	"backend-task/internal/auth/models"
+	"backend-task/internal/constants"
+	"backend-task/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequirePermission Rejects Callers Lacking The Permission With A 403.
+// Sensitive Permissions Also Need A Token With An MFA Claim, Otherwise The Caller Gets A 401 Step-Up Challenge.
+func RequirePermission(permission string) gin.HandlerFunc {
+
+	return func(context *gin.Context) {
+
+		principal := models.PrincipalFromContext(context.Request.Context())
+		if principal == nil {
+
+			rejectUnauthorized(context, utils.ErrMissingBearerToken)
+			return
+		}
+
+		if !principal.Can(permission) {
+
+			utils.RespondError(context, utils.NewForbidden(fmt.Errorf("%w: requires %s", utils.ErrPermissionDenied, permission)))
+			context.Abort()
+			return
+		}
+
+		if constants.MFARequiredPermissions[permission] && !principal.MFA {
+
+			context.Header(constants.HeaderWWWAuthenticate, `Bearer error="insufficient_user_authentication", error_description="multi-factor authentication required"`)
+			utils.RespondError(context, utils.NewUnauthorized(utils.ErrMFARequired))
+			context.Abort()
+			return
+		}
+
+		context.Next()
+	}
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"backend-task/internal/auth/models"
+	"backend-task/internal/constants"
+	"backend-task/internal/tenant"
+	"backend-task/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ResolveTenant Scopes The Request To A Tenant. Authenticated Callers Always Use Their Own ( Token Claim Or
+// API Key; The Default Tenant When They Carry None, e.g. External Tokens Or Disabled Auth ) And May Not Name
+// Another. Only Unauthenticated Requests To Public Paths ( Register, Login, Emailed Links ) Name It With The
+// X-Tenant-ID Header Or The "tenant" Query Parameter, Defaulting To The Default Tenant. Runs After Authentication.
+func ResolveTenant() gin.HandlerFunc {
+
+	return func(context *gin.Context) {
+
+		requested := context.GetHeader(constants.HeaderTenantID)
+		if requested == "" {
+
+			requested = context.Query(constants.QueryTenantID)
+		}
+
+		if requested != "" && !tenant.ValidID(requested) {
+
+			utils.RespondError(context, utils.NewBadRequest(utils.ErrInvalidTenantID))
+			context.Abort()
+			return
+		}
+
+		tenantID := requested
+		if principal := models.PrincipalFromContext(context.Request.Context()); principal != nil {
+
+			bound := principal.TenantID
+			if bound == "" {
+
+				bound = constants.DefaultTenantID
+			}
+
+			if requested != "" && requested != bound {
+
+				utils.RespondError(context, utils.NewForbidden(utils.ErrTenantMismatch))
+				context.Abort()
+				return
+			}
+
+			tenantID = bound
+		}
+
+		if tenantID == "" {
+
+			tenantID = constants.DefaultTenantID
+		}
+
+		context.Request = context.Request.WithContext(tenant.WithID(context.Request.Context(), tenantID))
+		context.Next()
+	}
+}
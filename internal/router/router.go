@@ -1,43 +1,124 @@
 package router
 
 import (
+	"strings"
+
+	auditHandlers "backend-task/internal/audit/handlers"
+	authHandlers "backend-task/internal/auth/handlers"
+	authServices "backend-task/internal/auth/services"
+	"backend-task/internal/config"
+	"backend-task/internal/constants"
+	eventHandlers "backend-task/internal/events/handlers"
+	eventServices "backend-task/internal/events/services"
+	jobHandlers "backend-task/internal/job/handlers"
+	"backend-task/internal/middleware"
 	"backend-task/internal/user/handlers"
-	"backend-task/internal/user/repository"
-	services "backend-task/internal/user/services"
 	UserServiceInterface "backend-task/internal/user/services/interface"
+	"backend-task/internal/utils"
+	webhookHandlers "backend-task/internal/webhook/handlers"
 
-	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
 type Server struct {
 	*gin.Engine
 }
 
-// Setup Routers Builds All Routes And Dependencies :
-func SetupRouters(db *gorm.DB) *Server {
+// Setup Routers Builds All Routes On Top Of The Service Graph.
+// The Broker Is Shared With The Outbox Relay, Which Feeds It.
+func SetupRouters(services *Services, broker *eventServices.Broker) *Server {
+
+	// Custom Binding Rules ( notblank, pastdate ) And JSON Field Names In Validation Errors :
+	utils.RegisterValidationRules()
 
 	router := gin.New()
 
+	// Client IPs ( API Key Allowlists, Audit ) Come From X-Forwarded-For Only Behind A Configured Proxy :
+	if err := router.SetTrustedProxies(middleware.ParseList(config.GetEnv(constants.TRUSTED_PROXIES, ""))); err != nil {
+
+		utils.Fatal("failed to configure trusted proxies: " + err.Error())
+	}
+
 	// Middlewares :
 	router.Use(gin.Logger())   // Request Logging.
 	router.Use(gin.Recovery()) // Rcover From Panics.
-	router.Use(cors.Default()) // CORS Enabled By Default.
+	router.Use(middleware.CORS(middleware.ParseList(config.GetEnv(constants.CORS_ALLOWED_ORIGINS, constants.DefaultCORSAllowedOrigins))))
+	router.Use(middleware.RequestContext()) // Request ID, Actor & Client IP For Auditing.
+	router.Use(authentication(services))    // JWT Bearer Tokens Or API Keys, Except Bypassed Paths.
+	router.Use(middleware.ResolveTenant())  // Tenant From The Caller, X-Tenant-ID Or ?tenant=.
 
 	// Wire layers :
-	userRepo := repository.NewUserRepository(db)
-	groupRepo := repository.NewGroupRepository(db)
-	userService := services.NewUserService(db, userRepo, groupRepo)
-	userHandler := handlers.NewUserHandler(userService)
+	auditHandler := auditHandlers.NewAuditHandler(services.Audit)
+	streamHandler := eventHandlers.NewStreamHandler(broker, services.Outbox, config.GetEnvDuration(constants.SSE_HEARTBEAT_INTERVAL, constants.DefaultSSEHeartbeatInterval))
+	userHandler := handlers.NewUserHandler(services.Users)
+	dataExportHandler := handlers.NewDataExportHandler(services.Exports)
+	consentHandler := handlers.NewConsentHandler(services.Users)
+	webhookHandler := webhookHandlers.NewWebhookHandler(services.Webhooks)
+	jobHandler := jobHandlers.NewJobHandler(services.Jobs)
+	apiKeyHandler := authHandlers.NewAPIKeyHandler(services.APIKeys)
+	accountHandler := authHandlers.NewAccountHandler(services.Accounts)
 
 	// Versioned API Routes :
 	api := router.Group("/api/v1")
 	{
-		api.POST("/users", userHandler.CreateUser)
-		api.GET("/users/:id", userHandler.GetUserByID)
-		api.PATCH("/users/:id", userHandler.UpdateUser)
-		api.GET("/users", userHandler.QueryUsers) // Supports Group Filter.
+		permit := middleware.RequirePermission
+
+		api.POST("/auth/register", accountHandler.Register) // Public ( See PublicAuthPaths ).
+		api.POST("/auth/login", accountHandler.Login)
+		api.POST("/auth/refresh", accountHandler.Refresh)
+		api.POST("/auth/logout", accountHandler.Logout)
+		api.PUT("/auth/password", accountHandler.ChangePassword) // Caller's Own Password.
+		api.POST("/auth/mfa/enroll", accountHandler.EnrollMFA)   // Caller's Own Second Factor.
+		api.POST("/auth/mfa/activate", accountHandler.ActivateMFA)
+		api.POST("/auth/mfa/verify", accountHandler.VerifyMFA) // Public, Completes A Login Challenge.
+		api.POST("/auth/mfa/step-up", accountHandler.StepUp)
+		api.PUT("/auth/accounts/:id/roles", permit(constants.PermissionAccountsManage), accountHandler.SetRoles)
+
+		api.POST("/users", permit(constants.PermissionUsersCreate), userHandler.CreateUser)
+		api.POST("/users/import", permit(constants.PermissionUsersImport), userHandler.ImportUsers)  // CSV, Supports Dry Run.
+		api.GET("/users/export", permit(constants.PermissionUsersExport), userHandler.ExportUsers)   // Streams CSV / NDJSON.
+		api.GET("/groups/export", permit(constants.PermissionUsersExport), userHandler.ExportGroups) // Streams CSV / NDJSON.
+		api.GET("/users/verify", userHandler.VerifyEmail)                                            // Public, Link From The Verification Email.
+		api.GET("/users/:id", userHandler.GetUserByID)                                               // Self Or "users:read", Checked By The Handler.
+		api.PATCH("/users/:id", userHandler.UpdateUser)                                              // Self Or "users:update", Checked By The Handler.
+		api.GET("/users/:id/export", dataExportHandler.ExportUserData)                               // Self Or "users:export", Data Portability ( JSON Or Signed Zip ).
+		api.GET("/users", permit(constants.PermissionUsersRead), userHandler.QueryUsers)             // Supports Group And Status Filters.
+		api.GET("/users/search", permit(constants.PermissionUsersRead), userHandler.SearchUsers)     // Fuzzy, Ranked, Paginated.
+		api.POST("/users/:id/suspend", permit(constants.PermissionUsersStatus), userHandler.SuspendUser)
+		api.POST("/users/:id/deactivate", permit(constants.PermissionUsersStatus), userHandler.DeactivateUser)
+		api.POST("/users/:id/activate", permit(constants.PermissionUsersStatus), userHandler.ActivateUser)
+		api.GET("/users/duplicates", permit(constants.PermissionUsersMerge), userHandler.FindDuplicateUsers) // Scored, Paginated.
+		api.POST("/users/merge", permit(constants.PermissionUsersMerge), userHandler.MergeUsers)
+
+		api.GET("/consents/grant", consentHandler.GrantConsent) // Public, Link From The Guardian's Consent Email.
+		api.GET("/consents", permit(constants.PermissionConsentsManage), consentHandler.ListConsents)
+		api.POST("/consents/:id/revoke", permit(constants.PermissionConsentsManage), consentHandler.RevokeConsent)
+
+		api.GET("/audit", permit(constants.PermissionAuditRead), auditHandler.QueryAudit) // Supports Filters & Pagination.
+
+		api.GET("/events/stream", permit(constants.PermissionEventsRead), streamHandler.Stream) // Server-Sent Events.
+
+		webhooks := api.Group("/webhooks", permit(constants.PermissionWebhooksManage))
+		webhooks.POST("", webhookHandler.CreateSubscription)
+		webhooks.GET("", webhookHandler.ListSubscriptions)
+		webhooks.GET("/:id", webhookHandler.GetSubscription)
+		webhooks.PATCH("/:id", webhookHandler.UpdateSubscription)
+		webhooks.DELETE("/:id", webhookHandler.DeleteSubscription)
+		webhooks.GET("/:id/deliveries", webhookHandler.ListDeliveries)
+		webhooks.GET("/deliveries/:deliveryId/attempts", webhookHandler.ListAttempts)
+		webhooks.POST("/deliveries/:deliveryId/redeliver", webhookHandler.Redeliver)
+
+		jobs := api.Group("/jobs", permit(constants.PermissionJobsManage))
+		jobs.POST("/user-imports", jobHandler.SubmitUserImport) // Async CSV Import, Returns 202.
+		jobs.GET("/:id", jobHandler.GetJob)
+		jobs.GET("/:id/errors", jobHandler.DownloadErrors) // Rejected Rows As CSV.
+		jobs.POST("/:id/cancel", jobHandler.CancelJob)
+
+		apiKeys := api.Group("/api-keys", permit(constants.PermissionAPIKeysManage))
+		apiKeys.POST("", apiKeyHandler.IssueAPIKey) // The Key Is Only Returned Here.
+		apiKeys.GET("", apiKeyHandler.ListAPIKeys)
+		apiKeys.POST("/:id/rotate", apiKeyHandler.RotateAPIKey)
+		apiKeys.DELETE("/:id", apiKeyHandler.RevokeAPIKey)
 	}
 
 	// Health Check ( Useful For Kubernetes, etc. )
@@ -49,16 +130,75 @@ func SetupRouters(db *gorm.DB) *Server {
 	return &Server{router}
 }
 
+// authentication Builds The JWT Middleware From The Environment.
+// Startup Fails When Auth Is Enabled Without Any Verification Key Or With Invalid Roles ( Fail Closed ).
+func authentication(services *Services) gin.HandlerFunc {
+
+	if strings.EqualFold(config.GetEnv(constants.AUTH_DISABLED, "false"), "true") {
+
+		utils.Error("authentication is disabled, every route is public")
+		return middleware.AllowAll()
+	}
+
+	customRoles, err := authServices.ParseCustomRoles(config.GetEnv(constants.RBAC_CUSTOM_ROLES, ""))
+	if err != nil {
+
+		utils.Fatal("failed to configure roles: " + err.Error())
+	}
+
+	authorizer, err := authServices.NewAuthorizer(customRoles)
+	if err != nil {
+
+		utils.Fatal("failed to configure roles: " + err.Error())
+	}
+
+	verifier, err := authServices.NewJWTVerifier(authServices.JWTConfig{
+		HMACKeyFile:      config.GetEnv(constants.JWT_HMAC_KEY_FILE, ""),
+		RSAPublicKeyFile: config.GetEnv(constants.JWT_RSA_PUBLIC_KEY_FILE, ""),
+		JWKSFile:         config.GetEnv(constants.JWT_JWKS_FILE, ""),
+		Issuer:           config.GetEnv(constants.JWT_ISSUER, ""),
+		Audience:         config.GetEnv(constants.JWT_AUDIENCE, ""),
+		Leeway:           config.GetEnvDuration(constants.JWT_LEEWAY, constants.DefaultJWTLeeway),
+	})
+	if err != nil {
+
+		utils.Fatal("failed to configure jwt authentication: " + err.Error())
+	}
+
+	return middleware.Authenticate(middleware.Authenticators{
+		Verifier:   verifier,
+		Authorizer: authorizer,
+		APIKeys:    services.APIKeys,
+		Sessions:   services.Accounts,
+		Bypass:     append(middleware.ParseList(config.GetEnv(constants.AUTH_BYPASS_PATHS, constants.DefaultAuthBypassPaths)), middleware.ParseList(constants.PublicAuthPaths)...),
+	})
+}
+
 // For Testing With Mocks :
 func SetupRoutersWithService(userService UserServiceInterface.UserService) *gin.Engine {
 
+	utils.RegisterValidationRules()
+
 	router := gin.Default()
+	router.Use(middleware.RequestContext())
+	router.Use(middleware.AllowAll())
+	router.Use(middleware.ResolveTenant())
 	handler := handlers.NewUserHandler(userService)
 
 	router.POST("/users", handler.CreateUser)
+	router.POST("/users/import", handler.ImportUsers)
+	router.GET("/users/export", handler.ExportUsers)
+	router.GET("/groups/export", handler.ExportGroups)
+	router.GET("/users/verify", handler.VerifyEmail)
 	router.GET("/users/:id", handler.GetUserByID)
 	router.PATCH("/users/:id", handler.UpdateUser)
 	router.GET("/users", handler.QueryUsers)
+	router.GET("/users/search", handler.SearchUsers)
+	router.POST("/users/:id/suspend", handler.SuspendUser)
+	router.POST("/users/:id/deactivate", handler.DeactivateUser)
+	router.POST("/users/:id/activate", handler.ActivateUser)
+	router.GET("/users/duplicates", handler.FindDuplicateUsers)
+	router.POST("/users/merge", handler.MergeUsers)
 
 	return router
 }
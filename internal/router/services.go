@@ -0,0 +1,152 @@
+package router
+
+import (
+	"fmt"
+
+	auditModels "backend-task/internal/audit/models"
+	auditRepository "backend-task/internal/audit/repository"
+	auditServices "backend-task/internal/audit/services"
+	auditServiceInterface "backend-task/internal/audit/services/interface"
+	authRepository "backend-task/internal/auth/repository"
+	authServices "backend-task/internal/auth/services"
+	authServiceInterface "backend-task/internal/auth/services/interface"
+	"backend-task/internal/config"
+	"backend-task/internal/constants"
+	eventModels "backend-task/internal/events/models"
+	eventRepository "backend-task/internal/events/repository"
+	eventServices "backend-task/internal/events/services"
+	eventServiceInterface "backend-task/internal/events/services/interface"
+	jobRepository "backend-task/internal/job/repository"
+	jobServices "backend-task/internal/job/services"
+	jobServiceInterface "backend-task/internal/job/services/interface"
+	"backend-task/internal/mail"
+	"backend-task/internal/tenant"
+	"backend-task/internal/user/models"
+	"backend-task/internal/user/repository"
+	services "backend-task/internal/user/services"
+	UserServiceInterface "backend-task/internal/user/services/interface"
+	"backend-task/internal/utils"
+	webhookModels "backend-task/internal/webhook/models"
+	webhookRepository "backend-task/internal/webhook/repository"
+	webhookServices "backend-task/internal/webhook/services"
+	webhookServiceInterface "backend-task/internal/webhook/services/interface"
+
+	"gorm.io/gorm"
+)
+
+// Services Bundles The Application Service Graph,
+// Shared By The HTTP Server, The CLI Subcommands And Tests :
+type Services struct {
+	Audit    auditServiceInterface.AuditService
+	Events   eventServiceInterface.EventService
+	Outbox   eventRepository.OutboxRepository
+	Users    UserServiceInterface.UserService
+	Exports  UserServiceInterface.DataExportService
+	Webhooks webhookServiceInterface.WebhookService
+	Jobs     jobServiceInterface.JobService
+	APIKeys  authServiceInterface.APIKeyService
+	Accounts authServiceInterface.AccountService
+	Mailer   mail.Mailer
+}
+
+// NewServices Wires Repositories And Services On Top Of The Given Connection :
+func NewServices(db *gorm.DB) *Services {
+
+	// Users, Their Groups, The Audit Trail, Events And Webhooks Are Confined To The Request's Tenant :
+	if err := tenant.RegisterScope(db, &models.User{}, &models.Group{}, &models.GroupMembership{}, &models.GuardianConsent{},
+		&auditModels.AuditEntry{}, &eventModels.OutboxEvent{}, &webhookModels.WebhookSubscription{}, &webhookModels.WebhookDelivery{}); err != nil {
+
+		utils.Fatal("tenant scope: " + err.Error())
+	}
+
+	tenants, err := tenant.LoadRegistry(config.GetEnv(constants.TENANT_SETTINGS, ""))
+	if err != nil {
+
+		utils.Fatal(fmt.Sprintf("%s: %v", utils.ErrInvalidTenantSettings, err))
+	}
+
+	disposable, err := mail.LoadDomainBlocklist(config.GetEnv(constants.DISPOSABLE_EMAIL_DOMAINS_FILE, ""))
+	if err != nil {
+
+		utils.Fatal(fmt.Sprintf("%s: %v", utils.ErrInvalidDisposableDomains, err))
+	}
+
+	auditRepo := auditRepository.NewAuditRepository(db)
+	auditService := auditServices.NewAuditService(auditRepo, auditServices.NewMasker(config.GetEnv(constants.AUDIT_MASKED_FIELDS, constants.AuditDefaultMaskedFields)))
+
+	outboxRepo := eventRepository.NewOutboxRepository(db)
+	eventService := eventServices.NewEventService(outboxRepo)
+
+	emailPolicy := services.EmailPolicy{
+		FoldProviderAliases: config.GetEnv(constants.EMAIL_FOLD_PROVIDER_ALIASES, "true") == "true",
+		Disposable:          disposable,
+	}
+
+	userRepo := repository.NewUserRepository(db)
+	groupRepo := repository.NewGroupRepository(db)
+	mailer := newMailer()
+	consentRepo := repository.NewConsentRepository(db)
+	accountRepo := authRepository.NewAccountRepository(db)
+	userService := services.NewUserService(db, userRepo, groupRepo, consentRepo, auditService, eventService, services.VerificationConfig{
+		Mailer:     mailer,
+		Key:        []byte(config.GetEnv(constants.EMAIL_VERIFICATION_KEY, "")),
+		TTL:        config.GetEnvDuration(constants.EMAIL_VERIFICATION_TTL, constants.DefaultEmailVerificationTTL),
+		ConsentTTL: config.GetEnvDuration(constants.CONSENT_TTL, constants.DefaultConsentTTL),
+		BaseURL:    config.GetEnv(constants.PUBLIC_BASE_URL, constants.DefaultPublicBaseURL),
+	}, emailPolicy, tenants, accountRepo)
+	dataExportService := services.NewDataExportService(userRepo, groupRepo, consentRepo, auditService, []byte(config.GetEnv(constants.EXPORT_SIGNING_KEY, "")))
+
+	webhookService := webhookServices.NewWebhookService(db, webhookRepository.NewWebhookRepository(db), auditService)
+
+	jobService := jobServices.NewJobService(jobRepository.NewJobRepository(db), emailPolicy)
+
+	apiKeyService := authServices.NewAPIKeyService(db, authRepository.NewAPIKeyRepository(db), auditService)
+
+	// Login Is Unavailable ( 500 ) Until A Signing Key Is Configured :
+	tokenIssuer, err := authServices.NewTokenIssuer(authServices.TokenIssuerConfig{
+		HMACKeyFile:       config.GetEnv(constants.JWT_HMAC_KEY_FILE, ""),
+		RSAPrivateKeyFile: config.GetEnv(constants.JWT_RSA_PRIVATE_KEY_FILE, ""),
+		Issuer:            config.GetEnv(constants.JWT_ISSUER, ""),
+		Audience:          config.GetEnv(constants.JWT_AUDIENCE, ""),
+		TTL:               config.GetEnvDuration(constants.AUTH_ACCESS_TOKEN_TTL, constants.DefaultAccessTokenTTL),
+	})
+	if err != nil {
+
+		utils.Error("password login disabled: " + err.Error())
+	}
+
+	accountService := authServices.NewAccountService(db, accountRepo, userRepo, userService, auditService, tokenIssuer, authServices.AccountConfig{
+		RefreshTTL:       config.GetEnvDuration(constants.AUTH_REFRESH_TOKEN_TTL, constants.DefaultRefreshTokenTTL),
+		LockoutThreshold: config.GetEnvInt(constants.AUTH_LOCKOUT_THRESHOLD, constants.DefaultLockoutThreshold),
+		LockoutBaseDelay: config.GetEnvDuration(constants.AUTH_LOCKOUT_BASE_DELAY, constants.DefaultLockoutBaseDelay),
+		LockoutMaxDelay:  config.GetEnvDuration(constants.AUTH_LOCKOUT_MAX_DELAY, constants.DefaultLockoutMaxDelay),
+		MFAIssuer:        config.GetEnv(constants.MFA_ISSUER, constants.DefaultMFAIssuer),
+	})
+
+	return &Services{Audit: auditService, Events: eventService, Outbox: outboxRepo, Users: userService, Exports: dataExportService, Webhooks: webhookService, Jobs: jobService, APIKeys: apiKeyService, Accounts: accountService, Mailer: mailer}
+}
+
+// newMailer Selects The Mailer Configured Via MAIL_DRIVER :
+func newMailer() mail.Mailer {
+
+	switch kind := config.GetEnv(constants.MAIL_DRIVER, constants.MailDriverFile); kind {
+	case constants.MailDriverSMTP:
+		return mail.NewSMTPMailer(mail.SMTPConfig{
+			Host:     config.GetEnv(constants.SMTP_HOST, ""),
+			Port:     config.GetEnv(constants.SMTP_PORT, constants.DefaultSMTPPort),
+			Username: config.GetEnv(constants.SMTP_USERNAME, ""),
+			Password: config.GetEnv(constants.SMTP_PASSWORD, ""),
+			From:     config.GetEnv(constants.MAIL_FROM, constants.DefaultMailFrom),
+		})
+
+	case constants.MailDriverMemory:
+		return mail.NewInMemoryMailer()
+
+	case constants.MailDriverFile:
+		return mail.NewFileMailer(config.GetEnv(constants.MAIL_SINK_FILE, constants.DefaultMailSinkFile))
+
+	default:
+		utils.Error(fmt.Sprintf("%s: %s", utils.ErrUnsupportedMailDriver, kind))
+		return mail.NewFileMailer(config.GetEnv(constants.MAIL_SINK_FILE, constants.DefaultMailSinkFile))
+	}
+}
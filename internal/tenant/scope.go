@@ -0,0 +1,123 @@
+package tenant
+
+import (
+	"reflect"
+
+	"backend-task/internal/constants"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	callbackAssign = "tenant:assign"
+	callbackQuery  = "tenant:query"
+	callbackRow    = "tenant:row"
+	callbackUpdate = "tenant:update"
+	callbackDelete = "tenant:delete"
+)
+
+// scope Remembers Which Tables Carry A tenant_id Column :
+type scope struct {
+	tables map[string]bool
+}
+
+// RegisterScope Installs GORM Callbacks That Confine Every Statement On The Given Models To The Tenant
+// Of The Statement's Context: Reads, Updates And Deletes Get A tenant_id Condition, Creates Get The Column Set.
+// Statements Without A Resolved Tenant Use The Default Tenant, Those Under AllTenants Are Left Alone.
+// Registering Twice Is A No-Op.
+func RegisterScope(db *gorm.DB, models ...any) error {
+
+	if db.Callback().Query().Get(callbackQuery) != nil {
+
+		return nil
+	}
+
+	tenantScope := &scope{tables: map[string]bool{}}
+	for _, model := range models {
+
+		statement := &gorm.Statement{DB: db}
+		if err := statement.Parse(model); err != nil {
+
+			return err
+		}
+
+		tenantScope.tables[statement.Schema.Table] = true
+	}
+
+	callbacks := db.Callback()
+	if err := callbacks.Create().Before("gorm:create").Register(callbackAssign, tenantScope.assign); err != nil {
+
+		return err
+	}
+
+	if err := callbacks.Query().Before("gorm:query").Register(callbackQuery, tenantScope.restrict); err != nil {
+
+		return err
+	}
+
+	if err := callbacks.Row().Before("gorm:row").Register(callbackRow, tenantScope.restrict); err != nil {
+
+		return err
+	}
+
+	if err := callbacks.Update().Before("gorm:update").Register(callbackUpdate, tenantScope.restrict); err != nil {
+
+		return err
+	}
+
+	return callbacks.Delete().Before("gorm:delete").Register(callbackDelete, tenantScope.restrict)
+}
+
+func (tenantScope *scope) scoped(db *gorm.DB) bool {
+
+	return db.Error == nil && db.Statement.Schema != nil && tenantScope.tables[db.Statement.Schema.Table] && !spansAllTenants(db.Statement.Context)
+}
+
+// restrict Adds "tenant_id = <tenant>" To The Statement :
+func (tenantScope *scope) restrict(db *gorm.DB) {
+
+	if !tenantScope.scoped(db) {
+
+		return
+	}
+
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: constants.ColumnTenantID}, Value: ID(db.Statement.Context)},
+	}})
+}
+
+// assign Stamps New Rows With The Tenant ( Rows That Already Name One Are Left Alone ) :
+func (tenantScope *scope) assign(db *gorm.DB) {
+
+	if !tenantScope.scoped(db) {
+
+		return
+	}
+
+	field := db.Statement.Schema.LookUpField(constants.ColumnTenantID)
+	if field == nil {
+
+		return
+	}
+
+	tenantID := ID(db.Statement.Context)
+	stamp := func(row reflect.Value) {
+
+		if _, zero := field.ValueOf(db.Statement.Context, row); zero {
+
+			db.AddError(field.Set(db.Statement.Context, row, tenantID))
+		}
+	}
+
+	switch value := db.Statement.ReflectValue; value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for index := 0; index < value.Len(); index++ {
+
+			stamp(reflect.Indirect(value.Index(index)))
+		}
+
+	case reflect.Struct:
+		stamp(value)
+	}
+}
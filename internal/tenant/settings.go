@@ -0,0 +1,119 @@
+package tenant
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"backend-task/internal/constants"
+	"backend-task/internal/utils"
+)
+
+// Age Bands Are The Highest Age ( Inclusive ) Of Each Base Group; Anyone Older Than AdultMax Is A Senior :
+type AgeBands struct {
+	ChildMax int `json:"child_max"`
+	TeenMax  int `json:"teen_max"`
+	AdultMax int `json:"adult_max"`
+}
+
+// Settings Are The Grouping Rules Of One Tenant :
+type Settings struct {
+	GroupCapacity int      `json:"group_capacity"` // Seats Per Newly Created Group.
+	AgeBands      AgeBands `json:"age_bands"`
+}
+
+// DefaultSettings Apply To Tenants Without Overrides :
+var DefaultSettings = Settings{
+	GroupCapacity: constants.GroupCapacity,
+	AgeBands:      AgeBands{ChildMax: constants.DefaultChildMaxAge, TeenMax: constants.DefaultTeenMaxAge, AdultMax: constants.DefaultAdultMaxAge},
+}
+
+// Registry Holds The Per-Tenant Overrides; A nil Registry Uses The Defaults Everywhere :
+type Registry struct {
+	settings map[string]Settings
+}
+
+// LoadRegistry Parses TENANT_SETTINGS ( Omitted Fields Keep Their Defaults ), e.g.
+// {"acme":{"group_capacity":5,"age_bands":{"child_max":10,"teen_max":15,"adult_max":59}}}
+func LoadRegistry(raw string) (*Registry, error) {
+
+	registry := &Registry{settings: map[string]Settings{}}
+	if strings.TrimSpace(raw) == "" {
+
+		return registry, nil
+	}
+
+	var overrides map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(raw), &overrides); err != nil {
+
+		return nil, fmt.Errorf("%w: %v", utils.ErrInvalidTenantSettings, err)
+	}
+
+	for tenantID, override := range overrides {
+
+		if !ValidID(tenantID) {
+
+			return nil, fmt.Errorf("%w: %q: %v", utils.ErrInvalidTenantSettings, tenantID, utils.ErrInvalidTenantID)
+		}
+
+		settings := DefaultSettings
+		if err := json.Unmarshal(override, &settings); err != nil {
+
+			return nil, fmt.Errorf("%w: %q: %v", utils.ErrInvalidTenantSettings, tenantID, err)
+		}
+
+		bands := settings.AgeBands
+		if settings.GroupCapacity < 1 || bands.ChildMax < 0 || bands.TeenMax <= bands.ChildMax || bands.AdultMax <= bands.TeenMax {
+
+			return nil, fmt.Errorf("%w: %q: group_capacity must be positive and child_max < teen_max < adult_max", utils.ErrInvalidTenantSettings, tenantID)
+		}
+
+		registry.settings[tenantID] = settings
+	}
+
+	return registry, nil
+}
+
+// For Returns The Settings Of The Tenant :
+func (registry *Registry) For(tenantID string) Settings {
+
+	if registry != nil {
+
+		if settings, ok := registry.settings[tenantID]; ok {
+
+			return settings
+		}
+	}
+
+	return DefaultSettings
+}
+
+// BaseGroup Maps A Date Of Birth To Its Base Group ( child, teen, adult, senior ) :
+func (bands AgeBands) BaseGroup(birth time.Time) string {
+
+	age := utils.CalculateAge(birth)
+	switch {
+	case age >= 0 && age <= bands.ChildMax:
+		return constants.BaseGroupChild
+
+	case age > bands.ChildMax && age <= bands.TeenMax:
+		return constants.BaseGroupTeen
+
+	case age > bands.TeenMax && age <= bands.AdultMax:
+		return constants.BaseGroupAdult
+
+	case age > bands.AdultMax:
+		return constants.BaseGroupSenior
+
+	default:
+		return constants.BaseGroupUnset
+	}
+}
+
+// IsMinor Reports Whether The Date Of Birth Falls Into The Child Or Teen Band :
+func (bands AgeBands) IsMinor(birth time.Time) bool {
+
+	base := bands.BaseGroup(birth)
+	return base == constants.BaseGroupChild || base == constants.BaseGroupTeen
+}
@@ -0,0 +1,53 @@
+package tenant
+
+import (
+	"context"
+	"regexp"
+
+	"backend-task/internal/constants"
+	"backend-task/internal/utils"
+)
+
+var validID = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// ID Returns The Tenant Of The Request Carried By The Context ( The Default Tenant When None Was Resolved ) :
+func ID(ctx context.Context) string {
+
+	if tenantID := utils.RequestMetaFromContext(ctx).TenantID; tenantID != "" {
+
+		return tenantID
+	}
+
+	return constants.DefaultTenantID
+}
+
+// WithID Returns A Copy Of The Context Scoped To The Tenant ( e.g., For Background Jobs ) :
+func WithID(ctx context.Context, tenantID string) context.Context {
+
+	meta := utils.RequestMetaFromContext(ctx)
+	meta.TenantID = tenantID
+
+	return utils.WithRequestMeta(ctx, meta)
+}
+
+type allTenantsKey struct{}
+
+// AllTenants Returns A Copy Of The Context That Lifts The Tenant Scope, For Background Workers That
+// Sweep Every Tenant ( Outbox Relay, Webhook Worker ). Rows They Create Must Name Their Tenant :
+func AllTenants(ctx context.Context) context.Context {
+
+	return context.WithValue(ctx, allTenantsKey{}, true)
+}
+
+// spansAllTenants Reports Whether The Context Was Returned By AllTenants :
+func spansAllTenants(ctx context.Context) bool {
+
+	all, _ := ctx.Value(allTenantsKey{}).(bool)
+	return all
+}
+
+// ValidID Reports Whether The Tenant ID Is Well-Formed :
+func ValidID(tenantID string) bool {
+
+	return len(tenantID) <= constants.MaxTenantIDChars && validID.MatchString(tenantID)
+}
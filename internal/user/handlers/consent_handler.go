@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+
+	constants "backend-task/internal/constants"
+	models "backend-task/internal/user/models"
+	UserServiceInterface "backend-task/internal/user/services/interface"
+	"backend-task/internal/utils"
+)
+
+type ConsentHandler struct {
+	Service UserServiceInterface.UserService
+}
+
+func NewConsentHandler(s UserServiceInterface.UserService) *ConsentHandler {
+
+	return &ConsentHandler{Service: s}
+}
+
+// GrantConsent godoc
+// @Summary Give guardian consent.
+// @Description Opened by the guardian from the consent email. Records the consent ( method email_link, time and client IP ) and allocates the minor to a group. Public.
+// @Tags consents
+// @Produce json
+// @Param token query string true "Consent token"
+// @Success 200 {object} models.User
+// @Failure 400 {object} models.ErrorResponse "Invalid or expired consent token"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /consents/grant [get]
+func (consentHandler *ConsentHandler) GrantConsent(context *gin.Context) {
+
+	user, err := consentHandler.Service.GrantConsent(context.Request.Context(), context.Query("token"))
+	if err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	context.JSON(constants.StatusOK, user)
+}
+
+// ListConsents godoc
+// @Summary List guardian consents.
+// @Description Returns consent records ( who, when, method, revocation ), oldest first.
+// @Tags consents
+// @Produce json
+// @Param user_id query string false "User ID"
+// @Param status query string false "Consent status" Enums(pending, granted, revoked)
+// @Success 200 {array} models.GuardianConsent
+// @Failure 400 {object} models.ErrorResponse "Invalid request. Possible reasons: invalid user_id or status."
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /consents [get]
+func (consentHandler *ConsentHandler) ListConsents(context *gin.Context) {
+
+	consents, err := consentHandler.Service.ListConsents(context.Request.Context(), context.Query("user_id"), context.Query("status"))
+	if err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	context.JSON(constants.StatusOK, consents)
+}
+
+// RevokeConsent godoc
+// @Summary Revoke a guardian consent.
+// @Description Marks the consent revoked ( by the caller, with an optional reason ). A minor who was allocated loses their group seat and returns to pending_consent.
+// @Tags consents
+// @Accept json
+// @Produce json
+// @Param id path string true "Consent ID"
+// @Param reason body models.RevokeConsentReq false "Reason"
+// @Success 200 {object} models.GuardianConsent
+// @Failure 400 {object} models.ErrorResponse "Invalid request. Possible reasons: invalid ID, or reason too long."
+// @Failure 404 {object} models.ErrorResponse "Consent not found"
+// @Failure 409 {object} models.ErrorResponse "Consent is already revoked"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /consents/{id}/revoke [post]
+func (consentHandler *ConsentHandler) RevokeConsent(context *gin.Context) {
+
+	var body models.RevokeConsentReq
+	if context.Request.ContentLength != 0 {
+
+		if err := context.ShouldBindJSON(&body); err != nil {
+
+			utils.RespondError(context, utils.NewBindingError(err))
+			return
+		}
+	}
+
+	consent, err := consentHandler.Service.RevokeConsent(context.Request.Context(), context.Param("id"), body.Reason)
+	if err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	context.JSON(constants.StatusOK, consent)
+}
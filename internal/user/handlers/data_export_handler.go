@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	constants "backend-task/internal/constants"
+	UserServiceInterface "backend-task/internal/user/services/interface"
+	"backend-task/internal/utils"
+)
+
+type DataExportHandler struct {
+	Service UserServiceInterface.DataExportService
+}
+
+func NewDataExportHandler(s UserServiceInterface.DataExportService) *DataExportHandler {
+
+	return &DataExportHandler{Service: s}
+}
+
+// ExportUserData godoc
+// @Summary Export all data held about a user.
+// @Description Returns the user record, their current group ( with base and capacity ), group history and the audit entries about them. With format=zip the bundle is returned as a signed archive: bundle.json, manifest.json ( SHA-256 of bundle.json ) and manifest.sig ( hex HMAC-SHA256 of manifest.json ).
+// @Tags users
+// @Produce json
+// @Produce application/zip
+// @Param id path string true "User ID"
+// @Param format query string false "Bundle format" Enums(json, zip) default(json)
+// @Success 200 {object} models.UserDataBundle
+// @Failure 400 {object} models.ErrorResponse "Invalid request. Possible reasons: invalid ID or unsupported format."
+// @Failure 403 {object} models.ErrorResponse "Requires users:export, or users:export:self for the caller's own record"
+// @Failure 404 {object} models.ErrorResponse "User not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error or signing key not configured"
+// @Router /users/{id}/export [get]
+func (dataExportHandler *DataExportHandler) ExportUserData(context *gin.Context) {
+
+	userId := context.Param("id")
+	if !authorizeSelf(context, userId, constants.PermissionUsersExport, constants.PermissionUsersExportSelf) {
+
+		return
+	}
+
+	format := context.DefaultQuery("format", constants.ExportFormatJSON)
+	if format != constants.ExportFormatJSON && format != constants.ExportFormatZip {
+
+		utils.RespondError(context, utils.NewBadRequest(utils.ErrUnsupportedDataExportFormat))
+		return
+	}
+
+	bundle, err := dataExportHandler.Service.BuildBundle(context.Request.Context(), userId)
+	if err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	if format == constants.ExportFormatJSON {
+
+		context.JSON(constants.StatusOK, bundle)
+		return
+	}
+
+	// Build The Archive In Memory, So Failures Still Produce A JSON Error :
+	var archive bytes.Buffer
+	if err := dataExportHandler.Service.WriteArchive(bundle, &archive); err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	context.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"user-%s-export.zip\"", bundle.User.ID))
+	context.Data(constants.StatusOK, constants.ContentTypeZip, archive.Bytes())
+}
@@ -2,7 +2,10 @@ package models
 
 // Create User Req Represents The Payload For Creating A User :
 type CreateUserReq struct {
-	Name        string `json:"name" binding:"required" example:"John Doe"`
+	Name        string `json:"name" binding:"required,notblank" example:"John Doe"`
 	Email       string `json:"email" binding:"required,email" example:"john@example.com"`
-	DateOfBirth string `json:"date_of_birth" binding:"required" example:"1990-01-01"`
+	DateOfBirth string `json:"date_of_birth" binding:"required,datetime=2006-01-02,pastdate" example:"1990-01-01"`
+
+	// Required For Users Under 18, Who Stay Unallocated Until The Guardian Consents.
+	Guardian *GuardianReq `json:"guardian,omitempty"`
 }
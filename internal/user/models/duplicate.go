@@ -0,0 +1,40 @@
+package models
+
+// Duplicate Candidate Is A Pair Of Users That Look Like The Same Person.
+//
+// @Description Possible Duplicate Pair, Most Likely First.
+type DuplicateCandidate struct {
+
+	// Likelihood From 0 To 1 That Both Users Are One Person.
+	Score float64 `json:"score" example:"0.8"`
+
+	// Signals Behind The Score.
+	Reasons []string `json:"reasons" example:"similar_name,same_date_of_birth" enums:"similar_name,same_date_of_birth,same_email_local_part"`
+
+	// Both Users, Older Record First ( The Natural Merge Survivor ).
+	Users []*User `json:"users"`
+}
+
+// Duplicate Filter Holds The Criteria For Listing Duplicate Candidates :
+type DuplicateFilter struct {
+	Group    string
+	MinScore float64
+	Page     int
+	PageSize int
+}
+
+// Duplicate Page Is A Paginated Slice Of Duplicate Candidates.
+//
+// @Description Paginated Duplicate Candidates.
+type DuplicatePage struct {
+	Items    []*DuplicateCandidate `json:"items"`
+	Total    int64                 `json:"total" example:"3"`
+	Page     int                   `json:"page" example:"1"`
+	PageSize int                   `json:"page_size" example:"50"`
+}
+
+// Merge Users Req Names The User To Keep And The Duplicate Folded Into It :
+type MergeUsersReq struct {
+	SurvivorID  string `json:"survivor_id" binding:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	DuplicateID string `json:"duplicate_id" binding:"required,uuid" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+}
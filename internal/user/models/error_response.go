@@ -2,12 +2,47 @@ package models
 
 // ---------------- Error Response ----------------
 
+// ErrorResponse Is The RFC 7807 Problem Document Returned For Every Error ( application/problem+json ).
+// Clients Should Branch On ErrorCode, Which Is Stable; Detail Is Human Readable And May Change.
 type ErrorResponse struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
+
+	// URI Identifying The Problem Type.
+	Type string `json:"type" example:"urn:backend-task:problem:user-email-taken"`
+
+	// Short Summary Of The HTTP Status.
+	Title string `json:"title" example:"Conflict"`
+
+	// HTTP Status Code.
+	Status int `json:"status" example:"409"`
+
+	// Explanation Specific To This Occurrence.
+	Detail string `json:"detail" example:"email already exists"`
+
+	// Request Path That Failed.
+	Instance string `json:"instance" example:"/api/v1/users"`
+
+	// Stable Machine-Readable Code.
+	ErrorCode string `json:"error_code" example:"USER_EMAIL_TAKEN"`
+
+	// Request ID ( Also In The X-Request-ID Header ), For Support.
+	RequestID string `json:"request_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+
+	// Every Invalid Field ( VALIDATION_FAILED Only ).
+	Errors []FieldError `json:"errors,omitempty"`
 }
 
-// Implement The Error Interface
-func (e ErrorResponse) Error() string {
-	return e.Message
+// FieldError Describes One Invalid Field Of A Request Body :
+type FieldError struct {
+
+	// JSON Path Of The Field; Bulk Requests Start With The Array Index ( e.g., "[2].email" ).
+	Field string `json:"field" example:"[0].email"`
+
+	// Failed Rule ( e.g., "required", "email", "pastdate" ).
+	Rule string `json:"rule" example:"email"`
+
+	// Parameter Of The Rule, If Any ( e.g., "1" For min=1 ).
+	Param string `json:"param,omitempty" example:"1"`
+
+	// Human Readable Explanation.
+	Message string `json:"message" example:"invalid email format"`
 }
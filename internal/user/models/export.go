@@ -0,0 +1,10 @@
+package models
+
+// Export Options Selects The Format And Filters Of A Streaming Export :
+type ExportOptions struct {
+	Format    string // csv Or ndjson.
+	Group     string // Users Only : Same Filter As The List Endpoint ( e.g., adult-1 ).
+	Status    string // Users Only : Same Filter As The List Endpoint ( Empty Leaves Out Suspended And Deactivated ).
+	Base      string // Groups Only : Base Category ( e.g., adult ).
+	BatchSize int    // Rows Fetched Per Query ( 0 Uses The Default ).
+}
@@ -8,6 +8,9 @@ import "time"
 // That Is Used To Manage Capacity Limits For Different Age Categories.
 type Group struct {
 
+	// Tenant Owning The Group ( Names Are Numbered Per Tenant ).
+	TenantID string `gorm:"primaryKey;size:64;default:default" json:"tenant_id" example:"default"`
+
 	// Group Name ( e.g., "adult-1", "senior-2" ).
 	// @Required
 	Name string `gorm:"primaryKey;size:64" json:"name" example:"adult-1"`
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Group Membership Records One Period A User Held A Seat In A Group ( Group History ).
+//
+// @Description A User's Seat In A Group Between JoinedAt And LeftAt.
+type GroupMembership struct {
+
+	// Membership Unique Identifier ( UUID ).
+	ID uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000" gorm:"type:uuid;primaryKey"`
+
+	// Tenant The Record Belongs To ( Set From The Request ).
+	TenantID string `json:"tenant_id" example:"default" gorm:"not null;default:default;index;size:64" readonly:"true"`
+
+	// Member.
+	UserID uuid.UUID `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440000" gorm:"type:uuid;not null;index"`
+
+	// Group Name ( e.g., "adult-1" ).
+	Group string `json:"group" example:"adult-1" gorm:"not null;size:64"`
+
+	// Base Age Category Of The Group.
+	Base string `json:"base" example:"adult" gorm:"not null;size:32"`
+
+	// Why The Seat Was Taken ( e.g., "allocated" ).
+	Reason string `json:"reason" example:"allocated" gorm:"not null;size:64"`
+
+	// Timestamp When The Seat Was Taken.
+	JoinedAt time.Time `json:"joined_at" example:"2025-09-01T12:00:00Z" gorm:"not null"`
+
+	// Timestamp When The Seat Was Released ( nil While Current ).
+	LeftAt *time.Time `json:"left_at,omitempty" example:"2025-10-01T12:00:00Z"`
+}
+
+// Before Create Ensures UUID Is Set Automatically :
+func (membership *GroupMembership) BeforeCreate(tx *gorm.DB) (err error) {
+
+	if membership.ID == uuid.Nil {
+
+		membership.ID = uuid.New()
+	}
+
+	return nil
+}
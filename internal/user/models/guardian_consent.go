@@ -0,0 +1,87 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Guardian Consent Records A Guardian's Consent For A Minor ( Child Or Teen ) To Be Registered.
+//
+// @Description Who Consented For Which User, When, How, And Whether It Was Revoked.
+type GuardianConsent struct {
+
+	// Consent Unique Identifier ( UUID ).
+	ID uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000" gorm:"type:uuid;primaryKey"`
+
+	// Tenant The Record Belongs To ( Set From The Request ).
+	TenantID string `json:"tenant_id" example:"default" gorm:"not null;default:default;index;size:64" readonly:"true"`
+
+	// The Minor.
+	UserID uuid.UUID `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440000" gorm:"type:uuid;not null;index"`
+
+	// Guardian Full Name.
+	GuardianName string `json:"guardian_name" example:"Jane Doe" gorm:"not null;size:255"`
+
+	// Guardian Email Address ( The Consent Link Is Sent Here ).
+	GuardianEmail string `json:"guardian_email" example:"jane.doe@example.com" gorm:"not null;size:320"`
+
+	// pending, granted Or revoked.
+	Status string `json:"status" example:"granted" gorm:"not null;index;size:16" enums:"pending,granted,revoked"`
+
+	// How Consent Was Given ( e.g., "email_link" ).
+	Method string `json:"method" example:"email_link" gorm:"not null;size:32"`
+
+	// SHA-256 Of The Consent Link Token ( The Token Itself Is Only Emailed ).
+	TokenHash string `json:"-" gorm:"not null;uniqueIndex;size:64"`
+
+	// Timestamp When The Consent Link Stops Working.
+	ExpiresAt time.Time `json:"expires_at" example:"2025-09-08T12:00:00Z" gorm:"not null"`
+
+	// Timestamp When Consent Was Requested.
+	RequestedAt time.Time `json:"requested_at" example:"2025-09-01T12:00:00Z" gorm:"not null"`
+
+	// Timestamp When The Guardian Consented.
+	GrantedAt *time.Time `json:"granted_at,omitempty" example:"2025-09-01T13:00:00Z"`
+
+	// Client IP The Consent Was Given From.
+	GrantedIP string `json:"granted_ip,omitempty" example:"203.0.113.7" gorm:"size:64"`
+
+	// Timestamp When Consent Was Revoked.
+	RevokedAt *time.Time `json:"revoked_at,omitempty" example:"2025-10-01T12:00:00Z"`
+
+	// Who Revoked The Consent ( Token Subject Or API Key ).
+	RevokedBy string `json:"revoked_by,omitempty" example:"admin-1" gorm:"size:255"`
+
+	// Why The Consent Was Revoked.
+	RevocationReason string `json:"revocation_reason,omitempty" example:"guardian withdrew consent" gorm:"size:512"`
+}
+
+// Before Create Ensures UUID Is Set Automatically :
+func (consent *GuardianConsent) BeforeCreate(tx *gorm.DB) (err error) {
+
+	if consent.ID == uuid.Nil {
+
+		consent.ID = uuid.New()
+	}
+
+	return nil
+}
+
+// Guardian Req Holds The Guardian's Contact Details, Required For Users Under 18 :
+type GuardianReq struct {
+	Name  string `json:"name" binding:"required" example:"Jane Doe"`
+	Email string `json:"email" binding:"required,email" example:"jane.doe@example.com"`
+}
+
+// Consent Filter Narrows A Consent Query ( Empty Fields Match Everything ) :
+type ConsentFilter struct {
+	UserID *uuid.UUID
+	Status string
+}
+
+// Revoke Consent Req Carries The Optional Reason For A Revocation :
+type RevokeConsentReq struct {
+	Reason string `json:"reason" example:"guardian withdrew consent"`
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Import Row Is One Data Row Read From A User Import File :
+type ImportRow struct {
+	Row         int // Line Number In The Source File ( The Header Is Line 1 ).
+	Name        string
+	Email       string
+	DateOfBirth string
+
+	// Optional Guardian Columns, Required For Minors.
+	GuardianName  string
+	GuardianEmail string
+}
+
+// Guardian Returns The Row's Guardian, Or nil When Both Columns Are Empty :
+func (row ImportRow) Guardian() *GuardianReq {
+
+	if row.GuardianName == "" && row.GuardianEmail == "" {
+
+		return nil
+	}
+
+	return &GuardianReq{Name: row.GuardianName, Email: row.GuardianEmail}
+}
+
+// Import Options Controls How An Import Is Applied :
+type ImportOptions struct {
+	DryRun    bool // Validate Only, Write Nothing.
+	BatchSize int  // Rows Committed Per Transaction ( 0 Uses The Default ).
+
+	// OnBatchTx ( Optional ) Runs Inside Each Batch Transaction With The Batch's Created Rows,
+	// So Callers Can Persist Progress Atomically With The Users Themselves.
+	OnBatchTx func(gormDB *gorm.DB, batch []ImportRowResult) error
+}
+
+// Import Row Result Reports The Outcome For A Single Row :
+type ImportRowResult struct {
+	Row         int        `json:"row" example:"2"`
+	Name        string     `json:"name" example:"John Doe"`
+	Email       string     `json:"email" example:"john@example.com"`
+	DateOfBirth string     `json:"date_of_birth" example:"1990-01-01"`
+	Status      string     `json:"status" example:"created" enums:"valid,invalid,created,failed"`
+	Errors      []string   `json:"errors,omitempty"`
+	UserID      *uuid.UUID `json:"user_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Group       string     `json:"group,omitempty" example:"adult-1"`
+}
+
+// Import Report Summarizes An Import With A Per-Row Breakdown :
+type ImportReport struct {
+	DryRun  bool              `json:"dry_run" example:"false"`
+	Total   int               `json:"total" example:"3"`
+	Valid   int               `json:"valid" example:"2"`
+	Invalid int               `json:"invalid" example:"1"`
+	Created int               `json:"created" example:"2"`
+	Failed  int               `json:"failed" example:"0"`
+	Rows    []ImportRowResult `json:"rows"`
+}
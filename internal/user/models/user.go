@@ -16,18 +16,42 @@ type User struct {
 	// @Required
 	ID uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000" gorm:"type:uuid;primaryKey"`
 
+	// Tenant The User Belongs To ( Set From The Request ).
+	TenantID string `json:"tenant_id" example:"default" gorm:"not null;default:default;size:64" readonly:"true"`
+
 	// Full Name Of The User.
 	// @Required
 	Name string `json:"name" example:"John Doe" gorm:"not null;size:255" binding:"required"`
 
-	// Email Address ( Unique, Valid format ).
+	// Email Address As Entered ( Unique Per Tenant By Its Canonical Form; IDN And Quoted Local Parts Accepted ).
 	// @Required
-	Email string `json:"email" example:"john.doe@example.com" gorm:"not null;uniqueIndex;size:320" binding:"required,email"`
+	Email string `json:"email" example:"John.Doe@example.com" gorm:"not null;size:320" binding:"required,email"`
+
+	// Form Compared For Duplicates: Lower-Case, Punycode Domain, Provider Aliases Folded.
+	EmailCanonical string `json:"email_canonical" example:"john.doe@example.com" gorm:"not null;default:'';size:320" readonly:"true"`
 
 	// Date Of Birth In YYYY-MM-DD Format ( Must Be In The Past ).
 	// @Required
 	DateOfBirth time.Time `json:"date_of_birth" example:"1990-05-15" gorm:"type:date;not null" binding:"required"`
 
+	// Whether The User Confirmed The Email Address ( Reset When It Changes ).
+	EmailVerified bool `json:"email_verified" example:"false" gorm:"not null;default:false" readonly:"true"`
+
+	// Timestamp When The Email Address Was Confirmed.
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty" example:"2025-09-01T12:05:00Z" readonly:"true"`
+
+	// Lifecycle Status ( Minors Stay pending_consent, Without A Group, Until Their Guardian Consents ).
+	Status string `json:"status" example:"active" enums:"pending_consent,active,suspended,deactivated" gorm:"not null;default:active;index;size:32" readonly:"true"`
+
+	// Reason Given For The Last Status Change.
+	StatusReason string `json:"status_reason,omitempty" example:"spam reports" gorm:"size:512" readonly:"true"`
+
+	// Actor Who Made The Last Status Change.
+	StatusChangedBy string `json:"status_changed_by,omitempty" example:"admin-1" gorm:"size:255" readonly:"true"`
+
+	// Timestamp Of The Last Status Change.
+	StatusChangedAt *time.Time `json:"status_changed_at,omitempty" example:"2025-09-01T12:10:00Z" readonly:"true"`
+
 	// Group Assignment ( Computed, Read-Only ).
 	Group string `json:"group" example:"adult-1" gorm:"not null;index;size:64" readonly:"true"`
 
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	auditModels "backend-task/internal/audit/models"
+)
+
+// User Data Bundle Holds Everything Stored About One User ( GDPR Data Portability ).
+//
+// @Description All Data Held About A User.
+type UserDataBundle struct {
+
+	// Timestamp When The Bundle Was Generated.
+	GeneratedAt time.Time `json:"generated_at" example:"2025-09-01T12:00:00Z"`
+
+	// The User Record.
+	User User `json:"user"`
+
+	// Current Group, With Its Base And Capacity.
+	Group *Group `json:"group,omitempty"`
+
+	// Every Group Seat The User Held, Oldest First.
+	GroupHistory []*GroupMembership `json:"group_history"`
+
+	// Guardian Consents Given ( Or Pending ) For The User, Oldest First.
+	Consents []*GuardianConsent `json:"consents"`
+
+	// Audit Entries Where The User Is The Subject, Newest First.
+	AuditEntries []*auditModels.AuditEntry `json:"audit_entries"`
+}
+
+// User Data Manifest Describes A Signed Export Archive : The SHA-256 Of Each File,
+// Itself Signed With HMAC-SHA256 In manifest.sig.
+type UserDataManifest struct {
+	UserID      string            `json:"user_id"`
+	GeneratedAt time.Time         `json:"generated_at"`
+	Algorithm   string            `json:"algorithm"`
+	Files       map[string]string `json:"files"` // File Name -> Hex SHA-256.
+}
@@ -0,0 +1,20 @@
+package models
+
+// User Search Filter Holds The Term And The Optional Criteria Of A User Search :
+type UserSearchFilter struct {
+	Query    string
+	Group    string
+	Status   string
+	Page     int
+	PageSize int
+}
+
+// User Page Is A Paginated Slice Of Users.
+//
+// @Description Paginated Users, Best Search Match First.
+type UserPage struct {
+	Items    []*User `json:"items"`
+	Total    int64   `json:"total" example:"12"`
+	Page     int     `json:"page" example:"1"`
+	PageSize int     `json:"page_size" example:"50"`
+}
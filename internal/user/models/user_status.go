@@ -0,0 +1,9 @@
+package models
+
+// Change Status Req Carries The Reason For A Status Change ( Suspend, Deactivate, Activate ) :
+type ChangeStatusReq struct {
+	Reason string `json:"reason" binding:"required" example:"repeated spam reports"`
+
+	// Suspend Only: Give Up The Group Seat ( Deactivation Always Does ).
+	ReleaseSeat bool `json:"release_seat" example:"false"`
+}
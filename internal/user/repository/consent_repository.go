@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"backend-task/internal/user/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Consent Repository Interface :
+type ConsentRepository interface {
+	CreateConsentTx(gormDB *gorm.DB, consent *models.GuardianConsent) error
+	TransitionConsentTx(gormDB *gorm.DB, consent *models.GuardianConsent, from string, fields ...string) (bool, error)
+	GetConsent(context context.Context, consentID uuid.UUID) (*models.GuardianConsent, error)
+	GetConsentByTokenHash(context context.Context, tokenHash string) (*models.GuardianConsent, error)
+	ListConsents(context context.Context, filter models.ConsentFilter) ([]*models.GuardianConsent, error)
+	ReassignConsentsTx(gormDB *gorm.DB, fromUserID, toUserID uuid.UUID) error
+}
+
+// ConsentRepositoryDB Implementation :
+type ConsentRepositoryDB struct {
+	gormDB *gorm.DB
+}
+
+// Constructor :
+func NewConsentRepository(db *gorm.DB) ConsentRepository {
+
+	return &ConsentRepositoryDB{gormDB: db}
+}
+
+func (consentRepositoryDB *ConsentRepositoryDB) CreateConsentTx(gormDB *gorm.DB, consent *models.GuardianConsent) error {
+
+	return gormDB.Create(consent).Error
+}
+
+// TransitionConsentTx Saves The Fields Only While The Stored Status Is Still from,
+// So Concurrent Grants Or Revocations Apply Once. The Boolean Reports Whether It Did.
+func (consentRepositoryDB *ConsentRepositoryDB) TransitionConsentTx(gormDB *gorm.DB, consent *models.GuardianConsent, from string, fields ...string) (bool, error) {
+
+	result := gormDB.Model(consent).Where("status = ?", from).Select(fields).Updates(consent)
+	return result.RowsAffected == 1, result.Error
+}
+
+func (consentRepositoryDB *ConsentRepositoryDB) GetConsent(context context.Context, consentID uuid.UUID) (*models.GuardianConsent, error) {
+
+	var consent models.GuardianConsent
+	if err := consentRepositoryDB.gormDB.WithContext(context).First(&consent, "id = ?", consentID).Error; err != nil {
+
+		return nil, err
+	}
+
+	return &consent, nil
+}
+
+func (consentRepositoryDB *ConsentRepositoryDB) GetConsentByTokenHash(context context.Context, tokenHash string) (*models.GuardianConsent, error) {
+
+	var consent models.GuardianConsent
+	if err := consentRepositoryDB.gormDB.WithContext(context).First(&consent, "token_hash = ?", tokenHash).Error; err != nil {
+
+		return nil, err
+	}
+
+	return &consent, nil
+}
+
+// ListConsents Returns Matching Consents, Oldest First :
+func (consentRepositoryDB *ConsentRepositoryDB) ListConsents(context context.Context, filter models.ConsentFilter) ([]*models.GuardianConsent, error) {
+
+	var consents []*models.GuardianConsent
+	gormDB := consentRepositoryDB.gormDB.WithContext(context).Order("requested_at ASC")
+	if filter.UserID != nil {
+
+		gormDB = gormDB.Where("user_id = ?", *filter.UserID)
+	}
+
+	if filter.Status != "" {
+
+		gormDB = gormDB.Where("status = ?", filter.Status)
+	}
+
+	if err := gormDB.Find(&consents).Error; err != nil {
+
+		return nil, fmt.Errorf("failed to list consents: %w", err)
+	}
+
+	return consents, nil
+}
+
+// ReassignConsentsTx Moves One User's Guardian Consents Onto Another ( User Merge ) :
+func (consentRepositoryDB *ConsentRepositoryDB) ReassignConsentsTx(gormDB *gorm.DB, fromUserID, toUserID uuid.UUID) error {
+
+	return gormDB.Model(&models.GuardianConsent{}).Where("user_id = ?", fromUserID).Update("user_id", toUserID).Error
+}
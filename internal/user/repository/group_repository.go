@@ -1,20 +1,29 @@
 package repository
 
 import (
+	"context"
 	"fmt"
+	"time"
 
-	"backend-task/internal/constants"
 	"backend-task/internal/user/models"
 	"backend-task/internal/utils"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
 // Group Repository Interface :
 type GroupRepository interface {
-	FindAllocatableGroupTx(gormDB *gorm.DB, base string) (*models.Group, error)
+	FindAllocatableGroupTx(gormDB *gorm.DB, base string, capacity int) (*models.Group, bool, error)
 	IncrementGroupCountTx(gormDB *gorm.DB, name string) error
+	DecrementGroupCountTx(gormDB *gorm.DB, name string) error
+	ListGroupsAfter(context context.Context, base string, after *models.Group, limit int) ([]*models.Group, error)
+	GetGroup(context context.Context, name string) (*models.Group, error)
+	RecordMembershipTx(gormDB *gorm.DB, membership *models.GroupMembership) error
+	CloseMembershipTx(gormDB *gorm.DB, userID uuid.UUID, leftAt time.Time) error
+	ListMemberships(context context.Context, userID uuid.UUID) ([]*models.GroupMembership, error)
+	ReassignMembershipsTx(gormDB *gorm.DB, fromUserID, toUserID uuid.UUID) error
 }
 
 // GroupRepositoryDB Implementation :
@@ -28,52 +37,129 @@ func NewGroupRepository(db *gorm.DB) GroupRepository {
 	return &GroupRepositoryDB{gormDB: db}
 }
 
-func (groupRepositoryDB *GroupRepositoryDB) FindAllocatableGroupTx(gormDB *gorm.DB, base string) (*models.Group, error) {
+// FindAllocatableGroupTx Returns A Group With Free Capacity, Creating The Next One When All Are Full.
+// New Groups Get The Given Capacity; Existing Groups Keep The Capacity They Were Created With.
+// The Boolean Reports Whether The Group Was Created By This Call.
+func (groupRepositoryDB *GroupRepositoryDB) FindAllocatableGroupTx(gormDB *gorm.DB, base string, capacity int) (*models.Group, bool, error) {
 
 	var group models.Group
 
 	// Try To find Existing Group With Available Capacity.
 	err := gormDB.Clauses(clause.Locking{Strength: "UPDATE"}).
-		Where("base = ? AND member_count < ?", base, constants.GroupCapacity).
-		Order("index ASC").
+		Where("base = ? AND member_count < capacity", base).
+		Order("\"index\" ASC").
 		First(&group).Error
 
 	if err == nil {
 
-		return &group, nil
+		return &group, false, nil
 	}
 
 	if err != nil && err != gorm.ErrRecordNotFound {
 
-		return nil, fmt.Errorf("%w: %v", utils.ErrFailedToFindGroup, err)
+		return nil, false, fmt.Errorf("%w: %v", utils.ErrFailedToFindGroup, err)
 	}
 
 	// No Available Group, Create New.
 	var maxIndex int
 	if err2 := gormDB.Model(&models.Group{}).Where("base = ?", base).Select("COALESCE(MAX(\"index\"),0)").Scan(&maxIndex).Error; err2 != nil {
 
-		return nil, fmt.Errorf("%w: %v", utils.ErrFailedToGetMaxGroupIdx, err2)
+		return nil, false, fmt.Errorf("%w: %v", utils.ErrFailedToGetMaxGroupIdx, err2)
 	}
 
 	group = models.Group{
 
 		Base:     base,
 		Index:    maxIndex + 1,
-		Capacity: constants.GroupCapacity,
+		Capacity: capacity,
 		Name:     fmt.Sprintf("%s-%d", base, maxIndex+1),
 	}
 
 	if err3 := gormDB.Create(&group).Error; err3 != nil {
 
-		return nil, fmt.Errorf("%w: %v", utils.ErrFailedToCreateNewGroup, err3)
+		return nil, false, fmt.Errorf("%w: %v", utils.ErrFailedToCreateNewGroup, err3)
 	}
 
-	return &group, nil
+	return &group, true, nil
 }
 
 func (groupRepositoryDB *GroupRepositoryDB) IncrementGroupCountTx(tx *gorm.DB, name string) error {
 
 	return tx.Model(&models.Group{}).
-		Where("name = ? AND member_count < ?", name, constants.GroupCapacity).
+		Where("name = ? AND member_count < capacity", name).
 		Update("member_count", gorm.Expr("member_count + 1")).Error
 }
+
+func (groupRepositoryDB *GroupRepositoryDB) DecrementGroupCountTx(tx *gorm.DB, name string) error {
+
+	return tx.Model(&models.Group{}).
+		Where("name = ? AND member_count > 0", name).
+		Update("member_count", gorm.Expr("member_count - 1")).Error
+}
+
+// ListGroupsAfter Returns The Next Page Of Groups Ordered By Base And Index ( Keyset Pagination ) :
+func (groupRepositoryDB *GroupRepositoryDB) ListGroupsAfter(context context.Context, base string, after *models.Group, limit int) ([]*models.Group, error) {
+
+	var groups []*models.Group
+	gormDB := groupRepositoryDB.gormDB.WithContext(context).Order("base ASC").Order("\"index\" ASC").Limit(limit)
+	if after != nil {
+
+		gormDB = gormDB.Where("base > ? OR (base = ? AND \"index\" > ?)", after.Base, after.Base, after.Index)
+	}
+
+	if base != "" {
+
+		gormDB = gormDB.Where("base = ?", base)
+	}
+
+	if err := gormDB.Find(&groups).Error; err != nil {
+
+		return nil, fmt.Errorf("failed to list groups: %w", err)
+	}
+
+	return groups, nil
+}
+
+func (groupRepositoryDB *GroupRepositoryDB) GetGroup(context context.Context, name string) (*models.Group, error) {
+
+	var group models.Group
+	if err := groupRepositoryDB.gormDB.WithContext(context).First(&group, "name = ?", name).Error; err != nil {
+
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+// ---------------- Group History ----------------
+
+func (groupRepositoryDB *GroupRepositoryDB) RecordMembershipTx(gormDB *gorm.DB, membership *models.GroupMembership) error {
+
+	return gormDB.Create(membership).Error
+}
+
+// CloseMembershipTx Ends The User's Current Seat ( The Membership Without LeftAt ) :
+func (groupRepositoryDB *GroupRepositoryDB) CloseMembershipTx(gormDB *gorm.DB, userID uuid.UUID, leftAt time.Time) error {
+
+	return gormDB.Model(&models.GroupMembership{}).
+		Where("user_id = ? AND left_at IS NULL", userID).
+		Update("left_at", leftAt).Error
+}
+
+// ListMemberships Returns A User's Group History, Oldest First :
+func (groupRepositoryDB *GroupRepositoryDB) ListMemberships(context context.Context, userID uuid.UUID) ([]*models.GroupMembership, error) {
+
+	var memberships []*models.GroupMembership
+	if err := groupRepositoryDB.gormDB.WithContext(context).Where("user_id = ?", userID).Order("joined_at ASC").Find(&memberships).Error; err != nil {
+
+		return nil, fmt.Errorf("failed to list group history: %w", err)
+	}
+
+	return memberships, nil
+}
+
+// ReassignMembershipsTx Moves One User's Group History Onto Another ( User Merge ) :
+func (groupRepositoryDB *GroupRepositoryDB) ReassignMembershipsTx(gormDB *gorm.DB, fromUserID, toUserID uuid.UUID) error {
+
+	return gormDB.Model(&models.GroupMembership{}).Where("user_id = ?", fromUserID).Update("user_id", toUserID).Error
+}
@@ -2,9 +2,12 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"backend-task/internal/db"
 	"backend-task/internal/user/models"
+	"backend-task/internal/utils"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -12,11 +15,20 @@ import (
 
 // User Repository Interface :
 type UserRepository interface {
-	CreateNewUser(context context.Context, user *models.User) error
-	GetUserByID(context context.Context, userID uuid.UUID) (*models.User, error)
-	UpdateUser(context context.Context, user *models.User, fields ...string) error
+	CreateNewUser(context context.Context, user *models.User) error // utils.ErrEmailAlreadyExists When The Email Is Taken.
+	CreateNewUserTx(gormDB *gorm.DB, user *models.User) error
+	GetUserByID(context context.Context, userID uuid.UUID) (*models.User, error)   // utils.ErrUserNotFound When Missing.
+	GetUserByEmail(context context.Context, email string) (*models.User, error)    // utils.ErrUserNotFound When Missing.
+	UpdateUser(context context.Context, user *models.User, fields ...string) error // utils.ErrEmailAlreadyExists When The Email Is Taken.
+	UpdateUserTx(gormDB *gorm.DB, user *models.User, fields ...string) error
+	TransitionUserStatusTx(gormDB *gorm.DB, user *models.User, from string, fields ...string) (bool, error)
 	ListUsers(context context.Context, group string) ([]*models.User, error)
-	IsEmailExists(context context.Context, email string) (bool, error)
+	ListUsersByStatus(context context.Context, group string, statuses []string) ([]*models.User, error)
+	SearchUsers(context context.Context, filter models.UserSearchFilter, statuses []string) ([]*models.User, int64, error)
+	IsEmailExists(context context.Context, canonical string) (bool, error) // Advisory; The Unique Index Is Authoritative.
+	FindExistingEmails(context context.Context, canonicals []string) ([]string, error)
+	ListUsersAfter(context context.Context, group string, statuses []string, afterID uuid.UUID, limit int) ([]*models.User, error)
+	DeleteUserTx(gormDB *gorm.DB, user *models.User) (bool, error)
 }
 
 // UserRepositoryDB Implementation :
@@ -32,7 +44,12 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 
 func (userRepositoryDB *UserRepositoryDB) CreateNewUser(context context.Context, user *models.User) error {
 
-	return userRepositoryDB.gormDB.WithContext(context).Create(user).Error
+	return userWriteError(userRepositoryDB.gormDB.WithContext(context).Create(user).Error)
+}
+
+func (userRepositoryDB *UserRepositoryDB) CreateNewUserTx(gormDB *gorm.DB, user *models.User) error {
+
+	return userWriteError(gormDB.Create(user).Error)
 }
 
 func (userRepositoryDB *UserRepositoryDB) GetUserByID(context context.Context, userID uuid.UUID) (*models.User, error) {
@@ -40,18 +57,42 @@ func (userRepositoryDB *UserRepositoryDB) GetUserByID(context context.Context, u
 	var user models.User
 	if err := userRepositoryDB.gormDB.WithContext(context).First(&user, "id = ?", userID).Error; err != nil {
 
-		return nil, fmt.Errorf("user not found: %w", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+
+			return nil, utils.ErrUserNotFound
+		}
+
+		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 	return &user, nil
 }
 
 func (userRepositoryDB *UserRepositoryDB) UpdateUser(context context.Context, user *models.User, fields ...string) error {
 
-	return userRepositoryDB.gormDB.WithContext(context).Model(user).Select(fields).Updates(user).Error
+	return userWriteError(userRepositoryDB.gormDB.WithContext(context).Model(user).Select(fields).Updates(user).Error)
+}
+
+func (userRepositoryDB *UserRepositoryDB) UpdateUserTx(gormDB *gorm.DB, user *models.User, fields ...string) error {
+
+	return userWriteError(gormDB.Model(user).Select(fields).Updates(user).Error)
+}
+
+// TransitionUserStatusTx Saves The Fields Only While The Stored Status Is Still from,
+// So Concurrent Status Changes Apply Once. The Boolean Reports Whether It Did.
+func (userRepositoryDB *UserRepositoryDB) TransitionUserStatusTx(gormDB *gorm.DB, user *models.User, from string, fields ...string) (bool, error) {
+
+	result := gormDB.Model(user).Where("status = ?", from).Select(fields).Updates(user)
+	return result.RowsAffected == 1, userWriteError(result.Error)
 }
 
 func (userRepositoryDB *UserRepositoryDB) ListUsers(context context.Context, group string) ([]*models.User, error) {
 
+	return userRepositoryDB.ListUsersByStatus(context, group, nil)
+}
+
+// ListUsersByStatus Lists Users In Any Of The Given Statuses ( All Statuses When Empty ) :
+func (userRepositoryDB *UserRepositoryDB) ListUsersByStatus(context context.Context, group string, statuses []string) ([]*models.User, error) {
+
 	var users []*models.User
 	gormDB := userRepositoryDB.gormDB.WithContext(context).Order("created_at asc")
 	if group != "" {
@@ -59,6 +100,11 @@ func (userRepositoryDB *UserRepositoryDB) ListUsers(context context.Context, gro
 		gormDB = gormDB.Where("\"group\" = ?", group)
 	}
 
+	if len(statuses) > 0 {
+
+		gormDB = gormDB.Where("status IN ?", statuses)
+	}
+
 	if err := gormDB.Find(&users).Error; err != nil {
 
 		return nil, fmt.Errorf("failed to list users: %w", err)
@@ -67,13 +113,134 @@ func (userRepositoryDB *UserRepositoryDB) ListUsers(context context.Context, gro
 	return users, nil
 }
 
-func (userRepositoryDB *UserRepositoryDB) IsEmailExists(context context.Context, email string) (bool, error) {
+// SearchUsers Returns One Page Of Users Matching filter.Query, Best Match First, And The Number Of Matches :
+func (userRepositoryDB *UserRepositoryDB) SearchUsers(context context.Context, filter models.UserSearchFilter, statuses []string) ([]*models.User, int64, error) {
+
+	search := db.NewUserSearch(userRepositoryDB.gormDB, filter.Query)
+	gormDB := userRepositoryDB.gormDB.WithContext(context).Model(&models.User{}).Scopes(search.Match)
+	if filter.Group != "" {
+
+		gormDB = gormDB.Where("users.\"group\" = ?", filter.Group)
+	}
+
+	if len(statuses) > 0 {
+
+		gormDB = gormDB.Where("users.status IN ?", statuses)
+	}
+
+	var total int64
+	if err := gormDB.Count(&total).Error; err != nil {
+
+		return nil, 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	var users []*models.User
+	if err := gormDB.Select("users.*").Scopes(search.Rank).
+		Offset((filter.Page - 1) * filter.PageSize).
+		Limit(filter.PageSize).
+		Find(&users).Error; err != nil {
+
+		return nil, 0, fmt.Errorf("failed to search users: %w", err)
+	}
+
+	return users, total, nil
+}
+
+func (userRepositoryDB *UserRepositoryDB) GetUserByEmail(context context.Context, email string) (*models.User, error) {
+
+	var user models.User
+	if err := userRepositoryDB.gormDB.WithContext(context).First(&user, "lower(email) = lower(?)", email).Error; err != nil {
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+
+			return nil, utils.ErrUserNotFound
+		}
+
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return &user, nil
+}
+
+// IsEmailExists Lets Callers Fail Early With A Clear Error. Two Concurrent Creates Can Both
+// Pass It, So Writes Still Rely On The Unique Index ( See userWriteError ) :
+func (userRepositoryDB *UserRepositoryDB) IsEmailExists(context context.Context, canonical string) (bool, error) {
 
 	var count int64
-	if err := userRepositoryDB.gormDB.WithContext(context).Model(&models.User{}).Where("email = ?", email).Count(&count).Error; err != nil {
+	if err := userRepositoryDB.gormDB.WithContext(context).Model(&models.User{}).Where("email_canonical = ?", canonical).Count(&count).Error; err != nil {
 
 		return false, fmt.Errorf("failed to check email existence: %w", err)
 	}
 
 	return count > 0, nil
 }
+
+// FindExistingEmails Returns Which Of The Given Canonical Emails Are Already Registered,
+// Querying In Chunks To Stay Below Driver Bind-Parameter Limits :
+func (userRepositoryDB *UserRepositoryDB) FindExistingEmails(context context.Context, canonicals []string) ([]string, error) {
+
+	const chunkSize = 500
+
+	var existing []string
+	for start := 0; start < len(canonicals); start += chunkSize {
+
+		var found []string
+		chunk := canonicals[start:min(start+chunkSize, len(canonicals))]
+		if err := userRepositoryDB.gormDB.WithContext(context).Model(&models.User{}).Where("email_canonical IN ?", chunk).Pluck("email_canonical", &found).Error; err != nil {
+
+			return nil, fmt.Errorf("failed to check existing emails: %w", err)
+		}
+
+		existing = append(existing, found...)
+	}
+
+	return existing, nil
+}
+
+// ListUsersAfter Returns The Next Page Of Users Ordered By ID ( Keyset Pagination ),
+// So Exports Can Walk The Whole Table In Constant Memory; Statuses Filter As In ListUsersByStatus :
+func (userRepositoryDB *UserRepositoryDB) ListUsersAfter(context context.Context, group string, statuses []string, afterID uuid.UUID, limit int) ([]*models.User, error) {
+
+	var users []*models.User
+	gormDB := userRepositoryDB.gormDB.WithContext(context).Order("id ASC").Limit(limit)
+	if afterID != uuid.Nil {
+
+		gormDB = gormDB.Where("id > ?", afterID)
+	}
+
+	if group != "" {
+
+		gormDB = gormDB.Where("\"group\" = ?", group)
+	}
+
+	if len(statuses) > 0 {
+
+		gormDB = gormDB.Where("status IN ?", statuses)
+	}
+
+	if err := gormDB.Find(&users).Error; err != nil {
+
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	return users, nil
+}
+
+// DeleteUserTx Removes The User Row; The Boolean Reports Whether It Still Existed :
+func (userRepositoryDB *UserRepositoryDB) DeleteUserTx(gormDB *gorm.DB, user *models.User) (bool, error) {
+
+	result := gormDB.Delete(&models.User{}, "id = ?", user.ID)
+	return result.RowsAffected == 1, result.Error
+}
+
+// userWriteError Turns A Unique Violation On Insert Or Update Into utils.ErrEmailAlreadyExists;
+// The Email Index Is The Only Unique Constraint On Users Besides The Random Primary Key :
+func userWriteError(err error) error {
+
+	if err != nil && db.IsUniqueViolation(err) {
+
+		return utils.ErrEmailAlreadyExists
+	}
+
+	return err
+}
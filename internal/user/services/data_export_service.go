@@ -0,0 +1,238 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+
+	auditModels "backend-task/internal/audit/models"
+	auditServiceInterface "backend-task/internal/audit/services/interface"
+	"backend-task/internal/constants"
+	"backend-task/internal/user/models"
+	"backend-task/internal/user/repository"
+	userServiceInterface "backend-task/internal/user/services/interface"
+	"backend-task/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type DataExportService struct {
+	users      repository.UserRepository
+	groups     repository.GroupRepository
+	consents   repository.ConsentRepository
+	audit      auditServiceInterface.AuditService
+	signingKey []byte
+	now        func() time.Time
+}
+
+func NewDataExportService(users repository.UserRepository, groups repository.GroupRepository, consents repository.ConsentRepository, audit auditServiceInterface.AuditService, signingKey []byte) userServiceInterface.DataExportService {
+
+	return &DataExportService{users: users, groups: groups, consents: consents, audit: audit, signingKey: signingKey, now: time.Now}
+}
+
+// ---------------- Bundle ----------------
+
+func (dataExportService *DataExportService) BuildBundle(context context.Context, id string) (*models.UserDataBundle, error) {
+
+	uid, err := uuid.Parse(id)
+	if err != nil {
+
+		return nil, utils.NewBadRequest(utils.ErrInvalidID)
+	}
+
+	user, err := dataExportService.users.GetUserByID(context, uid)
+	if err != nil {
+
+		if errors.Is(err, utils.ErrUserNotFound) {
+
+			return nil, utils.NewNotFound(utils.ErrUserNotFound)
+		}
+
+		return nil, err
+	}
+
+	bundle := &models.UserDataBundle{GeneratedAt: dataExportService.now().UTC(), User: *user}
+
+	if user.Group != "" {
+
+		group, err := dataExportService.groups.GetGroup(context, user.Group)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+
+			return nil, err
+		}
+
+		bundle.Group = group
+	}
+
+	if bundle.GroupHistory, err = dataExportService.groups.ListMemberships(context, user.ID); err != nil {
+
+		return nil, err
+	}
+
+	if bundle.Consents, err = dataExportService.consents.ListConsents(context, models.ConsentFilter{UserID: &user.ID}); err != nil {
+
+		return nil, err
+	}
+
+	if bundle.AuditEntries, err = dataExportService.auditTrail(context, user.ID.String()); err != nil {
+
+		return nil, err
+	}
+
+	return bundle, nil
+}
+
+// auditTrail Collects Every Audit Entry About The User, Page By Page :
+func (dataExportService *DataExportService) auditTrail(context context.Context, userID string) ([]*auditModels.AuditEntry, error) {
+
+	entries := []*auditModels.AuditEntry{}
+	filter := auditModels.AuditFilter{TargetType: constants.AuditTargetUser, TargetID: userID, Page: 1, PageSize: constants.MaxPageSize}
+
+	for {
+
+		page, err := dataExportService.audit.QueryEntries(context, filter)
+		if err != nil {
+
+			return nil, err
+		}
+
+		entries = append(entries, page.Items...)
+		if len(page.Items) == 0 || int64(len(entries)) >= page.Total {
+
+			return entries, nil
+		}
+
+		filter.Page++
+	}
+}
+
+// ---------------- Signed Archive ----------------
+
+// WriteArchive Writes bundle.json, A Manifest With Its SHA-256, And The Manifest's HMAC-SHA256 Signature :
+func (dataExportService *DataExportService) WriteArchive(bundle *models.UserDataBundle, writer io.Writer) error {
+
+	if len(dataExportService.signingKey) == 0 {
+
+		return utils.NewInternalError(utils.ErrExportSigningKeyMissing)
+	}
+
+	bundleJSON, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+
+		return err
+	}
+
+	digest := sha256.Sum256(bundleJSON)
+	manifestJSON, err := json.MarshalIndent(models.UserDataManifest{
+
+		UserID:      bundle.User.ID.String(),
+		GeneratedAt: bundle.GeneratedAt,
+		Algorithm:   constants.DataExportAlgorithm,
+		Files:       map[string]string{constants.DataExportBundleFile: hex.EncodeToString(digest[:])},
+	}, "", "  ")
+
+	if err != nil {
+
+		return err
+	}
+
+	archive := zip.NewWriter(writer)
+	files := []struct {
+		name    string
+		content []byte
+	}{
+		{constants.DataExportBundleFile, bundleJSON},
+		{constants.DataExportManifestFile, manifestJSON},
+		{constants.DataExportSignatureFile, []byte(signManifest(dataExportService.signingKey, manifestJSON))},
+	}
+
+	for _, file := range files {
+
+		entry, err := archive.CreateHeader(&zip.FileHeader{Name: file.name, Method: zip.Deflate, Modified: bundle.GeneratedAt})
+		if err != nil {
+
+			return err
+		}
+
+		if _, err := entry.Write(file.content); err != nil {
+
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+// VerifyUserDataArchive Checks The Manifest Signature And File Digests Of An Export Archive
+// And Returns The Bundle It Contains :
+func VerifyUserDataArchive(archive []byte, signingKey []byte) (*models.UserDataBundle, error) {
+
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+
+		return nil, err
+	}
+
+	contents := make(map[string][]byte, len(reader.File))
+	for _, file := range reader.File {
+
+		opened, err := file.Open()
+		if err != nil {
+
+			return nil, err
+		}
+
+		content, err := io.ReadAll(opened)
+		opened.Close()
+		if err != nil {
+
+			return nil, err
+		}
+
+		contents[file.Name] = content
+	}
+
+	manifestJSON := contents[constants.DataExportManifestFile]
+	signature := contents[constants.DataExportSignatureFile]
+	if !hmac.Equal([]byte(signManifest(signingKey, manifestJSON)), signature) {
+
+		return nil, utils.ErrInvalidExportSignature
+	}
+
+	var manifest models.UserDataManifest
+	if err := json.Unmarshal(manifestJSON, &manifest); err != nil {
+
+		return nil, err
+	}
+
+	bundleJSON := contents[constants.DataExportBundleFile]
+	digest := sha256.Sum256(bundleJSON)
+	if manifest.Files[constants.DataExportBundleFile] != hex.EncodeToString(digest[:]) {
+
+		return nil, utils.ErrInvalidExportSignature
+	}
+
+	var bundle models.UserDataBundle
+	if err := json.Unmarshal(bundleJSON, &bundle); err != nil {
+
+		return nil, err
+	}
+
+	return &bundle, nil
+}
+
+// signManifest Returns The Hex HMAC-SHA256 Of The Manifest :
+func signManifest(signingKey, manifest []byte) string {
+
+	mac := hmac.New(sha256.New, signingKey)
+	mac.Write(manifest)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"strings"
+
+	"backend-task/internal/constants"
+	"backend-task/internal/mail"
+	"backend-task/internal/utils"
+)
+
+// EmailPolicy Decides Which Addresses Are Accepted And Which Ones Count As The Same Mailbox :
+type EmailPolicy struct {
+	FoldProviderAliases bool                  // Gmail Dots, +tags And Alias Domains Are One Mailbox.
+	Disposable          *mail.DomainBlocklist // Domains Refused For New Addresses ( nil = None ).
+}
+
+// emailInput Is An Address As Entered Plus The Canonical Form Compared For Uniqueness :
+type emailInput struct {
+	raw       string
+	canonical string
+}
+
+// check Parses email, Adding A Field Error Under field When It Is Malformed ( invalid ) Or Disposable.
+// The Result Is Only Meaningful When No Error Was Added.
+func (policy EmailPolicy) check(fields *utils.FieldErrors, field, email string, invalid error) emailInput {
+
+	address, err := mail.ParseAddress(email)
+	if err != nil {
+
+		fields.Add(field, "email", invalid)
+		return emailInput{}
+	}
+
+	if policy.Disposable.Blocks(address.Domain) {
+
+		fields.Add(field, constants.RuleNotDisposable, utils.ErrDisposableEmail)
+		return emailInput{}
+	}
+
+	return emailInput{raw: strings.TrimSpace(email), canonical: address.Canonical(policy.FoldProviderAliases)}
+}
+
+// Canonical Returns The Form Compared For Uniqueness, Or "" When email Is Malformed ( Left To Validation ) :
+func (policy EmailPolicy) Canonical(email string) string {
+
+	address, err := mail.ParseAddress(email)
+	if err != nil {
+
+		return ""
+	}
+
+	return address.Canonical(policy.FoldProviderAliases)
+}
@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"backend-task/internal/constants"
+	eventModels "backend-task/internal/events/models"
+	"backend-task/internal/i18n"
+	"backend-task/internal/mail"
+	"backend-task/internal/user/models"
+	"backend-task/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// VerificationConfig Configures The Links Emailed To Confirm Contact Details ( Email Verification, Guardian Consent ).
+// Without A Key, A Random One Is Generated ( Verification Links Stop Working After A Restart ).
+type VerificationConfig struct {
+	Mailer     mail.Mailer // nil Disables Sending.
+	Key        []byte
+	TTL        time.Duration
+	ConsentTTL time.Duration // Lifetime Of Guardian Consent Links.
+	BaseURL    string        // Prefix Of The Link In The Email ( e.g., https://api.example.com ).
+	Now        func() time.Time
+}
+
+// verificationClaims Is The Signed Part Of A Token; Binding The Email Voids Tokens Of A Previous Address :
+type verificationClaims struct {
+	UserID    uuid.UUID `json:"sub"`
+	Email     string    `json:"email"`
+	ExpiresAt int64     `json:"exp"`
+}
+
+// ---------------- Verify Email ----------------
+
+// VerifyEmail Confirms The Address A Token Was Issued For; Verifying Twice Is Harmless :
+func (userService *UserService) VerifyEmail(context context.Context, token string) (*models.User, error) {
+
+	claims, err := userService.parseVerificationToken(token)
+	if err != nil {
+
+		return nil, utils.NewBadRequest(utils.ErrInvalidVerificationToken)
+	}
+
+	user, err := userService.users.GetUserByID(context, claims.UserID)
+	if err != nil {
+
+		if errors.Is(err, utils.ErrUserNotFound) {
+
+			return nil, utils.NewBadRequest(utils.ErrInvalidVerificationToken)
+		}
+
+		return nil, err
+	}
+
+	// The Address Changed Since The Token Was Sent :
+	if user.Email != claims.Email {
+
+		return nil, utils.NewBadRequest(utils.ErrInvalidVerificationToken)
+	}
+
+	if user.EmailVerified {
+
+		return user, nil
+	}
+
+	before := *user
+	verifiedAt := userService.verification.Now().UTC()
+	user.EmailVerified = true
+	user.EmailVerifiedAt = &verifiedAt
+
+	err = userService.db.WithContext(context).Transaction(func(gormDB *gorm.DB) error {
+
+		if err := userService.users.UpdateUserTx(gormDB, user, "email_verified", "email_verified_at"); err != nil {
+
+			return err
+		}
+
+		if err := userService.audit.RecordTx(context, gormDB, constants.AuditActionUpdate, constants.AuditTargetUser, user.ID.String(), &before, user); err != nil {
+
+			return err
+		}
+
+		return userService.events.EmitTx(context, gormDB, constants.EventUserUpdated, eventModels.UserEventPayload{User: *user, GroupBase: groupBase(user.Group)})
+	})
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// ---------------- Helper ----------------
+
+// sendVerification Emails A Fresh Verification Link After The User Was Committed.
+// Failures Are Logged Only: The User Exists Either Way.
+func (userService *UserService) sendVerification(context context.Context, user *models.User) {
+
+	if userService.verification.Mailer == nil {
+
+		return
+	}
+
+	token, err := userService.verificationToken(user)
+	if err != nil {
+
+		utils.Error(fmt.Sprintf("verification token for user %s: %v", user.ID, err))
+		return
+	}
+
+	link := strings.TrimRight(userService.verification.BaseURL, "/") + constants.EmailVerificationPath + "?token=" + url.QueryEscape(token) + "&" + constants.QueryTenantID + "=" + url.QueryEscape(user.TenantID)
+	language := utils.RequestMetaFromContext(context).Language
+	message := mail.Message{
+		To:      user.Email,
+		Subject: i18n.Text(language, constants.MessageEmailVerificationSubject),
+		Body:    i18n.Text(language, constants.MessageEmailVerificationBody, "name", user.Name, "link", link, "ttl", userService.verification.TTL.String()),
+	}
+
+	if err := userService.verification.Mailer.Send(context, message); err != nil {
+
+		utils.Error(fmt.Sprintf("verification email for user %s: %v", user.ID, err))
+	}
+}
+
+// NotifyEmailTaken Emails The Address Instead Of Telling The Registering Caller It Is Taken :
+func (userService *UserService) NotifyEmailTaken(context context.Context, email string) {
+
+	if userService.verification.Mailer == nil {
+
+		return
+	}
+
+	language := utils.RequestMetaFromContext(context).Language
+	message := mail.Message{
+		To:      email,
+		Subject: i18n.Text(language, constants.MessageEmailTakenSubject),
+		Body:    i18n.Text(language, constants.MessageEmailTakenBody, "email", email),
+	}
+
+	if err := userService.verification.Mailer.Send(context, message); err != nil {
+
+		utils.Error(fmt.Sprintf("email taken notice: %v", err))
+	}
+}
+
+// verificationToken Returns base64url( claims ) "." base64url( HMAC-SHA256 ) :
+func (userService *UserService) verificationToken(user *models.User) (string, error) {
+
+	claims := verificationClaims{UserID: user.ID, Email: user.Email, ExpiresAt: userService.verification.Now().Add(userService.verification.TTL).Unix()}
+
+	raw, err := json.Marshal(claims)
+	if err != nil {
+
+		return "", err
+	}
+
+	payload := base64.RawURLEncoding.EncodeToString(raw)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(userService.signVerification(payload)), nil
+}
+
+func (userService *UserService) parseVerificationToken(token string) (*verificationClaims, error) {
+
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok {
+
+		return nil, utils.ErrInvalidVerificationToken
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(decoded, userService.signVerification(payload)) {
+
+		return nil, utils.ErrInvalidVerificationToken
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+
+		return nil, utils.ErrInvalidVerificationToken
+	}
+
+	var claims verificationClaims
+	if err := json.Unmarshal(raw, &claims); err != nil {
+
+		return nil, utils.ErrInvalidVerificationToken
+	}
+
+	if userService.verification.Now().Unix() >= claims.ExpiresAt {
+
+		return nil, utils.ErrInvalidVerificationToken
+	}
+
+	return &claims, nil
+}
+
+func (userService *UserService) signVerification(payload string) []byte {
+
+	mac := hmac.New(sha256.New, userService.verification.Key)
+	mac.Write([]byte(payload))
+
+	return mac.Sum(nil)
+}
+
+// withVerificationDefaults Fills In The Clock, TTL And ( Ephemeral ) Key :
+func withVerificationDefaults(config VerificationConfig) VerificationConfig {
+
+	if config.Now == nil {
+
+		config.Now = time.Now
+	}
+
+	if config.TTL <= 0 {
+
+		config.TTL = 24 * time.Hour
+	}
+
+	if config.ConsentTTL <= 0 {
+
+		config.ConsentTTL = 7 * 24 * time.Hour
+	}
+
+	if len(config.Key) == 0 {
+
+		config.Key = make([]byte, 32)
+		rand.Read(config.Key)
+
+		if config.Mailer != nil {
+
+			utils.Info("EMAIL_VERIFICATION_KEY is not set, verification links stop working after a restart")
+		}
+	}
+
+	return config
+}
@@ -0,0 +1,359 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"backend-task/internal/constants"
+	eventModels "backend-task/internal/events/models"
+	"backend-task/internal/i18n"
+	"backend-task/internal/mail"
+	"backend-task/internal/user/models"
+	"backend-task/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ---------------- Grant Consent ----------------
+
+// GrantConsent Records The Guardian's Consent From The Emailed Link And Allocates The Minor To A Group :
+func (userService *UserService) GrantConsent(context context.Context, token string) (*models.User, error) {
+
+	consent, err := userService.consents.GetConsentByTokenHash(context, hashConsentToken(token))
+	if err != nil {
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+
+			return nil, utils.NewBadRequest(utils.ErrInvalidConsentToken)
+		}
+
+		return nil, err
+	}
+
+	now := userService.verification.Now().UTC()
+	if consent.Status != constants.ConsentStatusPending || !now.Before(consent.ExpiresAt) {
+
+		return nil, utils.NewBadRequest(utils.ErrInvalidConsentToken)
+	}
+
+	user, err := userService.users.GetUserByID(context, consent.UserID)
+	if err != nil {
+
+		return nil, err
+	}
+
+	beforeConsent, beforeUser := *consent, *user
+	consent.Status = constants.ConsentStatusGranted
+	consent.Method = constants.ConsentMethodEmailLink
+	consent.GrantedAt = &now
+	consent.GrantedIP = utils.RequestMetaFromContext(context).ClientIP
+
+	err = userService.db.WithContext(context).Transaction(func(gormDB *gorm.DB) error {
+
+		granted, err := userService.consents.TransitionConsentTx(gormDB, consent, constants.ConsentStatusPending, "status", "method", "granted_at", "granted_ip")
+		if err != nil {
+
+			return err
+		}
+
+		if !granted {
+
+			return utils.NewBadRequest(utils.ErrInvalidConsentToken)
+		}
+
+		if err := userService.audit.RecordTx(context, gormDB, constants.AuditActionUpdate, constants.AuditTargetConsent, consent.ID.String(), &beforeConsent, consent); err != nil {
+
+			return err
+		}
+
+		// Allocation Was Deferred Until Now; A Deactivated Minor Is Allocated When Re-Activated :
+		if user.Status != constants.UserStatusPendingConsent {
+
+			return nil
+		}
+
+		group, groupCreated, err := userService.allocatableGroupTx(context, gormDB, user.DateOfBirth)
+		if err != nil {
+
+			return err
+		}
+
+		user.Group = group.Name
+		user.Status = constants.UserStatusActive
+		if err := userService.users.UpdateUserTx(gormDB, user, "group", "status"); err != nil {
+
+			return err
+		}
+
+		if err := userService.takeSeatTx(gormDB, user, group, now); err != nil {
+
+			return err
+		}
+
+		if err := userService.audit.RecordTx(context, gormDB, constants.AuditActionUpdate, constants.AuditTargetUser, user.ID.String(), &beforeUser, user); err != nil {
+
+			return err
+		}
+
+		return userService.emitAllocationEventsTx(context, gormDB, user, group, groupCreated, constants.EventUserUpdated)
+	})
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// ---------------- Revoke Consent ----------------
+
+// RevokeConsent Withdraws A Consent; A Minor Who Was Allocated Loses Their Seat And Returns To pending_consent :
+func (userService *UserService) RevokeConsent(context context.Context, id, reason string) (*models.GuardianConsent, error) {
+
+	consentID, err := uuid.Parse(id)
+	if err != nil {
+
+		return nil, utils.NewBadRequest(utils.ErrInvalidID)
+	}
+
+	reason = strings.TrimSpace(reason)
+	if len(reason) > constants.MaxConsentReasonSize {
+
+		return nil, utils.NewBadRequest(utils.ErrConsentReasonTooLong)
+	}
+
+	consent, err := userService.consents.GetConsent(context, consentID)
+	if err != nil {
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+
+			return nil, utils.NewNotFound(utils.ErrConsentNotFound)
+		}
+
+		return nil, err
+	}
+
+	if consent.Status == constants.ConsentStatusRevoked {
+
+		return nil, utils.NewConflict(utils.ErrConsentAlreadyRevoked)
+	}
+
+	user, err := userService.users.GetUserByID(context, consent.UserID)
+	if err != nil {
+
+		return nil, err
+	}
+
+	now := userService.verification.Now().UTC()
+	before, from := *consent, consent.Status
+	consent.Status = constants.ConsentStatusRevoked
+	consent.RevokedAt = &now
+	consent.RevokedBy = utils.RequestMetaFromContext(context).ActorID
+	consent.RevocationReason = reason
+
+	err = userService.db.WithContext(context).Transaction(func(gormDB *gorm.DB) error {
+
+		revoked, err := userService.consents.TransitionConsentTx(gormDB, consent, from, "status", "revoked_at", "revoked_by", "revocation_reason")
+		if err != nil {
+
+			return err
+		}
+
+		if !revoked {
+
+			return utils.NewConflict(utils.ErrConsentAlreadyRevoked)
+		}
+
+		if err := userService.audit.RecordTx(context, gormDB, constants.AuditActionUpdate, constants.AuditTargetConsent, consent.ID.String(), &before, consent); err != nil {
+
+			return err
+		}
+
+		if from != constants.ConsentStatusGranted {
+
+			return nil
+		}
+
+		return userService.releaseMinorTx(context, gormDB, user, now)
+	})
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	return consent, nil
+}
+
+// ---------------- List Consents ----------------
+
+// ListConsents Returns Consents, Optionally For One User And / Or In One Status :
+func (userService *UserService) ListConsents(context context.Context, userID, status string) ([]*models.GuardianConsent, error) {
+
+	var filter models.ConsentFilter
+	if userID != "" {
+
+		uid, err := uuid.Parse(userID)
+		if err != nil {
+
+			return nil, utils.NewBadRequest(utils.ErrInvalidID)
+		}
+
+		filter.UserID = &uid
+	}
+
+	switch status {
+	case "", constants.ConsentStatusPending, constants.ConsentStatusGranted, constants.ConsentStatusRevoked:
+		filter.Status = status
+
+	default:
+		return nil, utils.NewBadRequest(utils.ErrInvalidConsentStatus)
+	}
+
+	consents, err := userService.consents.ListConsents(context, filter)
+	if err != nil {
+
+		return nil, err
+	}
+
+	if consents == nil {
+
+		consents = []*models.GuardianConsent{}
+	}
+
+	return consents, nil
+}
+
+// ---------------- Helper ----------------
+
+// createPendingMinorTx Persists A Minor Without A Group, Plus The Pending Consent Whose Token Is Emailed After Commit :
+func (userService *UserService) createPendingMinorTx(context context.Context, gormDB *gorm.DB, input newUserInput) (*models.User, *models.GuardianConsent, string, error) {
+
+	user := &models.User{
+
+		Name:           input.name,
+		Email:          input.email.raw,
+		EmailCanonical: input.email.canonical,
+		DateOfBirth:    input.birth,
+		Status:         constants.UserStatusPendingConsent,
+	}
+
+	if err := userService.users.CreateNewUserTx(gormDB, user); err != nil {
+
+		return nil, nil, "", err
+	}
+
+	token, err := newConsentToken()
+	if err != nil {
+
+		return nil, nil, "", err
+	}
+
+	now := userService.verification.Now().UTC()
+	consent := &models.GuardianConsent{
+
+		UserID:        user.ID,
+		GuardianName:  input.guardian.Name,
+		GuardianEmail: input.guardian.Email,
+		Status:        constants.ConsentStatusPending,
+		Method:        constants.ConsentMethodEmailLink,
+		TokenHash:     hashConsentToken(token),
+		ExpiresAt:     now.Add(userService.verification.ConsentTTL),
+		RequestedAt:   now,
+	}
+
+	if err := userService.consents.CreateConsentTx(gormDB, consent); err != nil {
+
+		return nil, nil, "", err
+	}
+
+	if err := userService.audit.RecordTx(context, gormDB, constants.AuditActionCreate, constants.AuditTargetUser, user.ID.String(), nil, user); err != nil {
+
+		return nil, nil, "", err
+	}
+
+	if err := userService.audit.RecordTx(context, gormDB, constants.AuditActionCreate, constants.AuditTargetConsent, consent.ID.String(), nil, consent); err != nil {
+
+		return nil, nil, "", err
+	}
+
+	if err := userService.events.EmitTx(context, gormDB, constants.EventUserCreated, eventModels.UserEventPayload{User: *user}); err != nil {
+
+		return nil, nil, "", err
+	}
+
+	return user, consent, token, nil
+}
+
+// releaseMinorTx Frees The Minor's Seat; An Active Minor Returns To pending_consent :
+func (userService *UserService) releaseMinorTx(context context.Context, gormDB *gorm.DB, user *models.User, now time.Time) error {
+
+	if user.Group == "" {
+
+		return nil
+	}
+
+	before := *user
+	if user.Status == constants.UserStatusActive {
+
+		user.Status = constants.UserStatusPendingConsent
+		if err := userService.users.UpdateUserTx(gormDB, user, "status"); err != nil {
+
+			return err
+		}
+	}
+
+	return userService.releaseSeatAndRecordTx(context, gormDB, &before, user, now)
+}
+
+// sendConsentRequest Emails The Consent Link To The Guardian; Failures Are Logged Only :
+func (userService *UserService) sendConsentRequest(context context.Context, user *models.User, consent *models.GuardianConsent, token string) {
+
+	if userService.verification.Mailer == nil {
+
+		return
+	}
+
+	link := strings.TrimRight(userService.verification.BaseURL, "/") + constants.ConsentGrantPath + "?token=" + url.QueryEscape(token) + "&" + constants.QueryTenantID + "=" + url.QueryEscape(user.TenantID)
+
+	// The Guardian Gets The Language Of Whoever Registered The Minor :
+	language := utils.RequestMetaFromContext(context).Language
+	message := mail.Message{
+		To:      consent.GuardianEmail,
+		Subject: i18n.Text(language, constants.MessageConsentEmailSubject),
+		Body: i18n.Text(language, constants.MessageConsentEmailBody,
+			"guardian", consent.GuardianName, "name", user.Name, "email", user.Email, "link", link, "ttl", userService.verification.ConsentTTL.String()),
+	}
+
+	if err := userService.verification.Mailer.Send(context, message); err != nil {
+
+		utils.Error(fmt.Sprintf("consent email for user %s: %v", user.ID, err))
+	}
+}
+
+func newConsentToken() (string, error) {
+
+	random := make([]byte, constants.ConsentTokenBytes)
+	if _, err := rand.Read(random); err != nil {
+
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(random), nil
+}
+
+func hashConsentToken(token string) string {
+
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
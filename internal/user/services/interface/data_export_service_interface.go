@@ -0,0 +1,18 @@
+package serviceInterface
+
+import (
+	"context"
+	"io"
+
+	"backend-task/internal/user/models"
+)
+
+// Data Export Service Gathers Everything Stored About A User ( GDPR Data Portability ) :
+type DataExportService interface {
+
+	// BuildBundle Collects The User, Their Group, Group History And Audit Entries.
+	BuildBundle(context context.Context, id string) (*models.UserDataBundle, error)
+
+	// WriteArchive Writes The Bundle As A Signed Zip Archive.
+	WriteArchive(bundle *models.UserDataBundle, writer io.Writer) error
+}
@@ -1,19 +1,74 @@
 package serviceInterface
 
-import "backend-task/internal/user/models"
+import (
+	"context"
+	"io"
+
+	"backend-task/internal/user/models"
+
+	"gorm.io/gorm"
+)
 
 // User Service Defines All Operations The Service Must Provide :
 type UserService interface {
 
 	// CreateUser Creates A User And Assigns Them To A Group Automatically.
-	CreateUser(name, email, dob string) (*models.User, error)
+	CreateUser(context context.Context, name, email, dob string) (*models.User, error)
+
+	// CreateUserWithGuardian Creates A User; Minors Need A Guardian And Stay Unallocated Until Consent Is Given.
+	CreateUserWithGuardian(context context.Context, name, email, dob string, guardian *models.GuardianReq) (*models.User, error)
+
+	// CreateUserWithGuardianTx Creates A User Like CreateUserWithGuardian And Runs withinTx In The Same Transaction.
+	CreateUserWithGuardianTx(context context.Context, name, email, dob string, guardian *models.GuardianReq, withinTx func(gormDB *gorm.DB, user *models.User) error) (*models.User, error)
+
+	// CreateUsers Creates A Batch All Or Nothing, Reporting The Problems Of Every Element At Once.
+	CreateUsers(context context.Context, reqs []models.CreateUserReq) ([]*models.User, error)
+
+	// NotifyEmailTaken Tells The Owner Of An Address That Someone Tried To Register It Again.
+	NotifyEmailTaken(context context.Context, email string)
 
 	// GetUserByID Retrieves A User By UUID.
-	GetUserByID(id string) (*models.User, error)
+	GetUserByID(context context.Context, id string) (*models.User, error)
 
 	// UpdateUser Updates The Name And/Or Email Of A User.
-	UpdateUser(id string, name, email *string) (*models.User, error)
+	UpdateUser(context context.Context, id string, name, email *string) (*models.User, error)
+
+	// VerifyEmail Confirms A User's Email Address With The Token From The Verification Email.
+	VerifyEmail(context context.Context, token string) (*models.User, error)
+
+	// GrantConsent Records Guardian Consent From The Emailed Token And Allocates The Minor.
+	GrantConsent(context context.Context, token string) (*models.User, error)
+
+	// RevokeConsent Withdraws A Consent, Releasing The Minor's Group Seat.
+	RevokeConsent(context context.Context, id, reason string) (*models.GuardianConsent, error)
+
+	// ListConsents Lists Consents, Optionally Filtered By User And Status.
+	ListConsents(context context.Context, userID, status string) ([]*models.GuardianConsent, error)
+
+	// ChangeUserStatus Suspends, Deactivates Or Activates A User, Recording The Reason And The Actor.
+	ChangeUserStatus(context context.Context, id, status string, req models.ChangeStatusReq) (*models.User, error)
+
+	// ListUsersByFilter Lists Users Optionally Filtered By Group ( Suspended And Deactivated Users Excluded ).
+	ListUsersByFilter(context context.Context, group string) ([]*models.User, error)
+
+	// ListUsersByStatus Lists Users Optionally Filtered By Group And Status.
+	ListUsersByStatus(context context.Context, group, status string) ([]*models.User, error)
+
+	// SearchUsers Finds Users By Partial Or Misspelled Name And Email, Ranked And Paginated.
+	SearchUsers(context context.Context, filter models.UserSearchFilter) (*models.UserPage, error)
+
+	// FindDuplicates Lists Pairs Of Users That Look Like The Same Person, Scored And Paginated.
+	FindDuplicates(context context.Context, filter models.DuplicateFilter) (*models.DuplicatePage, error)
+
+	// MergeUsers Folds A Duplicate User Into The Survivor And Deletes The Duplicate.
+	MergeUsers(context context.Context, req models.MergeUsersReq) (*models.User, error)
+
+	// ImportUsers Validates ( And Unless Dry Run, Creates ) Users Read From An Import File.
+	ImportUsers(context context.Context, rows []models.ImportRow, options models.ImportOptions) (*models.ImportReport, error)
+
+	// ExportUsers Streams Users As CSV Or NDJSON, Optionally Filtered By Group.
+	ExportUsers(context context.Context, writer io.Writer, options models.ExportOptions) error
 
-	// ListUsersByFilter Lists Users Optionally Filtered By Group.
-	ListUsersByFilter(group string) ([]*models.User, error)
+	// ExportGroups Streams Groups As CSV Or NDJSON, Optionally Filtered By Base.
+	ExportGroups(context context.Context, writer io.Writer, options models.ExportOptions) error
 }
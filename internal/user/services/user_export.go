@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"backend-task/internal/constants"
+	"backend-task/internal/user/models"
+	"backend-task/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+// Export Content Types By Format :
+var exportContentTypes = map[string]string{
+	constants.ExportFormatCSV:    constants.ContentTypeCSV,
+	constants.ExportFormatNDJSON: constants.ContentTypeNDJSON,
+}
+
+// ExportContentType Returns The Content Type Of An Export Format, Or false When It Is Unsupported :
+func ExportContentType(format string) (string, bool) {
+
+	contentType, ok := exportContentTypes[format]
+	return contentType, ok
+}
+
+// ---------------- Export Users ----------------
+
+// ExportUsers Streams Every Listed User Matching The Group And Status Filters As CSV Or NDJSON, Fetching One Batch
+// At A Time; Without A Status Suspended And Deactivated Users Are Left Out As In ListUsersByStatus :
+func (userService *UserService) ExportUsers(context context.Context, writer io.Writer, options models.ExportOptions) error {
+
+	statuses, err := ListedStatuses(options.Status)
+	if err != nil {
+
+		return err
+	}
+
+	encoder, err := newExportEncoder(writer, options.Format, []string{"id", "name", "email", "date_of_birth", "group", "created_at", "updated_at"})
+	if err != nil {
+
+		return err
+	}
+
+	afterID := uuid.Nil
+	for {
+
+		users, err := userService.users.ListUsersAfter(context, options.Group, statuses, afterID, exportBatchSize(options))
+		if err != nil {
+
+			return err
+		}
+
+		for _, user := range users {
+
+			record := []string{user.ID.String(), user.Name, user.Email, user.DateOfBirth.Format("2006-01-02"), user.Group, user.CreatedAt.UTC().Format(time.RFC3339), user.UpdatedAt.UTC().Format(time.RFC3339)}
+			if err := encoder.encode(user, record); err != nil {
+
+				return err
+			}
+		}
+
+		if err := encoder.flush(); err != nil {
+
+			return err
+		}
+
+		if len(users) < exportBatchSize(options) {
+
+			return nil
+		}
+
+		afterID = users[len(users)-1].ID
+	}
+}
+
+// ---------------- Export Groups ----------------
+
+// ExportGroups Streams Every Group Matching The Base Filter As CSV Or NDJSON :
+func (userService *UserService) ExportGroups(context context.Context, writer io.Writer, options models.ExportOptions) error {
+
+	encoder, err := newExportEncoder(writer, options.Format, []string{"name", "base", "index", "capacity", "member_count", "created_at", "updated_at"})
+	if err != nil {
+
+		return err
+	}
+
+	var after *models.Group
+	for {
+
+		groups, err := userService.groups.ListGroupsAfter(context, options.Base, after, exportBatchSize(options))
+		if err != nil {
+
+			return err
+		}
+
+		for _, group := range groups {
+
+			record := []string{group.Name, group.Base, strconv.Itoa(group.Index), strconv.Itoa(group.Capacity), strconv.Itoa(group.MemberCount), group.CreatedAt.UTC().Format(time.RFC3339), group.UpdatedAt.UTC().Format(time.RFC3339)}
+			if err := encoder.encode(group, record); err != nil {
+
+				return err
+			}
+		}
+
+		if err := encoder.flush(); err != nil {
+
+			return err
+		}
+
+		if len(groups) < exportBatchSize(options) {
+
+			return nil
+		}
+
+		after = groups[len(groups)-1]
+	}
+}
+
+// ---------------- Encoder ----------------
+
+// exportEncoder Writes One Record Per Row : A CSV Line Or A JSON Document Per Line :
+type exportEncoder struct {
+	writer     io.Writer
+	csvWriter  *csv.Writer
+	jsonWriter *json.Encoder
+}
+
+func newExportEncoder(writer io.Writer, format string, header []string) (*exportEncoder, error) {
+
+	switch format {
+	case constants.ExportFormatCSV:
+		csvWriter := csv.NewWriter(writer)
+		if err := csvWriter.Write(header); err != nil {
+
+			return nil, err
+		}
+
+		return &exportEncoder{writer: writer, csvWriter: csvWriter}, nil
+
+	case constants.ExportFormatNDJSON:
+		return &exportEncoder{writer: writer, jsonWriter: json.NewEncoder(writer)}, nil
+
+	default:
+		return nil, utils.NewBadRequest(utils.ErrUnsupportedExportFormat)
+	}
+}
+
+func (encoder *exportEncoder) encode(value any, record []string) error {
+
+	if encoder.csvWriter != nil {
+
+		return encoder.csvWriter.Write(record)
+	}
+
+	return encoder.jsonWriter.Encode(value)
+}
+
+// flush Pushes The Current Batch Downstream, So Clients Receive Rows While The Export Runs :
+func (encoder *exportEncoder) flush() error {
+
+	if encoder.csvWriter != nil {
+
+		encoder.csvWriter.Flush()
+		if err := encoder.csvWriter.Error(); err != nil {
+
+			return err
+		}
+	}
+
+	if flusher, ok := encoder.writer.(interface{ Flush() error }); ok {
+
+		return flusher.Flush()
+	}
+
+	return nil
+}
+
+func exportBatchSize(options models.ExportOptions) int {
+
+	if options.BatchSize > 0 {
+
+		return options.BatchSize
+	}
+
+	return constants.DefaultExportBatchSize
+}
@@ -0,0 +1,367 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"backend-task/internal/constants"
+	"backend-task/internal/user/models"
+	"backend-task/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+// ---------------- Import Users ----------------
+
+// ImportUsers Validates Every Row With The CreateUser Rules, Detects Duplicate Emails Within The File
+// And Against The Database, Then ( Unless Dry Run ) Creates The Valid Rows In Batched Transactions.
+// Invalid Rows Never Block Valid Ones; A Failing Batch Is Rolled Back And Retried Row By Row,
+// So Only The Rows That Still Fail On Their Own Are Reported As "failed".
+func (userService *UserService) ImportUsers(context context.Context, rows []models.ImportRow, options models.ImportOptions) (*models.ImportReport, error) {
+
+	report := &models.ImportReport{DryRun: options.DryRun, Total: len(rows), Rows: make([]models.ImportRowResult, len(rows))}
+	inputs := make([]newUserInput, len(rows))
+	firstRowByEmail := make(map[string]int, len(rows))
+	emails := make([]string, 0, len(rows))
+	bands := userService.settings(context).AgeBands
+
+	for index, row := range rows {
+
+		result := &report.Rows[index]
+		*result = models.ImportRowResult{Row: row.Row, Name: row.Name, Email: row.Email, DateOfBirth: row.DateOfBirth, Status: constants.ImportRowValid}
+
+		input, err := validateNewUser(bands, userService.emails, row.Name, row.Email, row.DateOfBirth, row.Guardian())
+		if err != nil {
+
+			// One Message Per Failed Field :
+			var validationErr utils.ValidationError
+			if !errors.As(err, &validationErr) {
+
+				markImportRowInvalid(result, err.Error())
+				continue
+			}
+
+			for _, field := range validationErr.Fields {
+
+				markImportRowInvalid(result, field.Message)
+			}
+
+			continue
+		}
+
+		if firstRow, duplicate := firstRowByEmail[input.email.canonical]; duplicate {
+
+			markImportRowInvalid(result, DuplicateEmailMessage(firstRow))
+			continue
+		}
+
+		firstRowByEmail[input.email.canonical] = row.Row
+		inputs[index] = input
+		emails = append(emails, input.email.canonical)
+	}
+
+	// Duplicates Against The Database :
+	existing, err := userService.users.FindExistingEmails(context, emails)
+	if err != nil {
+
+		return nil, err
+	}
+
+	taken := make(map[string]bool, len(existing))
+	for _, email := range existing {
+
+		taken[email] = true
+	}
+
+	var pending []int
+	for index := range report.Rows {
+
+		result := &report.Rows[index]
+		if result.Status == constants.ImportRowValid && taken[inputs[index].email.canonical] {
+
+			markImportRowInvalid(result, utils.ErrEmailAlreadyExists.Error())
+		}
+
+		if result.Status == constants.ImportRowValid {
+
+			report.Valid++
+			pending = append(pending, index)
+			continue
+		}
+
+		report.Invalid++
+	}
+
+	if options.DryRun {
+
+		return report, nil
+	}
+
+	batchSize := options.BatchSize
+	if batchSize <= 0 {
+
+		batchSize = constants.DefaultImportBatchSize
+	}
+
+	// Commit Valid Rows, One Transaction Per Batch :
+	for start := 0; start < len(pending); start += batchSize {
+
+		if err := context.Err(); err != nil {
+
+			return report, err
+		}
+
+		batch := pending[start:min(start+batchSize, len(pending))]
+		err := userService.importBatch(context, report, inputs, batch, options)
+		if err == nil {
+
+			continue
+		}
+
+		if len(batch) == 1 {
+
+			markImportRowFailed(&report.Rows[batch[0]], err)
+			report.Failed++
+			continue
+		}
+
+		// Retry Row By Row, So One Bad Row ( e.g., An Email Taken Meanwhile ) Does Not Fail Its Neighbours :
+		for _, index := range batch {
+
+			if err := context.Err(); err != nil {
+
+				return report, err
+			}
+
+			if err := userService.importBatch(context, report, inputs, []int{index}, options); err != nil {
+
+				markImportRowFailed(&report.Rows[index], err)
+				report.Failed++
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// importBatch Creates The Given Rows In One Transaction. Their Results Only Change Once It Committed,
+// So On Error The Rows Are Left As They Were For The Caller To Retry Or Report :
+func (userService *UserService) importBatch(context context.Context, report *models.ImportReport, inputs []newUserInput, batch []int, options models.ImportOptions) error {
+
+	results := make([]models.ImportRowResult, len(batch))
+	created := make([]*models.User, 0, len(batch))
+	var consents []pendingConsent
+	err := userService.db.WithContext(context).Transaction(func(gormDB *gorm.DB) error {
+
+		for position, index := range batch {
+
+			// Minors Wait For Their Guardian Like Individually Created Ones :
+			var user *models.User
+			var consent *models.GuardianConsent
+			var token string
+			var err error
+			if inputs[index].guardian != nil {
+
+				user, consent, token, err = userService.createPendingMinorTx(context, gormDB, inputs[index])
+			} else {
+
+				user, err = userService.createUserTx(context, gormDB, inputs[index])
+			}
+
+			if err != nil {
+
+				return err
+			}
+
+			if consent != nil {
+
+				consents = append(consents, pendingConsent{user: user, consent: consent, token: token})
+			}
+
+			created = append(created, user)
+			results[position] = report.Rows[index]
+			results[position].Status = constants.ImportRowCreated
+			results[position].UserID = &user.ID
+			results[position].Group = user.Group
+		}
+
+		if options.OnBatchTx != nil {
+
+			return options.OnBatchTx(gormDB, results)
+		}
+
+		return nil
+	})
+	if err != nil {
+
+		return err
+	}
+
+	for position, index := range batch {
+
+		report.Rows[index] = results[position]
+	}
+	report.Created += len(batch)
+
+	for _, user := range created {
+
+		userService.sendVerification(context, user)
+	}
+
+	for _, pending := range consents {
+
+		userService.sendConsentRequest(context, pending.user, pending.consent, pending.token)
+	}
+
+	return nil
+}
+
+// ---------------- CSV Parsing ----------------
+
+// ParseImportCSV Reads A CSV File With A Header Row Naming The name, email And date_of_birth Columns
+// ( In Any Order, Extra Columns Ignored; guardian_name And guardian_email Are Optional )
+// And Returns Its Data Rows With Their Line Numbers :
+func ParseImportCSV(reader io.Reader) ([]models.ImportRow, error) {
+
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1 // Short Rows Are Reported Per Row By Validation.
+	csvReader.TrimLeadingSpace = true
+
+	header, err := csvReader.Read()
+	if err != nil {
+
+		if errors.Is(err, io.EOF) {
+
+			return nil, utils.NewBadRequest(utils.ErrImportNoRows)
+		}
+
+		return nil, utils.NewBadRequest(fmt.Errorf("%w: %v", utils.ErrMalformedCSV, err))
+	}
+
+	columns := make(map[string]int, len(header))
+	for index, name := range header {
+
+		// Spreadsheet Exports Often Start With A UTF-8 Byte Order Mark :
+		name = strings.TrimPrefix(name, "\ufeff")
+		columns[strings.ToLower(strings.TrimSpace(name))] = index
+	}
+
+	for _, required := range []string{constants.ImportColumnName, constants.ImportColumnEmail, constants.ImportColumnDateOfBirth} {
+
+		if _, ok := columns[required]; !ok {
+
+			return nil, utils.NewBadRequest(utils.ErrImportMissingColumns)
+		}
+	}
+
+	var rows []models.ImportRow
+	for {
+
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+
+			break
+		}
+
+		if err != nil {
+
+			return nil, utils.NewBadRequest(fmt.Errorf("%w: %v", utils.ErrMalformedCSV, err))
+		}
+
+		if len(rows) == constants.ImportMaxRows {
+
+			return nil, utils.NewBadRequest(utils.ErrImportTooManyRows)
+		}
+
+		line, _ := csvReader.FieldPos(0)
+		rows = append(rows, models.ImportRow{
+
+			Row:         line,
+			Name:        csvField(record, columns[constants.ImportColumnName]),
+			Email:       csvField(record, columns[constants.ImportColumnEmail]),
+			DateOfBirth: csvField(record, columns[constants.ImportColumnDateOfBirth]),
+
+			GuardianName:  optionalCSVField(record, columns, constants.ImportColumnGuardianName),
+			GuardianEmail: optionalCSVField(record, columns, constants.ImportColumnGuardianEmail),
+		})
+	}
+
+	if len(rows) == 0 {
+
+		return nil, utils.NewBadRequest(utils.ErrImportNoRows)
+	}
+
+	return rows, nil
+}
+
+// ---------------- Helper ----------------
+
+// pendingConsent Is A Consent Request Emailed Once Its Batch Committed :
+type pendingConsent struct {
+	user    *models.User
+	consent *models.GuardianConsent
+	token   string
+}
+
+// DuplicateEmailMessage Describes A Row Repeating The Email Of An Earlier Row :
+func DuplicateEmailMessage(firstRow int) string {
+
+	return fmt.Sprintf("%s ( first seen on row %d )", utils.ErrDuplicateEmailInFile, firstRow)
+}
+
+func markImportRowInvalid(result *models.ImportRowResult, message string) {
+
+	result.Status = constants.ImportRowInvalid
+	result.Errors = append(result.Errors, message)
+}
+
+func markImportRowFailed(result *models.ImportRowResult, err error) {
+
+	result.Status = constants.ImportRowFailed
+	result.Errors = []string{importFailureMessage(err)}
+}
+
+// importFailureMessage Exposes Client Errors As-Is But Hides Internal Causes Behind A Generic Message :
+func importFailureMessage(err error) string {
+
+	var apiErr utils.APIError
+	if errors.As(err, &apiErr) {
+
+		return apiErr.Error()
+	}
+
+	// Client Errors Such As A Row Losing An Email Race Are Safe To Report :
+	if definition, registered := utils.LookupError(err); registered && definition.Status < constants.StatusInternalServerError {
+
+		return definition.Err.Error()
+	}
+
+	utils.Error(fmt.Sprintf("import batch failed: %v", err))
+	return utils.ErrInternalError.Error()
+}
+
+func csvField(record []string, index int) string {
+
+	if index >= len(record) {
+
+		return ""
+	}
+
+	return strings.TrimSpace(record[index])
+}
+
+// optionalCSVField Returns "" When The Header Has No Such Column :
+func optionalCSVField(record []string, columns map[string]int, column string) string {
+
+	index, ok := columns[column]
+	if !ok {
+
+		return ""
+	}
+
+	return csvField(record, index)
+}
@@ -0,0 +1,342 @@
+package service
+
+import (
+	"context"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"backend-task/internal/constants"
+	eventModels "backend-task/internal/events/models"
+	"backend-task/internal/user/models"
+	"backend-task/internal/utils"
+
+	"github.com/google/uuid"
+	"golang.org/x/text/unicode/norm"
+	"gorm.io/gorm"
+)
+
+// ---------------- Find Duplicates ----------------
+
+// FindDuplicates Scores Pairs Of Users That May Be The Same Person, Most Likely First.
+// Only Pairs Sharing A Date Of Birth Or A Canonical Email Local Part Are Compared, So Name Alone Never Makes A Candidate :
+func (userService *UserService) FindDuplicates(context context.Context, filter models.DuplicateFilter) (*models.DuplicatePage, error) {
+
+	if !(filter.MinScore >= 0 && filter.MinScore <= 1) {
+
+		return nil, utils.NewBadRequest(utils.ErrInvalidMinScore)
+	}
+
+	if filter.Page < 1 {
+
+		filter.Page = constants.DefaultPage
+	}
+
+	if filter.PageSize < 1 || filter.PageSize > constants.MaxPageSize {
+
+		filter.PageSize = constants.DefaultPageSize
+	}
+
+	people, err := userService.duplicateProfiles(context, filter.Group)
+	if err != nil {
+
+		return nil, err
+	}
+
+	// Block On Shared Birth Dates And Local Parts; A Pair In Both Blocks Is Scored Once :
+	blocks := map[string][]int{}
+	for index, person := range people {
+
+		blocks["dob:"+person.birth] = append(blocks["dob:"+person.birth], index)
+		if person.local != "" {
+
+			blocks["local:"+person.local] = append(blocks["local:"+person.local], index)
+		}
+	}
+
+	seen := map[[2]int]bool{}
+	candidates := []*models.DuplicateCandidate{}
+	for _, block := range blocks {
+
+		for first := 0; first < len(block); first++ {
+
+			for second := first + 1; second < len(block); second++ {
+
+				pair := [2]int{block[first], block[second]}
+				if seen[pair] {
+
+					continue
+				}
+				seen[pair] = true
+
+				if candidate := scoreDuplicate(people[pair[0]], people[pair[1]]); candidate.Score >= filter.MinScore {
+
+					candidates = append(candidates, candidate)
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+
+		if candidates[i].Score != candidates[j].Score {
+
+			return candidates[i].Score > candidates[j].Score
+		}
+
+		return olderFirst(candidates[i].Users[0], candidates[j].Users[0])
+	})
+
+	page := &models.DuplicatePage{Items: []*models.DuplicateCandidate{}, Total: int64(len(candidates)), Page: filter.Page, PageSize: filter.PageSize}
+	if start := (filter.Page - 1) * filter.PageSize; start < len(candidates) {
+
+		page.Items = candidates[start:min(start+filter.PageSize, len(candidates))]
+	}
+
+	return page, nil
+}
+
+// duplicateProfile Is A User Reduced To The Fields Compared For Duplicates :
+type duplicateProfile struct {
+	user  *models.User
+	name  string // Normalized, See normalizeName.
+	birth string // YYYY-MM-DD.
+	local string // Local Part Of The Canonical Email.
+}
+
+// duplicateProfiles Walks The Tenant's Listed Users ( Optionally One Group ) In Pages, Oldest Record First :
+func (userService *UserService) duplicateProfiles(context context.Context, group string) ([]duplicateProfile, error) {
+
+	var people []duplicateProfile
+	afterID := uuid.Nil
+	for {
+
+		users, err := userService.users.ListUsersAfter(context, group, constants.ListedUserStatuses, afterID, constants.DefaultExportBatchSize)
+		if err != nil {
+
+			return nil, err
+		}
+
+		for _, user := range users {
+
+			people = append(people, duplicateProfile{user: user, name: normalizeName(user.Name), birth: user.DateOfBirth.Format("2006-01-02"), local: localPart(user.EmailCanonical)})
+		}
+
+		if len(users) < constants.DefaultExportBatchSize {
+
+			break
+		}
+
+		afterID = users[len(users)-1].ID
+	}
+
+	slices.SortStableFunc(people, func(a, b duplicateProfile) int {
+
+		if olderFirst(a.user, b.user) {
+
+			return -1
+		}
+
+		if olderFirst(b.user, a.user) {
+
+			return 1
+		}
+
+		return 0
+	})
+
+	return people, nil
+}
+
+// scoreDuplicate Weighs Name Similarity, Date Of Birth And Email Local Part; a Is The Older Record :
+func scoreDuplicate(a, b duplicateProfile) *models.DuplicateCandidate {
+
+	candidate := &models.DuplicateCandidate{Reasons: []string{}, Users: []*models.User{a.user, b.user}}
+
+	similarity := nameSimilarity(a.name, b.name)
+	score := constants.DuplicateNameWeight * similarity
+	if similarity >= constants.DuplicateSimilarName {
+
+		candidate.Reasons = append(candidate.Reasons, constants.DuplicateReasonSimilarName)
+	}
+
+	if a.birth == b.birth {
+
+		score += constants.DuplicateBirthWeight
+		candidate.Reasons = append(candidate.Reasons, constants.DuplicateReasonSameBirth)
+	}
+
+	// Canonical Emails Are Unique, So An Equal Local Part Means The Same Mailbox Name At Another Provider :
+	if a.local != "" && a.local == b.local {
+
+		score += constants.DuplicateEmailWeight
+		candidate.Reasons = append(candidate.Reasons, constants.DuplicateReasonSameEmailLocal)
+	}
+
+	candidate.Score = math.Round(score*100) / 100
+	return candidate
+}
+
+// normalizeName Lower-Cases, Strips Accents And Punctuation And Sorts The Words,
+// So "Doe, Jöhn" And "john doe" Compare Equal :
+func normalizeName(name string) string {
+
+	var builder strings.Builder
+	for _, char := range norm.NFD.String(strings.ToLower(name)) {
+
+		switch {
+		case unicode.Is(unicode.Mn, char):
+			continue
+
+		case unicode.IsLetter(char) || unicode.IsDigit(char):
+			builder.WriteRune(char)
+
+		default:
+			builder.WriteRune(' ')
+		}
+	}
+
+	words := strings.Fields(builder.String())
+	slices.Sort(words)
+	return strings.Join(words, " ")
+}
+
+// nameSimilarity Is 1 Minus The Levenshtein Distance Over The Longer Name's Length ( 0..1 ) :
+func nameSimilarity(a, b string) float64 {
+
+	first, second := []rune(a), []rune(b)
+	longest := max(len(first), len(second))
+	if longest == 0 {
+
+		return 0
+	}
+
+	previous := make([]int, len(second)+1)
+	current := make([]int, len(second)+1)
+	for index := range previous {
+
+		previous[index] = index
+	}
+
+	for i := 1; i <= len(first); i++ {
+
+		current[0] = i
+		for j := 1; j <= len(second); j++ {
+
+			cost := 1
+			if first[i-1] == second[j-1] {
+
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return 1 - float64(previous[len(second)])/float64(longest)
+}
+
+// localPart Cuts A Canonical Email At Its Last "@" ( Quoted Local Parts May Contain One ) :
+func localPart(canonical string) string {
+
+	index := strings.LastIndex(canonical, "@")
+	if index < 0 {
+
+		return ""
+	}
+
+	return canonical[:index]
+}
+
+func olderFirst(a, b *models.User) bool {
+
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+
+	return a.ID.String() < b.ID.String()
+}
+
+// ---------------- Merge Users ----------------
+
+// MergeUsers Folds The Duplicate Into The Survivor: The Duplicate's Group Seat Is Released, Its Group History,
+// Guardian Consents And Audit Trail Move To The Survivor, And The Duplicate Record Is Deleted. The Survivor's
+// Own Fields Are Kept As They Are :
+func (userService *UserService) MergeUsers(context context.Context, req models.MergeUsersReq) (*models.User, error) {
+
+	survivor, err := userService.GetUserByID(context, req.SurvivorID)
+	if err != nil {
+
+		return nil, err
+	}
+
+	duplicate, err := userService.GetUserByID(context, req.DuplicateID)
+	if err != nil {
+
+		return nil, err
+	}
+
+	if survivor.ID == duplicate.ID {
+
+		return nil, utils.NewBadRequest(utils.ErrMergeSameUser)
+	}
+
+	now := userService.verification.Now().UTC()
+	before := *duplicate
+
+	err = userService.db.WithContext(context).Transaction(func(gormDB *gorm.DB) error {
+
+		if err := userService.releaseSeatTx(gormDB, duplicate, now); err != nil {
+
+			return err
+		}
+
+		if err := userService.groups.ReassignMembershipsTx(gormDB, duplicate.ID, survivor.ID); err != nil {
+
+			return err
+		}
+
+		if err := userService.consents.ReassignConsentsTx(gormDB, duplicate.ID, survivor.ID); err != nil {
+
+			return err
+		}
+
+		if err := userService.audit.ReassignTargetTx(context, gormDB, constants.AuditTargetUser, duplicate.ID.String(), survivor.ID.String()); err != nil {
+
+			return err
+		}
+
+		deleted, err := userService.users.DeleteUserTx(gormDB, duplicate)
+		if err != nil {
+
+			return err
+		}
+
+		// A Concurrent Merge Got There First :
+		if !deleted {
+
+			return utils.NewNotFound(utils.ErrUserNotFound)
+		}
+
+		// The Diff From The Duplicate's Last Snapshot To The Survivor Records What Was Folded In :
+		if err := userService.audit.RecordTx(context, gormDB, constants.AuditActionMerge, constants.AuditTargetUser, survivor.ID.String(), &before, survivor); err != nil {
+
+			return err
+		}
+
+		payload := eventModels.UserEventPayload{User: *survivor, GroupBase: groupBase(survivor.Group), MergedUserID: duplicate.ID.String()}
+		return userService.events.EmitTx(context, gormDB, constants.EventUserMerged, payload)
+	})
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	return survivor, nil
+}
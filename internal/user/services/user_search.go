@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"backend-task/internal/constants"
+	"backend-task/internal/user/models"
+	"backend-task/internal/utils"
+)
+
+// ---------------- Search Users ----------------
+
+// SearchUsers Finds Users By Partial Or Misspelled Name And Email, Best Match First. Group And Status
+// Filter As In ListUsersByStatus, So Suspended And Deactivated Users Are Only Found When Asked For :
+func (userService *UserService) SearchUsers(context context.Context, filter models.UserSearchFilter) (*models.UserPage, error) {
+
+	filter.Query = strings.TrimSpace(filter.Query)
+	if length := utf8.RuneCountInString(filter.Query); length < constants.MinSearchQueryLength || length > constants.MaxSearchQueryLength {
+
+		return nil, utils.NewBadRequest(utils.ErrInvalidSearchQuery)
+	}
+
+	statuses, err := ListedStatuses(filter.Status)
+	if err != nil {
+
+		return nil, err
+	}
+
+	if filter.Page < 1 {
+
+		filter.Page = constants.DefaultPage
+	}
+
+	if filter.PageSize < 1 || filter.PageSize > constants.MaxPageSize {
+
+		filter.PageSize = constants.DefaultPageSize
+	}
+
+	users, total, err := userService.users.SearchUsers(context, filter, statuses)
+	if err != nil {
+
+		return nil, err
+	}
+
+	if users == nil {
+
+		users = []*models.User{}
+	}
+
+	return &models.UserPage{Items: users, Total: total, Page: filter.Page, PageSize: filter.PageSize}, nil
+}
@@ -6,7 +6,11 @@ import (
 	"strings"
 	"time"
 
+	auditServiceInterface "backend-task/internal/audit/services/interface"
 	"backend-task/internal/constants"
+	eventModels "backend-task/internal/events/models"
+	eventServiceInterface "backend-task/internal/events/services/interface"
+	"backend-task/internal/tenant"
 	"backend-task/internal/user/models"
 	"backend-task/internal/user/repository"
 	userServiceInterface "backend-task/internal/user/services/interface"
@@ -17,88 +21,207 @@ import (
 )
 
 type UserService struct {
-	db     *gorm.DB
-	users  repository.UserRepository
-	groups repository.GroupRepository
+	db       *gorm.DB
+	users    repository.UserRepository
+	groups   repository.GroupRepository
+	consents repository.ConsentRepository
+	audit    auditServiceInterface.AuditService
+	events   eventServiceInterface.EventService
+
+	verification VerificationConfig
+	emails       EmailPolicy
+	tenants      *tenant.Registry // Per-Tenant Capacity And Age Bands ( nil = Defaults ).
+	sessions     SessionRevoker   // Signs Out Suspended And Deactivated Users ( nil = No Password Accounts ).
 }
 
-func NewUserService(db *gorm.DB, users repository.UserRepository, groups repository.GroupRepository) userServiceInterface.UserService {
+// SessionRevoker Ends Every Session Of A User Within A Transaction ( The Account Repository ) :
+type SessionRevoker interface {
+	RevokeUserSessionsTx(gormDB *gorm.DB, userID uuid.UUID, at time.Time) error
+}
+
+func NewUserService(db *gorm.DB, users repository.UserRepository, groups repository.GroupRepository, consents repository.ConsentRepository, audit auditServiceInterface.AuditService, events eventServiceInterface.EventService, verification VerificationConfig, emails EmailPolicy, tenants *tenant.Registry, sessions SessionRevoker) userServiceInterface.UserService {
 
-	return &UserService{db: db, users: users, groups: groups}
+	return &UserService{db: db, users: users, groups: groups, consents: consents, audit: audit, events: events, verification: withVerificationDefaults(verification), emails: emails, tenants: tenants, sessions: sessions}
 }
 
 // ---------------- Create User ----------------
 
-func (userService *UserService) CreateUser(name, email, dob string) (*models.User, error) {
+func (userService *UserService) CreateUser(context context.Context, name, email, dob string) (*models.User, error) {
 
-	name = strings.TrimSpace(name)
-	email = strings.ToLower(strings.TrimSpace(email))
+	return userService.CreateUserWithGuardian(context, name, email, dob, nil)
+}
 
-	if name == "" {
+// CreateUserWithGuardian Creates A User; Minors Need A Guardian And Stay Unallocated Until The Guardian Consents :
+func (userService *UserService) CreateUserWithGuardian(context context.Context, name, email, dob string, guardian *models.GuardianReq) (*models.User, error) {
 
-		return nil, utils.NewBadRequest(utils.ErrNameIsRequired)
-	}
+	return userService.CreateUserWithGuardianTx(context, name, email, dob, guardian, nil)
+}
+
+// CreateUserWithGuardianTx Also Runs withinTx ( e.g., Saving A Password ) In The Creating Transaction,
+// So Its Failure Leaves No User Behind :
+func (userService *UserService) CreateUserWithGuardianTx(context context.Context, name, email, dob string, guardian *models.GuardianReq, withinTx func(gormDB *gorm.DB, user *models.User) error) (*models.User, error) {
 
-	if !utils.ValidateEmail(email) {
+	input, err := validateNewUser(userService.settings(context).AgeBands, userService.emails, name, email, dob, guardian)
+	if err != nil {
 
-		return nil, utils.NewBadRequest(utils.ErrInvalidEmailFormat)
+		return nil, err
 	}
 
-	birth, err := time.Parse("2006-01-02", dob)
+	// Advisory Email Check For A Clear Early Error; Under Concurrent Creates
+	// The Unique Index Decides And The Insert Reports The Same Conflict :
+	exists, err := userService.users.IsEmailExists(context, input.email.canonical)
 	if err != nil {
 
-		return nil, utils.NewBadRequest(utils.ErrDateOfBirthFormat)
+		return nil, err
 	}
 
-	if err := utils.ValidateDateOfBirth(birth); err != nil {
+	if exists {
+
+		return nil, utils.NewConflict(utils.ErrEmailAlreadyExists)
+	}
+
+	var createdUser *models.User
+	var consent *models.GuardianConsent
+	var consentToken string
+
+	// Transaction For Safe Group Assignment,
+	// Wrap Everything ( Including The Audit Entry ) In A Transaction :
+	err = userService.db.WithContext(context).Transaction(func(gormDB *gorm.DB) error {
+
+		if input.guardian != nil {
+
+			createdUser, consent, consentToken, err = userService.createPendingMinorTx(context, gormDB, input)
+		} else {
+
+			createdUser, err = userService.createUserTx(context, gormDB, input)
+		}
+
+		if err != nil || withinTx == nil {
+
+			return err
+		}
+
+		return withinTx(gormDB, createdUser)
+	})
+
+	if err != nil {
 
 		return nil, err
 	}
 
-	// Ensure Email Uniqueness :
-	exists, err := userService.users.IsEmailExists(context.Background(), email)
+	userService.sendVerification(context, createdUser)
+	if consent != nil {
+
+		userService.sendConsentRequest(context, createdUser, consent, consentToken)
+	}
+
+	return createdUser, nil
+}
+
+// ---------------- Create Users ----------------
+
+// CreateUsers Creates A Batch All Or Nothing : Every Element Is Checked First And Their Problems Are Reported Together
+// ( Paths Prefixed By The Index, "[2].email" ); Only A Clean Batch Is Created, In One Transaction.
+// Taken Emails Alone Answer 409 Like A Single Create; Next To Other Problems They Are Listed As "unique" Fields.
+func (userService *UserService) CreateUsers(context context.Context, reqs []models.CreateUserReq) ([]*models.User, error) {
+
+	bands := userService.settings(context).AgeBands
+	emailTaken := utils.FieldErrors{{Field: "email", Rule: constants.RuleUnique, Message: utils.ErrEmailAlreadyExists.Error()}}.Err()
+	inputs := make([]newUserInput, len(reqs))
+	problems := make([]error, len(reqs))
+	seen := make(map[string]bool, len(reqs))
+	canonicals := make([]string, 0, len(reqs))
+	valid := true
+
+	for index, req := range reqs {
+
+		input, err := validateNewUser(bands, userService.emails, req.Name, req.Email, req.DateOfBirth, req.Guardian)
+		switch {
+
+		case err != nil:
+			problems[index], valid = err, false
+
+		case seen[input.email.canonical]:
+			// The Same Mailbox Twice In One Request :
+			problems[index], valid = emailTaken, false
+
+		default:
+			seen[input.email.canonical] = true
+			inputs[index] = input
+			canonicals = append(canonicals, input.email.canonical)
+		}
+	}
+
+	// Advisory Like In CreateUserWithGuardianTx; The Unique Index Still Decides Inside The Transaction :
+	existing, err := userService.users.FindExistingEmails(context, canonicals)
 	if err != nil {
 
 		return nil, err
 	}
 
-	if exists {
+	if len(existing) > 0 && valid {
 
-		return nil, utils.NewBadRequest(utils.ErrEmailAlreadyExists)
+		return nil, utils.NewConflict(utils.ErrEmailAlreadyExists)
 	}
 
-	baseGroup := ageToBaseGroup(birth)
-	var createdUser *models.User
+	taken := make(map[string]bool, len(existing))
+	for _, canonical := range existing {
 
-	// Transaction For Safe Group Assignment,
-	// Wrap Everything In A Transaction :
-	err = userService.db.Transaction(func(gormDB *gorm.DB) error {
+		taken[canonical] = true
+	}
 
-		group, err := userService.groups.FindAllocatableGroupTx(gormDB, baseGroup)
-		if err != nil {
+	var fields utils.FieldErrors
+	for index, problem := range problems {
 
-			return err
+		if problem == nil && taken[inputs[index].email.canonical] {
+
+			problem = emailTaken
 		}
 
-		user := &models.User{
+		if problem == nil {
 
-			Name:        name,
-			Email:       email,
-			DateOfBirth: birth,
-			Group:       group.Name,
+			continue
 		}
 
-		if err := userService.users.CreateNewUser(context.Background(), user); err != nil {
+		if err := fields.AddIndexed(problem, index); err != nil {
 
-			return err
+			return nil, err
 		}
+	}
 
-		if err := userService.groups.IncrementGroupCountTx(gormDB, group.Name); err != nil {
+	if err := fields.Err(); err != nil {
 
-			return err
+		return nil, err
+	}
+
+	created := make([]*models.User, len(inputs))
+	var consents []pendingConsent
+	err = userService.db.WithContext(context).Transaction(func(gormDB *gorm.DB) error {
+
+		for index, input := range inputs {
+
+			if input.guardian == nil {
+
+				user, err := userService.createUserTx(context, gormDB, input)
+				if err != nil {
+
+					return err
+				}
+
+				created[index] = user
+				continue
+			}
+
+			user, consent, token, err := userService.createPendingMinorTx(context, gormDB, input)
+			if err != nil {
+
+				return err
+			}
+
+			created[index] = user
+			consents = append(consents, pendingConsent{user: user, consent: consent, token: token})
 		}
 
-		createdUser = user
 		return nil
 	})
 
@@ -107,25 +230,36 @@ func (userService *UserService) CreateUser(name, email, dob string) (*models.Use
 		return nil, err
 	}
 
-	return createdUser, nil
+	// Emails Only Go Out Once The Whole Batch Is Stored :
+	for _, user := range created {
+
+		userService.sendVerification(context, user)
+	}
+
+	for _, pending := range consents {
+
+		userService.sendConsentRequest(context, pending.user, pending.consent, pending.token)
+	}
+
+	return created, nil
 }
 
 // ---------------- Get User ----------------
 
-func (userService *UserService) GetUserByID(id string) (*models.User, error) {
+func (userService *UserService) GetUserByID(context context.Context, id string) (*models.User, error) {
 
 	uid, err := uuid.Parse(id)
 	if err != nil {
 
-		return nil, utils.NewBadRequest(utils.ErrUserNotFound)
+		return nil, utils.NewBadRequest(utils.ErrInvalidID)
 	}
 
-	user, err := userService.users.GetUserByID(context.Background(), uid)
+	user, err := userService.users.GetUserByID(context, uid)
 	if err != nil {
 
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+		if errors.Is(err, utils.ErrUserNotFound) {
 
-			return nil, utils.NewBadRequest(utils.ErrUserNotFound)
+			return nil, utils.NewNotFound(utils.ErrUserNotFound)
 		}
 
 		return nil, err
@@ -136,62 +270,64 @@ func (userService *UserService) GetUserByID(id string) (*models.User, error) {
 
 // ---------------- Update User ----------------
 
-func (userService *UserService) UpdateUser(id string, name, email *string) (*models.User, error) {
+func (userService *UserService) UpdateUser(context context.Context, id string, name, email *string) (*models.User, error) {
 
-	uid, err := uuid.Parse(id)
+	user, err := userService.GetUserByID(context, id)
 	if err != nil {
 
-		return nil, utils.NewBadRequest(utils.ErrUserNotFound)
+		return nil, err
 	}
 
-	user, err := userService.users.GetUserByID(context.Background(), uid)
-	if err != nil {
+	var fields utils.FieldErrors
+	if name != nil && strings.TrimSpace(*name) == "" {
+
+		fields.Add("name", constants.RuleNotBlank, utils.ErrNameCannotBeEmpty)
+	}
 
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+	var newEmail emailInput
+	if email != nil {
 
-			return nil, utils.NewBadRequest(utils.ErrUserNotFound)
-		}
+		newEmail = userService.emails.check(&fields, "email", *email, utils.ErrInvalidEmailFormat)
+	}
+
+	if err := fields.Err(); err != nil {
 
 		return nil, err
 	}
 
-	changed := false
+	before := *user
+	changed, emailChanged := false, false
 	if name != nil {
 
-		newName := strings.TrimSpace(*name)
-		if newName == "" {
+		user.Name = strings.TrimSpace(*name)
+		changed = true
+	}
 
-			return nil, utils.NewBadRequest(utils.ErrNameCannotBeEmpty)
-		}
+	// Another Spelling Of The Same Mailbox ( Case, Gmail Dots ... ) Keeps Its Verification :
+	if email != nil && newEmail.raw != user.Email {
 
-		user.Name = newName
+		user.Email = newEmail.raw
 		changed = true
 	}
 
-	if email != nil {
+	if email != nil && newEmail.canonical != user.EmailCanonical {
 
-		newEmail := strings.ToLower(strings.TrimSpace(*email))
-		if !utils.ValidateEmail(newEmail) {
+		exists, err := userService.users.IsEmailExists(context, newEmail.canonical)
+		if err != nil {
 
-			return nil, utils.NewBadRequest(utils.ErrInvalidEmailFormat)
+			return nil, err
 		}
 
-		if newEmail != user.Email {
-
-			exists, err := userService.users.IsEmailExists(context.Background(), newEmail)
-			if err != nil {
-
-				return nil, err
-			}
-
-			if exists {
+		if exists {
 
-				return nil, utils.NewBadRequest(utils.ErrEmailAlreadyExists)
-			}
-
-			user.Email = newEmail
-			changed = true
+			return nil, utils.NewConflict(utils.ErrEmailAlreadyExists)
 		}
+
+		// A New Address Must Be Confirmed Again :
+		user.EmailCanonical = newEmail.canonical
+		user.EmailVerified = false
+		user.EmailVerifiedAt = nil
+		changed, emailChanged = true, true
 	}
 
 	if !changed {
@@ -199,40 +335,215 @@ func (userService *UserService) UpdateUser(id string, name, email *string) (*mod
 		return user, nil
 	}
 
-	if err := userService.users.UpdateUser(context.Background(), user, "name", "email"); err != nil {
+	// Persist The Change And Its Audit Entry Atomically :
+	err = userService.db.WithContext(context).Transaction(func(gormDB *gorm.DB) error {
+
+		if err := userService.users.UpdateUserTx(gormDB, user, "name", "email", "email_canonical", "email_verified", "email_verified_at"); err != nil {
+
+			return err
+		}
+
+		if err := userService.audit.RecordTx(context, gormDB, constants.AuditActionUpdate, constants.AuditTargetUser, user.ID.String(), &before, user); err != nil {
+
+			return err
+		}
+
+		return userService.events.EmitTx(context, gormDB, constants.EventUserUpdated, eventModels.UserEventPayload{User: *user, GroupBase: groupBase(user.Group)})
+	})
+
+	if err != nil {
 
 		return nil, err
 	}
 
+	if emailChanged {
+
+		userService.sendVerification(context, user)
+	}
+
 	return user, nil
 }
 
 // ---------------- List Users ----------------
 
-func (userService *UserService) ListUsersByFilter(group string) ([]*models.User, error) {
+func (userService *UserService) ListUsersByFilter(context context.Context, group string) ([]*models.User, error) {
 
-	return userService.users.ListUsers(context.Background(), group)
+	return userService.ListUsersByStatus(context, group, "")
 }
 
 // ---------------- Helper ----------------
 
-func ageToBaseGroup(birth time.Time) string {
+// NormalizeEmail Returns The Case-Insensitive Form Used Where No EmailPolicy Applies ( Login );
+// Uniqueness Itself Is Decided By The Canonical Form ( See EmailPolicy.Canonical ) :
+func NormalizeEmail(email string) string {
+
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// newUserInput Holds The Normalized Fields Of A User About To Be Created :
+type newUserInput struct {
+	name     string
+	email    emailInput
+	birth    time.Time
+	guardian *models.GuardianReq // Set For Minors Only.
+}
+
+// validateNewUser Applies The Creation Rules Shared By CreateUser And ImportUsers.
+// Minors Need A Guardian; A Guardian Given For An Adult Is Ignored.
+func validateNewUser(bands tenant.AgeBands, emails EmailPolicy, name, email, dob string, guardian *models.GuardianReq) (newUserInput, error) {
+
+	var fields utils.FieldErrors
+	input := newUserInput{name: strings.TrimSpace(name), email: emails.check(&fields, "email", email, utils.ErrInvalidEmailFormat)}
+
+	if input.name == "" {
 
-	age := utils.CalculateAge(birth)
+		fields.Add("name", "required", utils.ErrNameIsRequired)
+	}
+
+	birth, err := time.Parse("2006-01-02", dob)
 	switch {
-	case age >= 0 && age <= 12:
-		return constants.BaseGroupChild
 
-	case age >= 13 && age <= 17:
-		return constants.BaseGroupTeen
+	case err != nil:
+		fields.Add("date_of_birth", "datetime", utils.ErrDateOfBirthFormat)
+
+	case utils.ValidateDateOfBirth(birth) != nil:
+		fields.Add("date_of_birth", constants.RulePastDate, utils.ErrDateOfBirthCannotBeFuture)
+
+	case bands.IsMinor(birth):
+		// Only Minors Need A Guardian, So These Rules Wait For A Valid Date :
+		if guardian == nil || strings.TrimSpace(guardian.Name) == "" || strings.TrimSpace(guardian.Email) == "" {
+
+			fields.Add("guardian", "required", utils.ErrGuardianRequired)
+			break
+		}
+
+		// The Guardian Must Be Reachable At A Mailbox Other Than The Minor's :
+		guardianEmail := emails.check(&fields, "guardian.email", guardian.Email, utils.ErrInvalidGuardianEmail)
+		if guardianEmail.canonical != "" && guardianEmail.canonical == input.email.canonical {
+
+			fields.Add("guardian.email", "email", utils.ErrInvalidGuardianEmail)
+		}
+
+		input.guardian = &models.GuardianReq{Name: strings.TrimSpace(guardian.Name), Email: guardianEmail.raw}
+	}
+
+	if err := fields.Err(); err != nil {
+
+		return newUserInput{}, err
+	}
+
+	input.birth = birth
+	return input, nil
+}
+
+// createUserTx Allocates A Group Seat And Persists The User With Its Audit Entry And Events :
+func (userService *UserService) createUserTx(context context.Context, gormDB *gorm.DB, input newUserInput) (*models.User, error) {
+
+	group, groupCreated, err := userService.allocatableGroupTx(context, gormDB, input.birth)
+	if err != nil {
+
+		return nil, err
+	}
+
+	user := &models.User{
+
+		Name:           input.name,
+		Email:          input.email.raw,
+		EmailCanonical: input.email.canonical,
+		DateOfBirth:    input.birth,
+		Status:         constants.UserStatusActive,
+		Group:          group.Name,
+	}
+
+	if err := userService.users.CreateNewUserTx(gormDB, user); err != nil {
 
-	case age >= 18 && age <= 64:
-		return constants.BaseGroupAdult
+		return nil, err
+	}
 
-	case age >= 65:
-		return constants.BaseGroupSenior
+	if err := userService.takeSeatTx(gormDB, user, group, user.CreatedAt); err != nil {
 
-	default:
-		return constants.BaseGroupUnset
+		return nil, err
+	}
+
+	if err := userService.audit.RecordTx(context, gormDB, constants.AuditActionCreate, constants.AuditTargetUser, user.ID.String(), nil, user); err != nil {
+
+		return nil, err
 	}
+
+	if err := userService.emitAllocationEventsTx(context, gormDB, user, group, groupCreated, constants.EventUserCreated); err != nil {
+
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// takeSeatTx Counts The User In The Group And Opens Their Membership :
+func (userService *UserService) takeSeatTx(gormDB *gorm.DB, user *models.User, group *models.Group, joinedAt time.Time) error {
+
+	if err := userService.groups.IncrementGroupCountTx(gormDB, group.Name); err != nil {
+
+		return err
+	}
+
+	membership := &models.GroupMembership{UserID: user.ID, Group: group.Name, Base: group.Base, Reason: constants.MembershipReasonAllocated, JoinedAt: joinedAt}
+	return userService.groups.RecordMembershipTx(gormDB, membership)
+}
+
+// emitAllocationEventsTx Emits The Events Describing A User Taking A Seat In A Group.
+// userEvent Is user.created For New Users And user.updated For Minors Allocated After Consent.
+func (userService *UserService) emitAllocationEventsTx(context context.Context, gormDB *gorm.DB, user *models.User, group *models.Group, groupCreated bool, userEvent string) error {
+
+	if groupCreated {
+
+		if err := userService.events.EmitTx(context, gormDB, constants.EventGroupCreated, eventModels.GroupEventPayload{Group: *group}); err != nil {
+
+			return err
+		}
+	}
+
+	payload := eventModels.UserEventPayload{User: *user, GroupBase: group.Base}
+	if err := userService.events.EmitTx(context, gormDB, userEvent, payload); err != nil {
+
+		return err
+	}
+
+	if err := userService.events.EmitTx(context, gormDB, constants.EventUserGroupChanged, payload); err != nil {
+
+		return err
+	}
+
+	// The Increment Above Took The Last Free Seat :
+	if group.MemberCount+1 >= group.Capacity {
+
+		full := *group
+		full.MemberCount++
+		return userService.events.EmitTx(context, gormDB, constants.EventGroupFull, eventModels.GroupEventPayload{Group: full})
+	}
+
+	return nil
+}
+
+// groupBase Extracts The Base Category From A Group Name ( e.g., "adult-2" -> "adult" ) :
+func groupBase(group string) string {
+
+	if index := strings.LastIndex(group, "-"); index > 0 {
+
+		return group[:index]
+	}
+
+	return group
+}
+
+// settings Returns The Grouping Rules Of The Request's Tenant :
+func (userService *UserService) settings(context context.Context) tenant.Settings {
+
+	return userService.tenants.For(tenant.ID(context))
+}
+
+// allocatableGroupTx Finds ( Or Creates ) A Group With A Free Seat For The Date Of Birth, Using The Tenant's Rules :
+func (userService *UserService) allocatableGroupTx(context context.Context, gormDB *gorm.DB, birth time.Time) (*models.Group, bool, error) {
+
+	settings := userService.settings(context)
+	return userService.groups.FindAllocatableGroupTx(gormDB, settings.AgeBands.BaseGroup(birth), settings.GroupCapacity)
 }
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"backend-task/internal/constants"
+	eventModels "backend-task/internal/events/models"
+	"backend-task/internal/user/models"
+	"backend-task/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+// ---------------- Change Status ----------------
+
+// ChangeUserStatus Moves A User Along The Status State Machine, Recording The Reason And The Actor.
+// Deactivation ( And Suspension With ReleaseSeat ) Frees The Group Seat; Activating A User Without One Allocates Them Again :
+func (userService *UserService) ChangeUserStatus(context context.Context, id, status string, req models.ChangeStatusReq) (*models.User, error) {
+
+	if _, known := constants.UserStatusTransitions[status]; !known {
+
+		return nil, utils.NewBadRequest(utils.ErrInvalidUserStatus)
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" || len(reason) > constants.MaxStatusReasonSize {
+
+		return nil, utils.NewBadRequest(utils.ErrStatusReasonRequired)
+	}
+
+	user, err := userService.GetUserByID(context, id)
+	if err != nil {
+
+		return nil, err
+	}
+
+	if !slices.Contains(constants.UserStatusTransitions[user.Status], status) {
+
+		return nil, utils.NewConflict(utils.ErrInvalidStatusTransition)
+	}
+
+	allocate := status == constants.UserStatusActive && user.Group == ""
+	if allocate && userService.settings(context).AgeBands.IsMinor(user.DateOfBirth) {
+
+		consented, err := userService.hasGrantedConsent(context, user)
+		if err != nil {
+
+			return nil, err
+		}
+
+		if !consented {
+
+			return nil, utils.NewConflict(utils.ErrGuardianConsentRequired)
+		}
+	}
+
+	now := userService.verification.Now().UTC()
+	before, from := *user, user.Status
+	user.Status = status
+	user.StatusReason = reason
+	user.StatusChangedBy = utils.RequestMetaFromContext(context).ActorID
+	user.StatusChangedAt = &now
+
+	err = userService.db.WithContext(context).Transaction(func(gormDB *gorm.DB) error {
+
+		changed, err := userService.users.TransitionUserStatusTx(gormDB, user, from, "status", "status_reason", "status_changed_by", "status_changed_at")
+		if err != nil {
+
+			return err
+		}
+
+		if !changed {
+
+			return utils.NewConflict(utils.ErrInvalidStatusTransition)
+		}
+
+		// Tokens Already Issued Stop Working At Once, Not When They Expire :
+		if (status == constants.UserStatusSuspended || status == constants.UserStatusDeactivated) && userService.sessions != nil {
+
+			if err := userService.sessions.RevokeUserSessionsTx(gormDB, user.ID, now); err != nil {
+
+				return err
+			}
+		}
+
+		if status == constants.UserStatusDeactivated || (status == constants.UserStatusSuspended && req.ReleaseSeat) {
+
+			return userService.releaseSeatAndRecordTx(context, gormDB, &before, user, now)
+		}
+
+		if allocate {
+
+			return userService.allocateAndRecordTx(context, gormDB, &before, user, now)
+		}
+
+		if err := userService.audit.RecordTx(context, gormDB, constants.AuditActionUpdate, constants.AuditTargetUser, user.ID.String(), &before, user); err != nil {
+
+			return err
+		}
+
+		return userService.events.EmitTx(context, gormDB, constants.EventUserUpdated, eventModels.UserEventPayload{User: *user, GroupBase: groupBase(user.Group)})
+	})
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// ---------------- List Users By Status ----------------
+
+// ListUsersByStatus Lists Users, Optionally By Group; Without A Status Suspended And Deactivated Users Are Left Out :
+func (userService *UserService) ListUsersByStatus(context context.Context, group, status string) ([]*models.User, error) {
+
+	statuses, err := ListedStatuses(status)
+	if err != nil {
+
+		return nil, err
+	}
+
+	return userService.users.ListUsersByStatus(context, group, statuses)
+}
+
+// ListedStatuses Returns The Statuses A Listing Filters By : Only status When Given, Else constants.ListedUserStatuses.
+// Exported So Streaming Callers Can Reject A Bad Status Before Any Output Is Sent :
+func ListedStatuses(status string) ([]string, error) {
+
+	if status == "" {
+
+		return constants.ListedUserStatuses, nil
+	}
+
+	if _, known := constants.UserStatusTransitions[status]; !known {
+
+		return nil, utils.NewBadRequest(utils.ErrInvalidUserStatus)
+	}
+
+	return []string{status}, nil
+}
+
+// ---------------- Helper ----------------
+
+// releaseSeatTx Counts The User Out Of Their Group And Closes The Membership; The Caller Saves user.Group :
+func (userService *UserService) releaseSeatTx(gormDB *gorm.DB, user *models.User, now time.Time) error {
+
+	if user.Group == "" {
+
+		return nil
+	}
+
+	if err := userService.groups.DecrementGroupCountTx(gormDB, user.Group); err != nil {
+
+		return err
+	}
+
+	if err := userService.groups.CloseMembershipTx(gormDB, user.ID, now); err != nil {
+
+		return err
+	}
+
+	user.Group = ""
+	return nil
+}
+
+// releaseSeatAndRecordTx Frees The Seat Of A User Whose Status Was Just Saved, Then Audits And Emits The Change :
+func (userService *UserService) releaseSeatAndRecordTx(context context.Context, gormDB *gorm.DB, before, user *models.User, now time.Time) error {
+
+	if err := userService.releaseSeatTx(gormDB, user, now); err != nil {
+
+		return err
+	}
+
+	if err := userService.users.UpdateUserTx(gormDB, user, "group"); err != nil {
+
+		return err
+	}
+
+	if err := userService.audit.RecordTx(context, gormDB, constants.AuditActionUpdate, constants.AuditTargetUser, user.ID.String(), before, user); err != nil {
+
+		return err
+	}
+
+	payload := eventModels.UserEventPayload{User: *user, PreviousGroup: before.Group}
+	if err := userService.events.EmitTx(context, gormDB, constants.EventUserUpdated, payload); err != nil {
+
+		return err
+	}
+
+	if before.Group == "" {
+
+		return nil
+	}
+
+	return userService.events.EmitTx(context, gormDB, constants.EventUserGroupChanged, payload)
+}
+
+// allocateAndRecordTx Gives A Re-Activated User A Seat, Then Audits And Emits The Change :
+func (userService *UserService) allocateAndRecordTx(context context.Context, gormDB *gorm.DB, before, user *models.User, now time.Time) error {
+
+	group, groupCreated, err := userService.allocatableGroupTx(context, gormDB, user.DateOfBirth)
+	if err != nil {
+
+		return err
+	}
+
+	user.Group = group.Name
+	if err := userService.users.UpdateUserTx(gormDB, user, "group"); err != nil {
+
+		return err
+	}
+
+	if err := userService.takeSeatTx(gormDB, user, group, now); err != nil {
+
+		return err
+	}
+
+	if err := userService.audit.RecordTx(context, gormDB, constants.AuditActionUpdate, constants.AuditTargetUser, user.ID.String(), before, user); err != nil {
+
+		return err
+	}
+
+	return userService.emitAllocationEventsTx(context, gormDB, user, group, groupCreated, constants.EventUserUpdated)
+}
+
+// hasGrantedConsent Reports Whether The Minor's Guardian Has Given ( And Not Revoked ) Consent :
+func (userService *UserService) hasGrantedConsent(context context.Context, user *models.User) (bool, error) {
+
+	consents, err := userService.consents.ListConsents(context, models.ConsentFilter{UserID: &user.ID, Status: constants.ConsentStatusGranted})
+	if err != nil {
+
+		return false, err
+	}
+
+	return len(consents) > 0, nil
+}
@@ -2,8 +2,13 @@ package utils
 
 import (
 	"backend-task/internal/constants"
+	"backend-task/internal/i18n"
 	models "backend-task/internal/user/models"
 	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -30,71 +35,400 @@ var (
 	ErrFailedToFindGroup                  = errors.New("failed to find group")
 	ErrFailedToGetMaxGroupIdx             = errors.New("failed to get max group index")
 	ErrFailedToCreateNewGroup             = errors.New("failed to create new group")
+	ErrInvalidTimeRange                   = errors.New("from / to must be RFC 3339 timestamps")
+	ErrUnsupportedEventPublisher          = errors.New("unsupported event publisher, falling back to log")
+	ErrWebhookNotFound                    = errors.New("webhook subscription not found")
+	ErrWebhookDeliveryNotFound            = errors.New("webhook delivery not found")
+	ErrInvalidWebhookURL                  = errors.New("webhook url must be an absolute http or https url")
+	ErrWebhookSecretTooShort              = errors.New("webhook secret must be at least 16 characters")
+	ErrUnknownEventType                   = errors.New("unknown event type")
+	ErrUnsupportedContentType             = errors.New("content type must be text/csv")
+	ErrMalformedCSV                       = errors.New("malformed csv")
+	ErrImportMissingColumns               = errors.New("csv header must contain name, email and date_of_birth")
+	ErrImportNoRows                       = errors.New("csv file has no data rows")
+	ErrImportTooManyRows                  = errors.New("csv file exceeds the maximum number of rows")
+	ErrDuplicateEmailInFile               = errors.New("duplicate email in file")
+	ErrInvalidDryRun                      = errors.New("dry_run must be true or false")
+	ErrJobNotFound                        = errors.New("job not found")
+	ErrJobNotCancellable                  = errors.New("job has already finished")
+	ErrUnsupportedExportFormat            = errors.New("format must be csv or ndjson")
+	ErrInvalidGzipFlag                    = errors.New("gzip must be true or false")
+	ErrUnsupportedDataExportFormat        = errors.New("format must be json or zip")
+	ErrExportSigningKeyMissing            = errors.New("export signing key is not configured")
+	ErrInvalidExportSignature             = errors.New("export archive signature is invalid")
+	ErrMissingBearerToken                 = errors.New("missing bearer token")
+	ErrInvalidToken                       = errors.New("invalid or expired token")
+	ErrNoVerificationKeys                 = errors.New("no jwt verification keys configured")
+	ErrUnsupportedJWK                     = errors.New("unsupported jwk")
+	ErrPermissionDenied                   = errors.New("permission denied")
+	ErrUnknownPermission                  = errors.New("unknown permission")
+	ErrInvalidAPIKey                      = errors.New("invalid or revoked api key")
+	ErrAPIKeyIPNotAllowed                 = errors.New("api key is not allowed from this ip")
+	ErrAPIKeyNotFound                     = errors.New("api key not found")
+	ErrAPIKeyRevoked                      = errors.New("api key is revoked")
+	ErrInvalidAPIKeyName                  = errors.New("api key name is required ( max 128 characters )")
+	ErrInvalidAllowedIP                   = errors.New("allowed ips must be ip addresses or cidr ranges")
+	ErrAPIKeyPermissionRequiresMFA        = errors.New("permission requires multi-factor authentication and cannot be granted to an api key")
+	ErrInvalidCredentials                 = errors.New("invalid email or password")
+	ErrInvalidRefreshToken                = errors.New("invalid or expired refresh token")
+	ErrSessionRevoked                     = errors.New("session has been revoked")
+	ErrWeakPassword                       = errors.New("password must be 10 to 128 characters")
+	ErrCurrentPasswordMismatch            = errors.New("current password is incorrect")
+	ErrTokenSigningKeyMissing             = errors.New("token signing key is not configured")
+	ErrMalformedPasswordHash              = errors.New("malformed password hash")
+	ErrMFARequired                        = errors.New("multi-factor authentication required")
+	ErrMFAAlreadyEnabled                  = errors.New("mfa is already enabled")
+	ErrMFANotEnrolled                     = errors.New("mfa enrolment not started")
+	ErrInvalidMFACode                     = errors.New("invalid mfa code")
+	ErrInvalidMFAChallenge                = errors.New("invalid or expired mfa challenge")
+	ErrMFACodeRequired                    = errors.New("either code or recovery_code is required")
+	ErrAccountNotFound                    = errors.New("account not found")
+	ErrNotAnAccount                       = errors.New("caller is not a password account")
+	ErrInvalidRoles                       = errors.New("roles must be non-empty names")
+	ErrInvalidVerificationToken           = errors.New("invalid or expired verification token")
+	ErrUnsupportedMailDriver              = errors.New("unsupported mail driver, falling back to file")
+	ErrGuardianRequired                   = errors.New("guardian name and email are required for users under 18")
+	ErrInvalidGuardianEmail               = errors.New("guardian email must be a valid address other than the user's")
+	ErrInvalidConsentToken                = errors.New("invalid or expired consent token")
+	ErrConsentNotFound                    = errors.New("consent not found")
+	ErrConsentAlreadyRevoked              = errors.New("consent is already revoked")
+	ErrInvalidConsentStatus               = errors.New("status must be pending, granted or revoked")
+	ErrConsentReasonTooLong               = errors.New("reason must be at most 512 characters")
+	ErrInvalidUserStatus                  = errors.New("status must be pending_consent, active, suspended or deactivated")
+	ErrInvalidStatusTransition            = errors.New("status transition is not allowed")
+	ErrStatusReasonRequired               = errors.New("reason is required ( max 512 characters )")
+	ErrGuardianConsentRequired            = errors.New("a minor can only be activated with granted guardian consent")
+	ErrUserNotActive                      = errors.New("user is suspended or deactivated")
+	ErrInvalidTenantID                    = errors.New("tenant id must be 1 to 64 lowercase letters, digits, '-' or '_'")
+	ErrTenantMismatch                     = errors.New("tenant does not match the caller's tenant")
+	ErrInvalidTenantSettings              = errors.New("invalid tenant settings")
+	ErrValidationFailed                   = errors.New("request validation failed")
+	ErrDisposableEmail                    = errors.New("disposable email addresses are not allowed")
+	ErrInvalidDisposableDomains           = errors.New("invalid disposable email domains file")
+	ErrInvalidMinScore                    = errors.New("min_score must be a number between 0 and 1")
+	ErrMergeSameUser                      = errors.New("a user cannot be merged into itself")
+	ErrInvalidSearchQuery                 = errors.New("q must be between 3 and 100 characters")
 )
 
+// ---------------- Error Registry ----------------
+
+// ErrorDefinition Gives An Error Its Stable Code And The Status Used When It Is Returned Unwrapped :
+type ErrorDefinition struct {
+	Err    error
+	Code   string
+	Status int
+}
+
+// errorRegistry Lists Every Error That Reaches Clients. Codes Are Part Of The API Contract: Never Rename One.
+// Startup / Log-Only Errors ( e.g., ErrMigrationFailed ) Are Not Registered.
+var errorRegistry = []ErrorDefinition{
+	{Err: ErrInvalidRequestBody, Code: "INVALID_REQUEST_BODY", Status: constants.StatusBadRequest},
+	{Err: ErrInvalidID, Code: "INVALID_ID", Status: constants.StatusBadRequest},
+	{Err: ErrUserNotFound, Code: "USER_NOT_FOUND", Status: constants.StatusNotFound},
+	{Err: ErrNameIsRequired, Code: "USER_NAME_REQUIRED", Status: constants.StatusBadRequest},
+	{Err: ErrInvalidEmailFormat, Code: "USER_EMAIL_INVALID", Status: constants.StatusBadRequest},
+	{Err: ErrDateOfBirthFormat, Code: "USER_DATE_OF_BIRTH_INVALID", Status: constants.StatusBadRequest},
+	{Err: ErrEmailAlreadyExists, Code: "USER_EMAIL_TAKEN", Status: constants.StatusConflict},
+	{Err: ErrNameCannotBeEmpty, Code: "USER_NAME_EMPTY", Status: constants.StatusBadRequest},
+	{Err: ErrRecordNotFound, Code: "RECORD_NOT_FOUND", Status: constants.StatusNotFound},
+	{Err: ErrInternalError, Code: "INTERNAL_ERROR", Status: constants.StatusInternalServerError},
+	{Err: ErrDateOfBirthCannotBeFuture, Code: "USER_DATE_OF_BIRTH_IN_FUTURE", Status: constants.StatusBadRequest},
+	{Err: ErrFailedToFindGroup, Code: "GROUP_LOOKUP_FAILED", Status: constants.StatusInternalServerError},
+	{Err: ErrFailedToGetMaxGroupIdx, Code: "GROUP_INDEX_FAILED", Status: constants.StatusInternalServerError},
+	{Err: ErrFailedToCreateNewGroup, Code: "GROUP_CREATE_FAILED", Status: constants.StatusInternalServerError},
+	{Err: ErrInvalidTimeRange, Code: "INVALID_TIME_RANGE", Status: constants.StatusBadRequest},
+	{Err: ErrWebhookNotFound, Code: "WEBHOOK_NOT_FOUND", Status: constants.StatusNotFound},
+	{Err: ErrWebhookDeliveryNotFound, Code: "WEBHOOK_DELIVERY_NOT_FOUND", Status: constants.StatusNotFound},
+	{Err: ErrInvalidWebhookURL, Code: "WEBHOOK_URL_INVALID", Status: constants.StatusBadRequest},
+	{Err: ErrWebhookSecretTooShort, Code: "WEBHOOK_SECRET_TOO_SHORT", Status: constants.StatusBadRequest},
+	{Err: ErrUnknownEventType, Code: "EVENT_TYPE_UNKNOWN", Status: constants.StatusBadRequest},
+	{Err: ErrUnsupportedContentType, Code: "UNSUPPORTED_CONTENT_TYPE", Status: constants.StatusUnsupportedMediaType},
+	{Err: ErrMalformedCSV, Code: "IMPORT_CSV_MALFORMED", Status: constants.StatusBadRequest},
+	{Err: ErrImportMissingColumns, Code: "IMPORT_COLUMNS_MISSING", Status: constants.StatusBadRequest},
+	{Err: ErrImportNoRows, Code: "IMPORT_NO_ROWS", Status: constants.StatusBadRequest},
+	{Err: ErrImportTooManyRows, Code: "IMPORT_TOO_MANY_ROWS", Status: constants.StatusBadRequest},
+	{Err: ErrDuplicateEmailInFile, Code: "IMPORT_DUPLICATE_EMAIL", Status: constants.StatusBadRequest},
+	{Err: ErrInvalidDryRun, Code: "IMPORT_DRY_RUN_INVALID", Status: constants.StatusBadRequest},
+	{Err: ErrJobNotFound, Code: "JOB_NOT_FOUND", Status: constants.StatusNotFound},
+	{Err: ErrJobNotCancellable, Code: "JOB_NOT_CANCELLABLE", Status: constants.StatusConflict},
+	{Err: ErrUnsupportedExportFormat, Code: "EXPORT_FORMAT_UNSUPPORTED", Status: constants.StatusBadRequest},
+	{Err: ErrInvalidGzipFlag, Code: "EXPORT_GZIP_INVALID", Status: constants.StatusBadRequest},
+	{Err: ErrUnsupportedDataExportFormat, Code: "DATA_EXPORT_FORMAT_UNSUPPORTED", Status: constants.StatusBadRequest},
+	{Err: ErrExportSigningKeyMissing, Code: "DATA_EXPORT_SIGNING_KEY_MISSING", Status: constants.StatusInternalServerError},
+	{Err: ErrInvalidExportSignature, Code: "DATA_EXPORT_SIGNATURE_INVALID", Status: constants.StatusBadRequest},
+	{Err: ErrMissingBearerToken, Code: "AUTH_TOKEN_MISSING", Status: constants.StatusUnauthorized},
+	{Err: ErrInvalidToken, Code: "AUTH_TOKEN_INVALID", Status: constants.StatusUnauthorized},
+	{Err: ErrNoVerificationKeys, Code: "AUTH_VERIFICATION_KEYS_MISSING", Status: constants.StatusInternalServerError},
+	{Err: ErrUnsupportedJWK, Code: "AUTH_JWK_UNSUPPORTED", Status: constants.StatusInternalServerError},
+	{Err: ErrPermissionDenied, Code: "PERMISSION_DENIED", Status: constants.StatusForbidden},
+	{Err: ErrUnknownPermission, Code: "PERMISSION_UNKNOWN", Status: constants.StatusBadRequest},
+	{Err: ErrInvalidAPIKey, Code: "API_KEY_INVALID", Status: constants.StatusUnauthorized},
+	{Err: ErrAPIKeyIPNotAllowed, Code: "API_KEY_IP_NOT_ALLOWED", Status: constants.StatusForbidden},
+	{Err: ErrAPIKeyNotFound, Code: "API_KEY_NOT_FOUND", Status: constants.StatusNotFound},
+	{Err: ErrAPIKeyRevoked, Code: "API_KEY_REVOKED", Status: constants.StatusConflict},
+	{Err: ErrInvalidAPIKeyName, Code: "API_KEY_NAME_INVALID", Status: constants.StatusBadRequest},
+	{Err: ErrInvalidAllowedIP, Code: "API_KEY_ALLOWED_IP_INVALID", Status: constants.StatusBadRequest},
+	{Err: ErrAPIKeyPermissionRequiresMFA, Code: "API_KEY_PERMISSION_REQUIRES_MFA", Status: constants.StatusBadRequest},
+	{Err: ErrInvalidCredentials, Code: "AUTH_INVALID_CREDENTIALS", Status: constants.StatusUnauthorized},
+	{Err: ErrInvalidRefreshToken, Code: "AUTH_REFRESH_TOKEN_INVALID", Status: constants.StatusUnauthorized},
+	{Err: ErrSessionRevoked, Code: "AUTH_SESSION_REVOKED", Status: constants.StatusUnauthorized},
+	{Err: ErrWeakPassword, Code: "AUTH_PASSWORD_WEAK", Status: constants.StatusBadRequest},
+	{Err: ErrCurrentPasswordMismatch, Code: "AUTH_CURRENT_PASSWORD_MISMATCH", Status: constants.StatusBadRequest},
+	{Err: ErrTokenSigningKeyMissing, Code: "AUTH_SIGNING_KEY_MISSING", Status: constants.StatusInternalServerError},
+	{Err: ErrMalformedPasswordHash, Code: "AUTH_PASSWORD_HASH_MALFORMED", Status: constants.StatusInternalServerError},
+	{Err: ErrMFARequired, Code: "MFA_REQUIRED", Status: constants.StatusUnauthorized},
+	{Err: ErrMFAAlreadyEnabled, Code: "MFA_ALREADY_ENABLED", Status: constants.StatusConflict},
+	{Err: ErrMFANotEnrolled, Code: "MFA_NOT_ENROLLED", Status: constants.StatusBadRequest},
+	{Err: ErrInvalidMFACode, Code: "MFA_CODE_INVALID", Status: constants.StatusBadRequest},
+	{Err: ErrInvalidMFAChallenge, Code: "MFA_CHALLENGE_INVALID", Status: constants.StatusUnauthorized},
+	{Err: ErrMFACodeRequired, Code: "MFA_CODE_REQUIRED", Status: constants.StatusBadRequest},
+	{Err: ErrAccountNotFound, Code: "ACCOUNT_NOT_FOUND", Status: constants.StatusNotFound},
+	{Err: ErrNotAnAccount, Code: "ACCOUNT_REQUIRED", Status: constants.StatusForbidden},
+	{Err: ErrInvalidRoles, Code: "ACCOUNT_ROLES_INVALID", Status: constants.StatusBadRequest},
+	{Err: ErrInvalidVerificationToken, Code: "EMAIL_VERIFICATION_TOKEN_INVALID", Status: constants.StatusBadRequest},
+	{Err: ErrGuardianRequired, Code: "USER_GUARDIAN_REQUIRED", Status: constants.StatusBadRequest},
+	{Err: ErrInvalidGuardianEmail, Code: "USER_GUARDIAN_EMAIL_INVALID", Status: constants.StatusBadRequest},
+	{Err: ErrInvalidConsentToken, Code: "CONSENT_TOKEN_INVALID", Status: constants.StatusBadRequest},
+	{Err: ErrConsentNotFound, Code: "CONSENT_NOT_FOUND", Status: constants.StatusNotFound},
+	{Err: ErrConsentAlreadyRevoked, Code: "CONSENT_ALREADY_REVOKED", Status: constants.StatusConflict},
+	{Err: ErrInvalidConsentStatus, Code: "CONSENT_STATUS_INVALID", Status: constants.StatusBadRequest},
+	{Err: ErrConsentReasonTooLong, Code: "CONSENT_REASON_TOO_LONG", Status: constants.StatusBadRequest},
+	{Err: ErrInvalidUserStatus, Code: "USER_STATUS_INVALID", Status: constants.StatusBadRequest},
+	{Err: ErrInvalidStatusTransition, Code: "USER_STATUS_TRANSITION_NOT_ALLOWED", Status: constants.StatusConflict},
+	{Err: ErrStatusReasonRequired, Code: "USER_STATUS_REASON_REQUIRED", Status: constants.StatusBadRequest},
+	{Err: ErrGuardianConsentRequired, Code: "USER_GUARDIAN_CONSENT_REQUIRED", Status: constants.StatusConflict},
+	{Err: ErrUserNotActive, Code: "USER_NOT_ACTIVE", Status: constants.StatusForbidden},
+	{Err: ErrInvalidTenantID, Code: "TENANT_ID_INVALID", Status: constants.StatusBadRequest},
+	{Err: ErrTenantMismatch, Code: "TENANT_MISMATCH", Status: constants.StatusForbidden},
+	{Err: ErrValidationFailed, Code: "VALIDATION_FAILED", Status: constants.StatusBadRequest},
+	{Err: ErrDisposableEmail, Code: "USER_EMAIL_DISPOSABLE", Status: constants.StatusBadRequest},
+	{Err: ErrInvalidMinScore, Code: "INVALID_MIN_SCORE", Status: constants.StatusBadRequest},
+	{Err: ErrMergeSameUser, Code: "USER_MERGE_SAME", Status: constants.StatusBadRequest},
+	{Err: ErrInvalidSearchQuery, Code: "SEARCH_QUERY_INVALID", Status: constants.StatusBadRequest},
+}
+
+// LookupError Returns The Registry Entry Of The First Registered Error In err's Chain :
+func LookupError(err error) (ErrorDefinition, bool) {
+
+	for _, definition := range errorRegistry {
+
+		if errors.Is(err, definition.Err) {
+
+			return definition, true
+		}
+	}
+
+	return ErrorDefinition{}, false
+}
+
+// lookupCode Returns The Registry Entry With The Given Code :
+func lookupCode(code string) (ErrorDefinition, bool) {
+
+	for _, definition := range errorRegistry {
+
+		if definition.Code == code {
+
+			return definition, true
+		}
+	}
+
+	return ErrorDefinition{}, false
+}
+
+// ErrorCodes Returns Every Registered Code ( Used To Document And Test The Contract ) :
+func ErrorCodes() []string {
+
+	codes := make([]string, 0, len(errorRegistry))
+	for _, definition := range errorRegistry {
+
+		codes = append(codes, definition.Code)
+	}
+
+	return codes
+}
+
+// ---------------- API Error ----------------
+
+// APIError Is An Error Meant For The Client: The Status Chosen By The Caller Plus The Underlying Error,
+// Which Still Matches errors.Is And Supplies The Error Code :
+type APIError struct {
+	Status int
+	Err    error
+}
+
+func (apiErr APIError) Error() string {
+	return apiErr.Err.Error()
+}
+
+func (apiErr APIError) Unwrap() error {
+	return apiErr.Err
+}
+
 // ---------------- Predefined Constructors ----------------
 
 func NewBadRequest(err error) error {
-	return models.ErrorResponse{Code: constants.StatusBadRequest, Message: err.Error()}
+	return APIError{Status: constants.StatusBadRequest, Err: err}
+}
+
+func NewUnauthorized(err error) error {
+	return APIError{Status: constants.StatusUnauthorized, Err: err}
+}
+
+func NewForbidden(err error) error {
+	return APIError{Status: constants.StatusForbidden, Err: err}
 }
 
 func NewNotFound(err error) error {
-	return models.ErrorResponse{Code: constants.StatusNotFound, Message: err.Error()}
+	return APIError{Status: constants.StatusNotFound, Err: err}
+}
+
+func NewConflict(err error) error {
+	return APIError{Status: constants.StatusConflict, Err: err}
+}
+
+func NewUnsupportedMediaType(err error) error {
+	return APIError{Status: constants.StatusUnsupportedMediaType, Err: err}
 }
 
 func NewInternalError(err error) error {
-	return models.ErrorResponse{Code: constants.StatusInternalServerError, Message: err.Error()}
+	return APIError{Status: constants.StatusInternalServerError, Err: err}
 }
 
 // ---------------- Gin Error Responder ----------------
 
-func RespondError(context *gin.Context, err error) {
+// fallbackErrorCodes Name Errors Without A Registry Entry By Their Status :
+var fallbackErrorCodes = map[int]string{
+	constants.StatusBadRequest:           constants.ErrorCodeBadRequest,
+	constants.StatusUnauthorized:         constants.ErrorCodeUnauthorized,
+	constants.StatusForbidden:            constants.ErrorCodeForbidden,
+	constants.StatusNotFound:             constants.ErrorCodeNotFound,
+	constants.StatusConflict:             constants.ErrorCodeConflict,
+	constants.StatusUnsupportedMediaType: constants.ErrorCodeUnsupportedMediaType,
+	constants.StatusInternalServerError:  constants.ErrorCodeInternal,
+}
 
-	if err == nil {
+// NewProblem Builds The Problem Document For err.
+// APIErrors Keep Their Status And Message, Registered Errors Use The Registry Status,
+// Anything Else Is An Internal Error Whose Message Is Not Exposed.
+func NewProblem(err error, instance, requestID string) models.ErrorResponse {
 
-		return
+	status, detail := constants.StatusInternalServerError, ErrInternalError.Error()
+	definition, registered := LookupError(err)
+
+	var apiErr APIError
+	var validationErr ValidationError
+	switch {
+
+	case errors.As(err, &validationErr):
+		status, detail = constants.StatusBadRequest, ErrValidationFailed.Error()
+
+	case errors.As(err, &apiErr):
+		status, detail = apiErr.Status, apiErr.Error()
+
+	case registered:
+		status, detail = definition.Status, err.Error()
+		if status >= constants.StatusInternalServerError {
+
+			// Wrapped Causes Of Server Errors ( e.g., Database Messages ) Stay In The Logs :
+			detail = definition.Err.Error()
+		}
+
+	default:
+		definition, registered = LookupError(ErrInternalError)
 	}
 
-	var apiErr models.ErrorResponse
+	code := definition.Code
+	if !registered {
 
-	// Use APIError If Possible
-	if errors.As(err, &apiErr) {
+		code = fallbackErrorCodes[status]
+	}
 
-		context.JSON(apiErr.Code, apiErr)
-		return
+	return models.ErrorResponse{
+		Type:      constants.ProblemTypePrefix + strings.ReplaceAll(strings.ToLower(code), "_", "-"),
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    detail,
+		Instance:  instance,
+		ErrorCode: code,
+		RequestID: requestID,
+		Errors:    validationErr.Fields,
 	}
+}
 
-	switch {
+// LocalizeProblem Translates The Title, Detail And Field Messages Of A Problem. The Error Code Stays As-Is;
+// Details Carrying Extra Context ( e.g., "unknown permission: users:fly" ) And Missing Messages Stay English.
+func LocalizeProblem(problem models.ErrorResponse, language string) models.ErrorResponse {
+
+	// English Messages Are Already In Place ( And Field Messages From The Services Are More Specific ) :
+	if language == constants.DefaultLanguage {
 
-	case errors.Is(err, ErrRecordNotFound):
-		context.JSON(constants.StatusNotFound, models.ErrorResponse{Code: constants.StatusNotFound, Message: ErrRecordNotFound.Error()})
+		return problem
+	}
 
-	case errors.Is(err, ErrUserNotFound):
-		context.JSON(constants.StatusNotFound, models.ErrorResponse{Code: constants.StatusNotFound, Message: ErrUserNotFound.Error()})
+	if title, found := i18n.Lookup(language, constants.MessageTitlePrefix+strconv.Itoa(problem.Status)); found {
 
-	case errors.Is(err, ErrInvalidID):
-		context.JSON(constants.StatusBadRequest, models.ErrorResponse{Code: constants.StatusBadRequest, Message: ErrInvalidID.Error()})
+		problem.Title = title
+	}
 
-	case errors.Is(err, ErrNameCannotBeEmpty):
-		context.JSON(constants.StatusBadRequest, models.ErrorResponse{Code: constants.StatusBadRequest, Message: ErrNameCannotBeEmpty.Error()})
+	if definition, registered := lookupCode(problem.ErrorCode); registered && problem.Detail == definition.Err.Error() {
 
-	case errors.Is(err, ErrInvalidEmailFormat):
-		context.JSON(constants.StatusBadRequest, models.ErrorResponse{Code: constants.StatusBadRequest, Message: ErrInvalidEmailFormat.Error()})
+		if detail, found := i18n.Lookup(language, problem.ErrorCode); found {
 
-	case errors.Is(err, ErrEmailAlreadyExists):
-		context.JSON(constants.StatusBadRequest, models.ErrorResponse{Code: constants.StatusBadRequest, Message: ErrEmailAlreadyExists.Error()})
+			problem.Detail = detail
+		}
+	}
 
-	case errors.Is(err, ErrNameIsRequired):
-		context.JSON(constants.StatusBadRequest, models.ErrorResponse{Code: constants.StatusBadRequest, Message: ErrNameIsRequired.Error()})
+	if len(problem.Errors) == 0 {
 
-	case errors.Is(err, ErrDateOfBirthFormat):
-		context.JSON(constants.StatusBadRequest, models.ErrorResponse{Code: constants.StatusBadRequest, Message: ErrDateOfBirthFormat.Error()})
+		return problem
+	}
 
-	case errors.Is(err, ErrDateOfBirthCannotBeFuture):
-		context.JSON(constants.StatusBadRequest,
-			models.ErrorResponse{Code: constants.StatusBadRequest, Message: ErrDateOfBirthCannotBeFuture.Error()})
+	fields := make([]models.FieldError, len(problem.Errors))
+	for index, field := range problem.Errors {
 
-	default:
-		context.JSON(constants.StatusInternalServerError, models.ErrorResponse{Code: constants.StatusInternalServerError, Message: ErrInternalError.Error()})
+		if _, found := i18n.Lookup(language, constants.MessageRulePrefix+field.Rule); found {
+
+			// Like The English Messages, Name The Field Without Its Array Index :
+			name := field.Field
+			if _, rest, indexed := strings.Cut(name, "]."); indexed && strings.HasPrefix(name, "[") {
+
+				name = rest
+			}
+
+			field.Message = ruleMessage(language, name, field.Rule, field.Param)
+		}
+
+		fields[index] = field
+	}
+
+	problem.Errors = fields
+	return problem
+}
+
+// RespondError Writes err As An application/problem+json Response In The Caller's Language :
+func RespondError(context *gin.Context, err error) {
+
+	if err == nil {
+
+		return
+	}
+
+	meta := RequestMetaFromContext(context.Request.Context())
+	language := meta.Language
+	if language == "" {
+
+		language = i18n.Negotiate(context.GetHeader(constants.HeaderAcceptLanguage))
 	}
+
+	problem := LocalizeProblem(NewProblem(err, context.Request.URL.Path, meta.RequestID), language)
+	if problem.Status >= constants.StatusInternalServerError {
+
+		// The Client Only Sees INTERNAL_ERROR; The Cause Is Logged With The Request ID To Find It Again :
+		Error(fmt.Sprintf("%s %s ( request %s ): %v", context.Request.Method, problem.Instance, meta.RequestID, err))
+	}
+
+	// Set Before Rendering So Gin Keeps It Instead Of application/json :
+	context.Header(constants.HeaderContentType, constants.ContentTypeProblemJSON)
+	context.Header(constants.HeaderContentLanguage, language)
+	context.JSON(problem.Status, problem)
 }
@@ -0,0 +1,268 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"backend-task/internal/constants"
+	"backend-task/internal/i18n"
+	models "backend-task/internal/user/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// ---------------- Validation Error ----------------
+
+// ValidationError Reports Every Invalid Field Of A Request At Once ( VALIDATION_FAILED ) :
+type ValidationError struct {
+	Fields []models.FieldError
+}
+
+func (validationErr ValidationError) Error() string {
+
+	messages := make([]string, len(validationErr.Fields))
+	for index, field := range validationErr.Fields {
+
+		messages[index] = field.Message
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+func (validationErr ValidationError) Unwrap() error {
+	return ErrValidationFailed
+}
+
+// FieldErrors Collects Field Problems While Validating; Err Returns nil When There Are None :
+type FieldErrors []models.FieldError
+
+// Add Records A Failed Rule; The Message Is Taken From err ( Usually A Predefined Error ) :
+func (fieldErrors *FieldErrors) Add(field, rule string, err error) {
+
+	*fieldErrors = append(*fieldErrors, models.FieldError{Field: field, Rule: rule, Message: err.Error()})
+}
+
+func (fieldErrors FieldErrors) Err() error {
+
+	if len(fieldErrors) == 0 {
+
+		return nil
+	}
+
+	return ValidationError{Fields: fieldErrors}
+}
+
+// AddIndexed Records The Fields Of A ValidationError Under An Array Index ( "email" Becomes "[2].email" );
+// Other Errors Are Returned, So The Caller Can Stop :
+func (fieldErrors *FieldErrors) AddIndexed(err error, index int) error {
+
+	var validationErr ValidationError
+	if !errors.As(err, &validationErr) {
+
+		return err
+	}
+
+	*fieldErrors = append(*fieldErrors, withIndex(validationErr.Fields, index)...)
+	return nil
+}
+
+func withIndex(fields []models.FieldError, index int) []models.FieldError {
+
+	indexed := make([]models.FieldError, len(fields))
+	for position, field := range fields {
+
+		field.Field = "[" + strconv.Itoa(index) + "]." + field.Field
+		indexed[position] = field
+	}
+
+	return indexed
+}
+
+// ---------------- Request Binding ----------------
+
+// NewBindingError Turns A Failed ShouldBindJSON Into A ValidationError Naming Every Invalid Field.
+// Bodies That Are Not JSON At All Stay ErrInvalidRequestBody :
+func NewBindingError(err error) error {
+
+	var fields FieldErrors
+
+	var validationErrs validator.ValidationErrors
+	var typeErr *json.UnmarshalTypeError
+
+	switch {
+
+	case errors.As(err, &validationErrs):
+		fields = fromValidationErrors(validationErrs)
+
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		expected := jsonTypeName(typeErr.Type)
+		fields = append(fields, models.FieldError{
+			Field:   typeErr.Field,
+			Rule:    "type",
+			Param:   expected,
+			Message: fmt.Sprintf("%s must be of type %s, not %s", typeErr.Field, expected, typeErr.Value),
+		})
+	}
+
+	if len(fields) == 0 {
+
+		return NewBadRequest(ErrInvalidRequestBody)
+	}
+
+	return fields.Err()
+}
+
+// BindJSONList Binds A JSON Array And Validates Each Element, So Field Paths Carry The Index ( "[2].email" );
+// Gin's Own Slice Validation Drops It :
+func BindJSONList[T any](context *gin.Context, list *[]T) error {
+
+	if context.Request.Body == nil {
+
+		return NewBadRequest(ErrInvalidRequestBody)
+	}
+
+	if err := json.NewDecoder(context.Request.Body).Decode(list); err != nil {
+
+		return NewBindingError(err)
+	}
+
+	var fields FieldErrors
+	for index, item := range *list {
+
+		var validationErrs validator.ValidationErrors
+		err := binding.Validator.ValidateStruct(item)
+		if err == nil {
+
+			continue
+		}
+
+		if !errors.As(err, &validationErrs) {
+
+			return NewBadRequest(ErrInvalidRequestBody)
+		}
+
+		fields = append(fields, withIndex(fromValidationErrors(validationErrs), index)...)
+	}
+
+	return fields.Err()
+}
+
+// fromValidationErrors Names Fields After Their JSON Path, Without The Struct Name ( "CreateUserReq.guardian.email" -> "guardian.email" ) :
+func fromValidationErrors(validationErrs validator.ValidationErrors) FieldErrors {
+
+	var fields FieldErrors
+	for _, fieldErr := range validationErrs {
+
+		path := fieldErr.Namespace()
+		if _, rest, found := strings.Cut(path, "."); found {
+
+			path = rest
+		}
+
+		fields = append(fields, models.FieldError{
+			Field:   path,
+			Rule:    fieldErr.Tag(),
+			Param:   fieldErr.Param(),
+			Message: ruleMessage(constants.DefaultLanguage, path, fieldErr.Tag(), fieldErr.Param()),
+		})
+	}
+
+	return fields
+}
+
+// ruleMessage Explains A Failed Rule From The Message Catalog; Unknown Rules Get A Generic Message :
+func ruleMessage(language, field, rule, param string) string {
+
+	key := constants.MessageRulePrefix + rule
+	if _, found := i18n.Lookup(constants.DefaultLanguage, key); !found {
+
+		key = constants.MessageRulePrefix + "default"
+	}
+
+	return i18n.Text(language, key, "field", field, "rule", rule, "param", param)
+}
+
+func jsonTypeName(goType reflect.Type) string {
+
+	switch goType.Kind() {
+
+	case reflect.String:
+		return "string"
+
+	case reflect.Bool:
+		return "boolean"
+
+	case reflect.Slice, reflect.Array:
+		return "array"
+
+	case reflect.Struct, reflect.Map, reflect.Pointer:
+		return "object"
+
+	default:
+		return "number"
+	}
+}
+
+// ---------------- Custom Rules ----------------
+
+var registerRulesOnce sync.Once
+
+// RegisterValidationRules Plugs The Custom Rules Into Gin's Validator And Reports Fields By Their JSON Name.
+// Safe To Call More Than Once ( Every Router Calls It ).
+func RegisterValidationRules() {
+
+	registerRulesOnce.Do(func() {
+
+		engine, ok := binding.Validator.Engine().(*validator.Validate)
+		if !ok {
+
+			return
+		}
+
+		engine.RegisterTagNameFunc(func(field reflect.StructField) string {
+
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+
+				return ""
+			}
+
+			if name == "" {
+
+				return field.Name
+			}
+
+			return name
+		})
+
+		// Registration Only Fails For Empty Tags Or nil Functions :
+		_ = engine.RegisterValidation(constants.RuleNotBlank, notBlank)
+		_ = engine.RegisterValidation(constants.RulePastDate, pastDate)
+	})
+}
+
+// notBlank Rejects Strings That Are Only Whitespace :
+func notBlank(field validator.FieldLevel) bool {
+
+	return field.Field().Kind() != reflect.String || strings.TrimSpace(field.Field().String()) != ""
+}
+
+// pastDate Rejects yyyy-mm-dd Dates After Today; Malformed Dates Are Left To The "datetime" Rule :
+func pastDate(field validator.FieldLevel) bool {
+
+	date, err := time.Parse("2006-01-02", field.Field().String())
+	if err != nil {
+
+		return true
+	}
+
+	return ValidateDateOfBirth(date) == nil
+}
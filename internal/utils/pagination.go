@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"strconv"
+
+	"backend-task/internal/constants"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ParsePagination Reads "page" And "page_size" Query Parameters, Applying Defaults And Limits :
+func ParsePagination(context *gin.Context) (page, pageSize int) {
+
+	page, err := strconv.Atoi(context.DefaultQuery("page", strconv.Itoa(constants.DefaultPage)))
+	if err != nil || page < 1 {
+
+		page = constants.DefaultPage
+	}
+
+	pageSize, err = strconv.Atoi(context.DefaultQuery("page_size", strconv.Itoa(constants.DefaultPageSize)))
+	if err != nil || pageSize < 1 {
+
+		pageSize = constants.DefaultPageSize
+	}
+
+	if pageSize > constants.MaxPageSize {
+
+		pageSize = constants.MaxPageSize
+	}
+
+	return page, pageSize
+}
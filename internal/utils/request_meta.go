@@ -0,0 +1,32 @@
+package utils
+
+import "context"
+
+// Request Meta Describes Who Issued A Request And From Where :
+type RequestMeta struct {
+	RequestID string
+	ActorID   string
+	ClientIP  string
+	TenantID  string // Resolved From The Token Or The X-Tenant-ID Header.
+	Language  string // Negotiated From The Accept-Language Header ( Error Messages And Emails ).
+}
+
+type requestMetaKey struct{}
+
+// WithRequestMeta Returns A Copy Of The Context Carrying The Given Request Meta :
+func WithRequestMeta(ctx context.Context, meta RequestMeta) context.Context {
+
+	return context.WithValue(ctx, requestMetaKey{}, meta)
+}
+
+// RequestMetaFromContext Returns The Request Meta Stored In The Context ( Zero Value If Missing ) :
+func RequestMetaFromContext(ctx context.Context) RequestMeta {
+
+	if ctx == nil {
+
+		return RequestMeta{}
+	}
+
+	meta, _ := ctx.Value(requestMetaKey{}).(RequestMeta)
+	return meta
+}
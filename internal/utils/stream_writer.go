@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+)
+
+// StreamWriter Wraps A Destination ( File Or HTTP Response ) With Optional Gzip Compression.
+// Flush Pushes Everything Written So Far Through The Compressor And Down To The Client :
+type StreamWriter struct {
+	destination io.Writer
+	compressor  *gzip.Writer
+}
+
+func NewStreamWriter(destination io.Writer, compress bool) *StreamWriter {
+
+	streamWriter := &StreamWriter{destination: destination}
+	if compress {
+
+		streamWriter.compressor = gzip.NewWriter(destination)
+	}
+
+	return streamWriter
+}
+
+func (streamWriter *StreamWriter) Write(data []byte) (int, error) {
+
+	if streamWriter.compressor != nil {
+
+		return streamWriter.compressor.Write(data)
+	}
+
+	return streamWriter.destination.Write(data)
+}
+
+func (streamWriter *StreamWriter) Flush() error {
+
+	if streamWriter.compressor != nil {
+
+		if err := streamWriter.compressor.Flush(); err != nil {
+
+			return err
+		}
+	}
+
+	if flusher, ok := streamWriter.destination.(http.Flusher); ok {
+
+		flusher.Flush()
+	}
+
+	return nil
+}
+
+// Close Writes The Gzip Trailer; The Destination Itself Is Left Open :
+func (streamWriter *StreamWriter) Close() error {
+
+	if streamWriter.compressor != nil {
+
+		return streamWriter.compressor.Close()
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// String List Is Stored As A JSON Array In A Single Column ( Webhook Event Types, API Key Scopes ) :
+type StringList []string
+
+// Value Implements driver.Valuer :
+func (list StringList) Value() (driver.Value, error) {
+
+	if list == nil {
+
+		return "[]", nil
+	}
+
+	raw, err := json.Marshal(list)
+	if err != nil {
+
+		return nil, err
+	}
+
+	return string(raw), nil
+}
+
+// Scan Implements sql.Scanner :
+func (list *StringList) Scan(value any) error {
+
+	switch raw := value.(type) {
+	case nil:
+		*list = StringList{}
+		return nil
+
+	case string:
+		return json.Unmarshal([]byte(raw), list)
+
+	case []byte:
+		return json.Unmarshal(raw, list)
+
+	default:
+		return fmt.Errorf("unsupported string list type: %T", value)
+	}
+}
+
+// Contains Reports Whether The Value ( Or The Wildcard ) Is In The List :
+func (list StringList) Contains(value, wildcard string) bool {
+
+	for _, item := range list {
+
+		if item == value || (wildcard != "" && item == wildcard) {
+
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,18 +1,9 @@
 package utils
 
 import (
-	"regexp"
 	"time"
 )
 
-var emailRx = regexp.MustCompile(`^[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}$`)
-
-// ValidateEmail Checks If The Email Has A Valid Format :
-func ValidateEmail(email string) bool {
-
-	return emailRx.MatchString(email)
-}
-
 // CalculateAge Returns The Age In Years Based On Date Of Birth :
 func CalculateAge(dob time.Time) int {
 
@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+
+	constants "backend-task/internal/constants"
+	"backend-task/internal/utils"
+	models "backend-task/internal/webhook/models"
+	WebhookServiceInterface "backend-task/internal/webhook/services/interface"
+)
+
+type WebhookHandler struct {
+	Service WebhookServiceInterface.WebhookService
+}
+
+func NewWebhookHandler(s WebhookServiceInterface.WebhookService) *WebhookHandler {
+
+	return &WebhookHandler{Service: s}
+}
+
+// CreateSubscription godoc
+// @Summary Register a webhook.
+// @Description Registers a callback URL for the given event types ( "*" for all ). Payloads are signed with HMAC-SHA256 using the secret.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhook body models.CreateSubscriptionReq true "Webhook info"
+// @Success 201 {object} models.WebhookSubscription
+// @Failure 400 {object} models.ErrorResponse "Invalid request. Possible reasons: invalid url, secret too short, or unknown event type."
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /webhooks [post]
+func (webhookHandler *WebhookHandler) CreateSubscription(context *gin.Context) {
+
+	var body models.CreateSubscriptionReq
+	if err := context.ShouldBindJSON(&body); err != nil {
+
+		utils.RespondError(context, utils.NewBindingError(err))
+		return
+	}
+
+	subscription, err := webhookHandler.Service.CreateSubscription(context.Request.Context(), body)
+	if err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	context.JSON(constants.StatusCreated, subscription)
+}
+
+// ListSubscriptions godoc
+// @Summary List webhooks.
+// @Tags webhooks
+// @Produce json
+// @Success 200 {array} models.WebhookSubscription
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /webhooks [get]
+func (webhookHandler *WebhookHandler) ListSubscriptions(context *gin.Context) {
+
+	subscriptions, err := webhookHandler.Service.ListSubscriptions(context.Request.Context())
+	if err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	context.JSON(constants.StatusOK, subscriptions)
+}
+
+// GetSubscription godoc
+// @Summary Get a webhook.
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} models.WebhookSubscription
+// @Failure 400 {object} models.ErrorResponse "Invalid ID"
+// @Failure 404 {object} models.ErrorResponse "Webhook not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /webhooks/{id} [get]
+func (webhookHandler *WebhookHandler) GetSubscription(context *gin.Context) {
+
+	subscription, err := webhookHandler.Service.GetSubscription(context.Request.Context(), context.Param("id"))
+	if err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	context.JSON(constants.StatusOK, subscription)
+}
+
+// UpdateSubscription godoc
+// @Summary Update a webhook.
+// @Description Changes the URL, event types, secret and / or active flag.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Param webhook body models.UpdateSubscriptionReq true "Webhook info"
+// @Success 200 {object} models.WebhookSubscription
+// @Failure 400 {object} models.ErrorResponse "Invalid request"
+// @Failure 404 {object} models.ErrorResponse "Webhook not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /webhooks/{id} [patch]
+func (webhookHandler *WebhookHandler) UpdateSubscription(context *gin.Context) {
+
+	var body models.UpdateSubscriptionReq
+	if err := context.ShouldBindJSON(&body); err != nil {
+
+		utils.RespondError(context, utils.NewBindingError(err))
+		return
+	}
+
+	subscription, err := webhookHandler.Service.UpdateSubscription(context.Request.Context(), context.Param("id"), body)
+	if err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	context.JSON(constants.StatusOK, subscription)
+}
+
+// DeleteSubscription godoc
+// @Summary Delete a webhook.
+// @Description Deletes the webhook together with its delivery history.
+// @Tags webhooks
+// @Param id path string true "Webhook ID"
+// @Success 204
+// @Failure 400 {object} models.ErrorResponse "Invalid ID"
+// @Failure 404 {object} models.ErrorResponse "Webhook not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /webhooks/{id} [delete]
+func (webhookHandler *WebhookHandler) DeleteSubscription(context *gin.Context) {
+
+	if err := webhookHandler.Service.DeleteSubscription(context.Request.Context(), context.Param("id")); err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	context.Status(constants.StatusNoContent)
+}
+
+// ListDeliveries godoc
+// @Summary List webhook deliveries.
+// @Description Lists the deliveries of a webhook ( newest first ) with their status ( pending, succeeded, dead ).
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Success 200 {array} models.WebhookDelivery
+// @Failure 404 {object} models.ErrorResponse "Webhook not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /webhooks/{id}/deliveries [get]
+func (webhookHandler *WebhookHandler) ListDeliveries(context *gin.Context) {
+
+	deliveries, err := webhookHandler.Service.ListDeliveries(context.Request.Context(), context.Param("id"))
+	if err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	context.JSON(constants.StatusOK, deliveries)
+}
+
+// ListAttempts godoc
+// @Summary List delivery attempts.
+// @Description Lists every HTTP attempt made for a delivery.
+// @Tags webhooks
+// @Produce json
+// @Param deliveryId path string true "Delivery ID"
+// @Success 200 {array} models.WebhookAttempt
+// @Failure 404 {object} models.ErrorResponse "Delivery not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /webhooks/deliveries/{deliveryId}/attempts [get]
+func (webhookHandler *WebhookHandler) ListAttempts(context *gin.Context) {
+
+	attempts, err := webhookHandler.Service.ListAttempts(context.Request.Context(), context.Param("deliveryId"))
+	if err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	context.JSON(constants.StatusOK, attempts)
+}
+
+// Redeliver godoc
+// @Summary Redeliver a webhook delivery.
+// @Description Moves a ( dead ) delivery back to pending with a fresh attempt budget.
+// @Tags webhooks
+// @Param deliveryId path string true "Delivery ID"
+// @Success 202
+// @Failure 404 {object} models.ErrorResponse "Delivery not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /webhooks/deliveries/{deliveryId}/redeliver [post]
+func (webhookHandler *WebhookHandler) Redeliver(context *gin.Context) {
+
+	if err := webhookHandler.Service.Redeliver(context.Request.Context(), context.Param("deliveryId")); err != nil {
+
+		utils.RespondError(context, err)
+		return
+	}
+
+	context.Status(constants.StatusAccepted)
+}
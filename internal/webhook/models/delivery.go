@@ -0,0 +1,102 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Webhook Delivery Tracks One Event Being Delivered To One Subscription.
+//
+// @Description Webhook Delivery State ( pending, succeeded, dead ).
+type WebhookDelivery struct {
+
+	// Delivery Unique Identifier ( UUID ).
+	ID uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000" gorm:"type:uuid;primaryKey"`
+
+	// Tenant Of The Subscription And Event.
+	TenantID string `json:"tenant_id" example:"default" gorm:"not null;default:default;index;size:64"`
+
+	// Target Subscription.
+	SubscriptionID uuid.UUID `json:"subscription_id" gorm:"type:uuid;not null;uniqueIndex:idx_webhook_delivery_event"`
+
+	// Event Being Delivered ( Envelope ID ).
+	EventID uuid.UUID `json:"event_id" gorm:"type:uuid;not null;uniqueIndex:idx_webhook_delivery_event"`
+
+	// Event Type.
+	EventType string `json:"event_type" example:"user.created" gorm:"not null;size:64"`
+
+	// Signed Request Body ( The Event Envelope ).
+	Payload string `json:"-" gorm:"type:text;not null"`
+
+	// Delivery Status ( pending, succeeded, dead ).
+	Status string `json:"status" example:"pending" gorm:"not null;index;size:16"`
+
+	// Number Of Attempts Made So Far.
+	Attempts int `json:"attempts" example:"1" gorm:"not null;default:0"`
+
+	// When The Next Attempt Is Due ( Pending Only ).
+	NextAttemptAt time.Time `json:"next_attempt_at" gorm:"not null;index"`
+
+	// HTTP Status Of The Last Attempt ( 0 When No Response ).
+	LastStatusCode int `json:"last_status_code" example:"500"`
+
+	// Error Of The Last Attempt.
+	LastError string `json:"last_error,omitempty" gorm:"size:1024"`
+
+	// Timestamp When The Delivery Was Created.
+	CreatedAt time.Time `json:"created_at" example:"2025-09-01T12:00:00Z"`
+
+	// Timestamp When The Delivery Was Last Updated.
+	UpdatedAt time.Time `json:"updated_at" example:"2025-09-01T12:30:00Z"`
+}
+
+// Before Create Ensures UUID Is Set Automatically :
+func (delivery *WebhookDelivery) BeforeCreate(tx *gorm.DB) (err error) {
+
+	if delivery.ID == uuid.Nil {
+
+		delivery.ID = uuid.New()
+	}
+
+	return nil
+}
+
+// Webhook Attempt Records A Single HTTP Call Made For A Delivery.
+//
+// @Description Webhook Delivery Attempt ( For Inspection ).
+type WebhookAttempt struct {
+
+	// Attempt Unique Identifier ( UUID ).
+	ID uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000" gorm:"type:uuid;primaryKey"`
+
+	// Parent Delivery.
+	DeliveryID uuid.UUID `json:"delivery_id" gorm:"type:uuid;not null;index"`
+
+	// Attempt Number ( 1-Based ).
+	Attempt int `json:"attempt" example:"1" gorm:"not null"`
+
+	// HTTP Status Code ( 0 When No Response ).
+	StatusCode int `json:"status_code" example:"200"`
+
+	// Transport / HTTP Error, If Any.
+	Error string `json:"error,omitempty" gorm:"size:1024"`
+
+	// Round Trip Duration In Milliseconds.
+	DurationMs int64 `json:"duration_ms" example:"35"`
+
+	// Timestamp When The Attempt Was Made.
+	CreatedAt time.Time `json:"created_at" example:"2025-09-01T12:00:00Z"`
+}
+
+// Before Create Ensures UUID Is Set Automatically :
+func (attempt *WebhookAttempt) BeforeCreate(tx *gorm.DB) (err error) {
+
+	if attempt.ID == uuid.Nil {
+
+		attempt.ID = uuid.New()
+	}
+
+	return nil
+}
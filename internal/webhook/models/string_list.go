@@ -0,0 +1,6 @@
+package models
+
+import "backend-task/internal/utils"
+
+// String List Is Stored As A JSON Array In A Single Column :
+type StringList = utils.StringList
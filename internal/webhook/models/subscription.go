@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Webhook Subscription Registers A Partner URL For A Set Of Event Types.
+//
+// @Description Webhook Subscription ( The Secret Is Write-Only ).
+type WebhookSubscription struct {
+
+	// Subscription Unique Identifier ( UUID ).
+	ID uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000" gorm:"type:uuid;primaryKey"`
+
+	// Tenant Owning The Subscription ( Set From The Request ); It Only Receives That Tenant's Events.
+	TenantID string `json:"tenant_id" example:"default" gorm:"not null;default:default;index;size:64" readonly:"true"`
+
+	// Callback URL ( http / https ).
+	URL string `json:"url" example:"https://partner.example.com/hooks" gorm:"not null;size:2048"`
+
+	// Subscribed Event Types ( "*" For All ).
+	EventTypes StringList `json:"event_types" swaggertype:"array,string" example:"user.created,user.group_changed" gorm:"type:text;not null"`
+
+	// HMAC-SHA256 Signing Secret ( Never Returned ).
+	Secret string `json:"-" gorm:"not null;size:256"`
+
+	// Inactive Subscriptions Receive No New Deliveries.
+	Active bool `json:"active" example:"true" gorm:"not null;default:true;index"`
+
+	// Timestamp When The Subscription Was Created.
+	CreatedAt time.Time `json:"created_at" example:"2025-09-01T12:00:00Z"`
+
+	// Timestamp When The Subscription Was Last Updated.
+	UpdatedAt time.Time `json:"updated_at" example:"2025-09-01T12:30:00Z"`
+}
+
+// Before Create Ensures UUID Is Set Automatically :
+func (subscription *WebhookSubscription) BeforeCreate(tx *gorm.DB) (err error) {
+
+	if subscription.ID == uuid.Nil {
+
+		subscription.ID = uuid.New()
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package models
+
+// Create Subscription Req Represents The Payload For Registering A Webhook :
+type CreateSubscriptionReq struct {
+	URL        string   `json:"url" binding:"required,url" example:"https://partner.example.com/hooks"`
+	EventTypes []string `json:"event_types" binding:"required,min=1" example:"user.created,user.group_changed"`
+	Secret     string   `json:"secret" binding:"required" example:"a-long-random-shared-secret"`
+	Active     *bool    `json:"active,omitempty" example:"true"`
+}
+
+// Update Subscription Req Represents The Payload For Changing A Webhook ( All Fields Optional ) :
+type UpdateSubscriptionReq struct {
+	URL        *string  `json:"url,omitempty" example:"https://partner.example.com/hooks"`
+	EventTypes []string `json:"event_types,omitempty" example:"user.created"`
+	Secret     *string  `json:"secret,omitempty" example:"a-new-long-random-shared-secret"`
+	Active     *bool    `json:"active,omitempty" example:"false"`
+}
@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"backend-task/internal/constants"
+	"backend-task/internal/webhook/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Webhook Repository Interface :
+type WebhookRepository interface {
+	CreateSubscriptionTx(gormDB *gorm.DB, subscription *models.WebhookSubscription) error
+	GetSubscription(context context.Context, id uuid.UUID) (*models.WebhookSubscription, error)
+	ListSubscriptions(context context.Context) ([]*models.WebhookSubscription, error)
+	ListActiveSubscriptions(context context.Context) ([]*models.WebhookSubscription, error)
+	UpdateSubscriptionTx(gormDB *gorm.DB, subscription *models.WebhookSubscription) error
+	DeleteSubscriptionTx(gormDB *gorm.DB, id uuid.UUID) error
+
+	CreateDeliveries(context context.Context, deliveries []*models.WebhookDelivery) error
+	GetDelivery(context context.Context, id uuid.UUID) (*models.WebhookDelivery, error)
+	FetchDueDeliveries(context context.Context, now time.Time, limit int) ([]*models.WebhookDelivery, error)
+	ListDeliveries(context context.Context, subscriptionID uuid.UUID) ([]*models.WebhookDelivery, error)
+	SaveAttempt(context context.Context, delivery *models.WebhookDelivery, attempt *models.WebhookAttempt) error
+	ListAttempts(context context.Context, deliveryID uuid.UUID) ([]*models.WebhookAttempt, error)
+	ResetDelivery(context context.Context, id uuid.UUID, now time.Time) error
+}
+
+// WebhookRepositoryDB Implementation :
+type WebhookRepositoryDB struct {
+	gormDB *gorm.DB
+}
+
+// Constructor :
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+
+	return &WebhookRepositoryDB{gormDB: db}
+}
+
+// ---------------- Subscriptions ----------------
+
+// CreateSubscriptionTx, UpdateSubscriptionTx And DeleteSubscriptionTx Use The Caller's Transaction ( Shared With The Audit Entry ) :
+func (webhookRepositoryDB *WebhookRepositoryDB) CreateSubscriptionTx(gormDB *gorm.DB, subscription *models.WebhookSubscription) error {
+
+	return gormDB.Create(subscription).Error
+}
+
+func (webhookRepositoryDB *WebhookRepositoryDB) GetSubscription(context context.Context, id uuid.UUID) (*models.WebhookSubscription, error) {
+
+	var subscription models.WebhookSubscription
+	if err := webhookRepositoryDB.gormDB.WithContext(context).First(&subscription, "id = ?", id).Error; err != nil {
+
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+func (webhookRepositoryDB *WebhookRepositoryDB) ListSubscriptions(context context.Context) ([]*models.WebhookSubscription, error) {
+
+	var subscriptions []*models.WebhookSubscription
+	if err := webhookRepositoryDB.gormDB.WithContext(context).Order("created_at asc").Find(&subscriptions).Error; err != nil {
+
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+func (webhookRepositoryDB *WebhookRepositoryDB) ListActiveSubscriptions(context context.Context) ([]*models.WebhookSubscription, error) {
+
+	var subscriptions []*models.WebhookSubscription
+	if err := webhookRepositoryDB.gormDB.WithContext(context).Where("active = ?", true).Find(&subscriptions).Error; err != nil {
+
+		return nil, fmt.Errorf("failed to list active webhook subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+func (webhookRepositoryDB *WebhookRepositoryDB) UpdateSubscriptionTx(gormDB *gorm.DB, subscription *models.WebhookSubscription) error {
+
+	return gormDB.Model(subscription).
+		Select("url", "event_types", "secret", "active").
+		Updates(subscription).Error
+}
+
+// DeleteSubscriptionTx Removes The Subscription With Its Deliveries And Their Attempts :
+func (webhookRepositoryDB *WebhookRepositoryDB) DeleteSubscriptionTx(gormDB *gorm.DB, id uuid.UUID) error {
+
+	deliveryIDs := gormDB.Model(&models.WebhookDelivery{}).Select("id").Where("subscription_id = ?", id)
+	if err := gormDB.Where("delivery_id IN (?)", deliveryIDs).Delete(&models.WebhookAttempt{}).Error; err != nil {
+
+		return err
+	}
+
+	if err := gormDB.Where("subscription_id = ?", id).Delete(&models.WebhookDelivery{}).Error; err != nil {
+
+		return err
+	}
+
+	return gormDB.Delete(&models.WebhookSubscription{}, "id = ?", id).Error
+}
+
+// ---------------- Deliveries ----------------
+
+// CreateDeliveries Inserts Deliveries, Ignoring Ones Already Created For The Same ( Subscription, Event ) Pair.
+// This Keeps Fan-Out Idempotent When The Outbox Relay Redelivers An Event.
+func (webhookRepositoryDB *WebhookRepositoryDB) CreateDeliveries(context context.Context, deliveries []*models.WebhookDelivery) error {
+
+	if len(deliveries) == 0 {
+
+		return nil
+	}
+
+	return webhookRepositoryDB.gormDB.WithContext(context).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "subscription_id"}, {Name: "event_id"}}, DoNothing: true}).
+		Create(&deliveries).Error
+}
+
+func (webhookRepositoryDB *WebhookRepositoryDB) GetDelivery(context context.Context, id uuid.UUID) (*models.WebhookDelivery, error) {
+
+	var delivery models.WebhookDelivery
+	if err := webhookRepositoryDB.gormDB.WithContext(context).First(&delivery, "id = ?", id).Error; err != nil {
+
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+func (webhookRepositoryDB *WebhookRepositoryDB) FetchDueDeliveries(context context.Context, now time.Time, limit int) ([]*models.WebhookDelivery, error) {
+
+	var deliveries []*models.WebhookDelivery
+	if err := webhookRepositoryDB.gormDB.WithContext(context).
+		Where("status = ? AND next_attempt_at <= ?", constants.WebhookDeliveryPending, now).
+		Order("next_attempt_at asc").
+		Limit(limit).
+		Find(&deliveries).Error; err != nil {
+
+		return nil, fmt.Errorf("failed to fetch due webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func (webhookRepositoryDB *WebhookRepositoryDB) ListDeliveries(context context.Context, subscriptionID uuid.UUID) ([]*models.WebhookDelivery, error) {
+
+	var deliveries []*models.WebhookDelivery
+	if err := webhookRepositoryDB.gormDB.WithContext(context).
+		Where("subscription_id = ?", subscriptionID).
+		Order("created_at desc").
+		Find(&deliveries).Error; err != nil {
+
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// SaveAttempt Stores The Attempt And The Resulting Delivery State Atomically :
+func (webhookRepositoryDB *WebhookRepositoryDB) SaveAttempt(context context.Context, delivery *models.WebhookDelivery, attempt *models.WebhookAttempt) error {
+
+	return webhookRepositoryDB.gormDB.WithContext(context).Transaction(func(gormDB *gorm.DB) error {
+
+		if err := gormDB.Create(attempt).Error; err != nil {
+
+			return err
+		}
+
+		return gormDB.Model(delivery).
+			Select("status", "attempts", "next_attempt_at", "last_status_code", "last_error").
+			Updates(delivery).Error
+	})
+}
+
+func (webhookRepositoryDB *WebhookRepositoryDB) ListAttempts(context context.Context, deliveryID uuid.UUID) ([]*models.WebhookAttempt, error) {
+
+	var attempts []*models.WebhookAttempt
+	if err := webhookRepositoryDB.gormDB.WithContext(context).
+		Where("delivery_id = ?", deliveryID).
+		Order("attempt asc").
+		Find(&attempts).Error; err != nil {
+
+		return nil, fmt.Errorf("failed to list webhook attempts: %w", err)
+	}
+
+	return attempts, nil
+}
+
+// ResetDelivery Moves A ( Dead ) Delivery Back To Pending For Manual Redelivery :
+func (webhookRepositoryDB *WebhookRepositoryDB) ResetDelivery(context context.Context, id uuid.UUID, now time.Time) error {
+
+	return webhookRepositoryDB.gormDB.WithContext(context).Model(&models.WebhookDelivery{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"status": constants.WebhookDeliveryPending, "attempts": 0, "next_attempt_at": now}).Error
+}
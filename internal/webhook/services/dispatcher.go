@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"backend-task/internal/constants"
+	eventModels "backend-task/internal/events/models"
+	"backend-task/internal/tenant"
+	"backend-task/internal/webhook/models"
+	"backend-task/internal/webhook/repository"
+)
+
+// Dispatcher Is An Event Publisher That Fans Each Envelope Out Into One Pending
+// Delivery Per Matching Active Subscription. The Worker Performs The HTTP Calls.
+type Dispatcher struct {
+	webhooks repository.WebhookRepository
+	now      func() time.Time
+}
+
+func NewDispatcher(webhooks repository.WebhookRepository, now func() time.Time) *Dispatcher {
+
+	if now == nil {
+
+		now = time.Now
+	}
+
+	return &Dispatcher{webhooks: webhooks, now: now}
+}
+
+// Publish Implements publisher.Publisher. Only Subscriptions Of The Event's Tenant Receive It :
+func (dispatcher *Dispatcher) Publish(context context.Context, envelope eventModels.Envelope) error {
+
+	context = tenant.WithID(context, envelope.TenantID)
+
+	subscriptions, err := dispatcher.webhooks.ListActiveSubscriptions(context)
+	if err != nil {
+
+		return err
+	}
+
+	body, err := json.Marshal(envelope)
+	if err != nil {
+
+		return err
+	}
+
+	var deliveries []*models.WebhookDelivery
+	for _, subscription := range subscriptions {
+
+		if !subscription.EventTypes.Contains(envelope.Type, constants.WebhookAllEvents) {
+
+			continue
+		}
+
+		deliveries = append(deliveries, &models.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        envelope.ID,
+			EventType:      envelope.Type,
+			Payload:        string(body),
+			Status:         constants.WebhookDeliveryPending,
+			NextAttemptAt:  dispatcher.now().UTC(),
+		})
+	}
+
+	return dispatcher.webhooks.CreateDeliveries(context, deliveries)
+}
@@ -0,0 +1,35 @@
+package serviceInterface
+
+import (
+	"context"
+
+	"backend-task/internal/webhook/models"
+)
+
+// Webhook Service Defines Subscription Management And Delivery Inspection :
+type WebhookService interface {
+
+	// CreateSubscription Registers A New Webhook.
+	CreateSubscription(context context.Context, req models.CreateSubscriptionReq) (*models.WebhookSubscription, error)
+
+	// GetSubscription Retrieves A Webhook By UUID.
+	GetSubscription(context context.Context, id string) (*models.WebhookSubscription, error)
+
+	// ListSubscriptions Lists All Webhooks.
+	ListSubscriptions(context context.Context) ([]*models.WebhookSubscription, error)
+
+	// UpdateSubscription Changes The URL, Event Types, Secret And / Or Active Flag.
+	UpdateSubscription(context context.Context, id string, req models.UpdateSubscriptionReq) (*models.WebhookSubscription, error)
+
+	// DeleteSubscription Removes A Webhook Together With Its Delivery History.
+	DeleteSubscription(context context.Context, id string) error
+
+	// ListDeliveries Lists The Deliveries Of A Webhook, Newest First.
+	ListDeliveries(context context.Context, subscriptionID string) ([]*models.WebhookDelivery, error)
+
+	// ListAttempts Lists Every Attempt Made For A Delivery.
+	ListAttempts(context context.Context, deliveryID string) ([]*models.WebhookAttempt, error)
+
+	// Redeliver Schedules A ( Dead ) Delivery For Immediate Retry.
+	Redeliver(context context.Context, deliveryID string) error
+}
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	auditServiceInterface "backend-task/internal/audit/services/interface"
+	"backend-task/internal/constants"
+	"backend-task/internal/utils"
+	"backend-task/internal/webhook/models"
+	"backend-task/internal/webhook/repository"
+	webhookServiceInterface "backend-task/internal/webhook/services/interface"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Event Types A Webhook May Subscribe To :
+var subscribableEventTypes = map[string]bool{
+	constants.WebhookAllEvents:      true,
+	constants.EventUserCreated:      true,
+	constants.EventUserUpdated:      true,
+	constants.EventUserGroupChanged: true,
+	constants.EventUserMerged:       true,
+	constants.EventGroupCreated:     true,
+	constants.EventGroupFull:        true,
+}
+
+type WebhookService struct {
+	db       *gorm.DB
+	webhooks repository.WebhookRepository
+	audit    auditServiceInterface.AuditService
+}
+
+func NewWebhookService(db *gorm.DB, webhooks repository.WebhookRepository, audit auditServiceInterface.AuditService) webhookServiceInterface.WebhookService {
+
+	return &WebhookService{db: db, webhooks: webhooks, audit: audit}
+}
+
+// ---------------- Subscriptions ----------------
+
+func (webhookService *WebhookService) CreateSubscription(context context.Context, req models.CreateSubscriptionReq) (*models.WebhookSubscription, error) {
+
+	subscription := &models.WebhookSubscription{
+		URL:        strings.TrimSpace(req.URL),
+		EventTypes: models.StringList(req.EventTypes),
+		Secret:     req.Secret,
+		Active:     req.Active == nil || *req.Active,
+	}
+
+	if err := validateSubscription(subscription); err != nil {
+
+		return nil, err
+	}
+
+	err := webhookService.db.WithContext(context).Transaction(func(gormDB *gorm.DB) error {
+
+		if err := webhookService.webhooks.CreateSubscriptionTx(gormDB, subscription); err != nil {
+
+			return err
+		}
+
+		return webhookService.audit.RecordTx(context, gormDB, constants.AuditActionCreate, constants.AuditTargetWebhook, subscription.ID.String(), nil, auditSnapshot(subscription, true))
+	})
+	if err != nil {
+
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (webhookService *WebhookService) GetSubscription(context context.Context, id string) (*models.WebhookSubscription, error) {
+
+	uid, err := uuid.Parse(id)
+	if err != nil {
+
+		return nil, utils.NewBadRequest(utils.ErrInvalidID)
+	}
+
+	subscription, err := webhookService.webhooks.GetSubscription(context, uid)
+	if err != nil {
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+
+			return nil, utils.NewNotFound(utils.ErrWebhookNotFound)
+		}
+
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (webhookService *WebhookService) ListSubscriptions(context context.Context) ([]*models.WebhookSubscription, error) {
+
+	return webhookService.webhooks.ListSubscriptions(context)
+}
+
+func (webhookService *WebhookService) UpdateSubscription(context context.Context, id string, req models.UpdateSubscriptionReq) (*models.WebhookSubscription, error) {
+
+	subscription, err := webhookService.GetSubscription(context, id)
+	if err != nil {
+
+		return nil, err
+	}
+
+	before := *subscription
+	if req.URL != nil {
+
+		subscription.URL = strings.TrimSpace(*req.URL)
+	}
+
+	if req.EventTypes != nil {
+
+		subscription.EventTypes = models.StringList(req.EventTypes)
+	}
+
+	if req.Secret != nil {
+
+		subscription.Secret = *req.Secret
+	}
+
+	if req.Active != nil {
+
+		subscription.Active = *req.Active
+	}
+
+	if err := validateSubscription(subscription); err != nil {
+
+		return nil, err
+	}
+
+	err = webhookService.db.WithContext(context).Transaction(func(gormDB *gorm.DB) error {
+
+		if err := webhookService.webhooks.UpdateSubscriptionTx(gormDB, subscription); err != nil {
+
+			return err
+		}
+
+		return webhookService.audit.RecordTx(context, gormDB, constants.AuditActionUpdate, constants.AuditTargetWebhook, subscription.ID.String(),
+			auditSnapshot(&before, false), auditSnapshot(subscription, subscription.Secret != before.Secret))
+	})
+	if err != nil {
+
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (webhookService *WebhookService) DeleteSubscription(context context.Context, id string) error {
+
+	subscription, err := webhookService.GetSubscription(context, id)
+	if err != nil {
+
+		return err
+	}
+
+	return webhookService.db.WithContext(context).Transaction(func(gormDB *gorm.DB) error {
+
+		if err := webhookService.webhooks.DeleteSubscriptionTx(gormDB, subscription.ID); err != nil {
+
+			return err
+		}
+
+		return webhookService.audit.RecordTx(context, gormDB, constants.AuditActionDelete, constants.AuditTargetWebhook, subscription.ID.String(), auditSnapshot(subscription, false), nil)
+	})
+}
+
+// ---------------- Deliveries ----------------
+
+func (webhookService *WebhookService) ListDeliveries(context context.Context, subscriptionID string) ([]*models.WebhookDelivery, error) {
+
+	subscription, err := webhookService.GetSubscription(context, subscriptionID)
+	if err != nil {
+
+		return nil, err
+	}
+
+	return webhookService.webhooks.ListDeliveries(context, subscription.ID)
+}
+
+func (webhookService *WebhookService) ListAttempts(context context.Context, deliveryID string) ([]*models.WebhookAttempt, error) {
+
+	delivery, err := webhookService.getDelivery(context, deliveryID)
+	if err != nil {
+
+		return nil, err
+	}
+
+	return webhookService.webhooks.ListAttempts(context, delivery.ID)
+}
+
+func (webhookService *WebhookService) Redeliver(context context.Context, deliveryID string) error {
+
+	delivery, err := webhookService.getDelivery(context, deliveryID)
+	if err != nil {
+
+		return err
+	}
+
+	return webhookService.webhooks.ResetDelivery(context, delivery.ID, time.Now().UTC())
+}
+
+// ---------------- Helper ----------------
+
+func (webhookService *WebhookService) getDelivery(context context.Context, id string) (*models.WebhookDelivery, error) {
+
+	uid, err := uuid.Parse(id)
+	if err != nil {
+
+		return nil, utils.NewBadRequest(utils.ErrInvalidID)
+	}
+
+	delivery, err := webhookService.webhooks.GetDelivery(context, uid)
+	if err != nil {
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+
+			return nil, utils.NewNotFound(utils.ErrWebhookDeliveryNotFound)
+		}
+
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// auditedSubscription Is What The Audit Trail Keeps Of A Subscription : The Secret Only Shows ( Masked ) When It Was Set.
+type auditedSubscription struct {
+	*models.WebhookSubscription
+	Secret string `json:"secret,omitempty"`
+}
+
+// auditSnapshot Never Carries The Secret Itself; secretSet Marks It As Set Or Changed :
+func auditSnapshot(subscription *models.WebhookSubscription, secretSet bool) *auditedSubscription {
+
+	snapshot := &auditedSubscription{WebhookSubscription: subscription}
+	if secretSet {
+
+		snapshot.Secret = constants.AuditMaskValue
+	}
+
+	return snapshot
+}
+
+func validateSubscription(subscription *models.WebhookSubscription) error {
+
+	parsed, err := url.Parse(subscription.URL)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+
+		return utils.NewBadRequest(utils.ErrInvalidWebhookURL)
+	}
+
+	if len(subscription.Secret) < constants.WebhookMinSecretLength {
+
+		return utils.NewBadRequest(utils.ErrWebhookSecretTooShort)
+	}
+
+	if len(subscription.EventTypes) == 0 {
+
+		return utils.NewBadRequest(utils.ErrUnknownEventType)
+	}
+
+	for _, eventType := range subscription.EventTypes {
+
+		if !subscribableEventTypes[eventType] {
+
+			return utils.NewBadRequest(utils.ErrUnknownEventType)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"backend-task/internal/constants"
+	"backend-task/internal/tenant"
+	"backend-task/internal/utils"
+	"backend-task/internal/webhook/models"
+	"backend-task/internal/webhook/repository"
+
+	"gorm.io/gorm"
+)
+
+// Worker Config Tunes Delivery Retries :
+type WorkerConfig struct {
+	MaxAttempts  int           // Attempts Before The Delivery Is Dead Lettered.
+	BaseBackoff  time.Duration // Delay After The First Failure ( Doubled Each Time ).
+	MaxBackoff   time.Duration // Upper Bound For The Delay.
+	PollInterval time.Duration
+	BatchSize    int
+	Client       *http.Client
+	Now          func() time.Time // Injectable Clock.
+}
+
+// Worker Delivers Due Webhook Deliveries, Retrying With Exponential Backoff :
+type Worker struct {
+	webhooks repository.WebhookRepository
+	config   WorkerConfig
+}
+
+func NewWorker(webhooks repository.WebhookRepository, config WorkerConfig) *Worker {
+
+	if config.Now == nil {
+
+		config.Now = time.Now
+	}
+
+	if config.Client == nil {
+
+		config.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	if config.BatchSize <= 0 {
+
+		config.BatchSize = constants.DefaultWebhookBatchSize
+	}
+
+	if config.MaxAttempts <= 0 {
+
+		config.MaxAttempts = constants.DefaultWebhookMaxAttempts
+	}
+
+	return &Worker{webhooks: webhooks, config: config}
+}
+
+// Run Delivers Due Deliveries Until The Context Is Cancelled :
+func (worker *Worker) Run(context context.Context) {
+
+	ticker := time.NewTicker(worker.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+
+		if _, err := worker.DeliverDue(context); err != nil {
+
+			utils.Error(fmt.Sprintf("webhook worker: %v", err))
+		}
+
+		select {
+		case <-context.Done():
+			return
+
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverDue Attempts Every Delivery Whose Next Attempt Is Due ( Of Every Tenant ) And Returns How Many Were Attempted :
+func (worker *Worker) DeliverDue(context context.Context) (int, error) {
+
+	deliveries, err := worker.webhooks.FetchDueDeliveries(tenant.AllTenants(context), worker.config.Now().UTC(), worker.config.BatchSize)
+	if err != nil {
+
+		return 0, err
+	}
+
+	for _, delivery := range deliveries {
+
+		if err := worker.attempt(tenant.WithID(context, delivery.TenantID), delivery); err != nil {
+
+			return 0, err
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+func (worker *Worker) attempt(context context.Context, delivery *models.WebhookDelivery) error {
+
+	subscription, err := worker.webhooks.GetSubscription(context, delivery.SubscriptionID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+
+		return err
+	}
+
+	started := worker.config.Now()
+	statusCode, callErr := 0, error(nil)
+
+	switch {
+	case subscription == nil:
+		callErr = errors.New("subscription deleted")
+
+	case !subscription.Active:
+		callErr = errors.New("subscription inactive")
+
+	default:
+		statusCode, callErr = worker.post(context, subscription, delivery)
+	}
+
+	delivery.Attempts++
+	delivery.LastStatusCode = statusCode
+	delivery.LastError = ""
+
+	attempt := &models.WebhookAttempt{
+		DeliveryID: delivery.ID,
+		Attempt:    delivery.Attempts,
+		StatusCode: statusCode,
+		DurationMs: worker.config.Now().Sub(started).Milliseconds(),
+	}
+
+	switch {
+	case callErr == nil:
+		delivery.Status = constants.WebhookDeliverySucceeded
+
+	case delivery.Attempts >= worker.config.MaxAttempts || subscription == nil:
+		delivery.Status = constants.WebhookDeliveryDead
+		delivery.LastError = truncate(callErr.Error())
+		attempt.Error = delivery.LastError
+
+	default:
+		delivery.NextAttemptAt = worker.config.Now().UTC().Add(worker.backoff(delivery.Attempts))
+		delivery.LastError = truncate(callErr.Error())
+		attempt.Error = delivery.LastError
+	}
+
+	return worker.webhooks.SaveAttempt(context, delivery, attempt)
+}
+
+// post Sends The Signed Payload; Any Non-2xx Response Counts As A Failure :
+func (worker *Worker) post(context context.Context, subscription *models.WebhookSubscription, delivery *models.WebhookDelivery) (int, error) {
+
+	body := []byte(delivery.Payload)
+
+	request, err := http.NewRequestWithContext(context, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+
+		return 0, err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(constants.HeaderWebhookSignature, Sign(subscription.Secret, worker.config.Now(), body))
+	request.Header.Set(constants.HeaderWebhookEvent, delivery.EventType)
+	request.Header.Set(constants.HeaderWebhookEventID, delivery.EventID.String())
+	request.Header.Set(constants.HeaderWebhookDelivery, delivery.ID.String())
+
+	response, err := worker.config.Client.Do(request)
+	if err != nil {
+
+		return 0, err
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(response.Body, 64<<10))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+
+		return response.StatusCode, fmt.Errorf("unexpected status %d", response.StatusCode)
+	}
+
+	return response.StatusCode, nil
+}
+
+// backoff Returns BaseBackoff * 2^( attempts-1 ), Capped At MaxBackoff :
+func (worker *Worker) backoff(attempts int) time.Duration {
+
+	delay := worker.config.BaseBackoff
+	for i := 1; i < attempts && delay < worker.config.MaxBackoff; i++ {
+
+		delay *= 2
+	}
+
+	if worker.config.MaxBackoff > 0 && delay > worker.config.MaxBackoff {
+
+		delay = worker.config.MaxBackoff
+	}
+
+	return delay
+}
+
+func truncate(message string) string {
+
+	if len(message) > 1024 {
+
+		return message[:1024]
+	}
+
+	return message
+}
@@ -0,0 +1,344 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	authModels "backend-task/internal/auth/models"
+	authRepository "backend-task/internal/auth/repository"
+	authServices "backend-task/internal/auth/services"
+	"backend-task/internal/constants"
+	userModels "backend-task/internal/user/models"
+	userRepository "backend-task/internal/user/repository"
+	"backend-task/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+const testPassword = "correct-horse-battery"
+
+// registerTestAccount Registers Through The Public Endpoint, Which Does Not Return The User,
+// And Looks The New User Up As An Admin :
+func registerTestAccount(testingT *testing.T, server http.Handler, email string) userModels.User {
+
+	testingT.Helper()
+
+	resp := serveWithToken(server, http.MethodPost, "/api/v1/auth/register", "", `{"name":"Member","email":"`+email+`","date_of_birth":"1990-01-01","password":"`+testPassword+`"}`)
+	require.Equal(testingT, http.StatusAccepted, resp.Code, resp.Body.String())
+
+	resp = serveWithToken(server, http.MethodGet, "/api/v1/users", signHS256(testingT, newTestClaims("admin-1", constants.RoleAdmin)), "")
+	require.Equal(testingT, http.StatusOK, resp.Code, resp.Body.String())
+
+	var users []userModels.User
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &users))
+
+	for _, user := range users {
+
+		if user.Email == email {
+
+			return user
+		}
+	}
+
+	testingT.Fatalf("registered user %s not found", email)
+	return userModels.User{}
+}
+
+func loginTestAccount(testingT *testing.T, server http.Handler, email, password string) (int, authModels.TokenPair) {
+
+	testingT.Helper()
+
+	resp := serveWithToken(server, http.MethodPost, "/api/v1/auth/login", "", `{"email":"`+email+`","password":"`+password+`"}`)
+
+	var tokens authModels.TokenPair
+	if resp.Code == http.StatusOK {
+
+		require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &tokens))
+	}
+
+	return resp.Code, tokens
+}
+
+func refreshTestTokens(server http.Handler, refreshToken string) (int, authModels.TokenPair) {
+
+	resp := serveWithToken(server, http.MethodPost, "/api/v1/auth/refresh", "", `{"refresh_token":"`+refreshToken+`"}`)
+
+	var tokens authModels.TokenPair
+	json.Unmarshal(resp.Body.Bytes(), &tokens)
+
+	return resp.Code, tokens
+}
+
+func TestPasswordLoginGrantsSelfServiceAccess(testingT *testing.T) {
+
+	env, server := newAuthTestServer(testingT)
+
+	user := registerTestAccount(testingT, server, "member@test.com")
+	other, err := env.users.CreateUser(context.Background(), "Other", "other@test.com", "1990-01-01")
+	require.NoError(testingT, err)
+
+	// Email Matching Is Case Insensitive :
+	status, tokens := loginTestAccount(testingT, server, " Member@Test.com", testPassword)
+	require.Equal(testingT, http.StatusOK, status)
+	assert.Equal(testingT, constants.TokenTypeBearer, tokens.TokenType)
+	assert.Equal(testingT, int64(15*60), tokens.ExpiresIn)
+
+	assert.Equal(testingT, http.StatusOK, serveWithToken(server, http.MethodGet, "/api/v1/users/"+user.ID.String(), tokens.AccessToken, "").Code)
+	assert.Equal(testingT, http.StatusOK, serveWithToken(server, http.MethodPatch, "/api/v1/users/"+user.ID.String(), tokens.AccessToken, `{"name":"Renamed"}`).Code)
+	assert.Equal(testingT, http.StatusForbidden, serveWithToken(server, http.MethodGet, "/api/v1/users/"+other.ID.String(), tokens.AccessToken, "").Code)
+	assert.Equal(testingT, http.StatusOK, serveWithToken(server, http.MethodGet, "/api/v1/users/"+user.ID.String()+"/export", tokens.AccessToken, "").Code)
+	assert.Equal(testingT, http.StatusForbidden, serveWithToken(server, http.MethodGet, "/api/v1/users/"+other.ID.String()+"/export", tokens.AccessToken, "").Code)
+	assert.Equal(testingT, http.StatusForbidden, serveWithToken(server, http.MethodGet, "/api/v1/users", tokens.AccessToken, "").Code)
+}
+
+func TestLoginFailuresDoNotRevealAccounts(testingT *testing.T) {
+
+	env, server := newAuthTestServer(testingT)
+
+	registerTestAccount(testingT, server, "member@test.com")
+	_, err := env.users.CreateUser(context.Background(), "No Password", "nopassword@test.com", "1990-01-01")
+	require.NoError(testingT, err)
+
+	wrongPassword := serveWithToken(server, http.MethodPost, "/api/v1/auth/login", "", `{"email":"member@test.com","password":"wrong-password"}`)
+	unknownEmail := serveWithToken(server, http.MethodPost, "/api/v1/auth/login", "", `{"email":"ghost@test.com","password":"wrong-password"}`)
+	noPassword := serveWithToken(server, http.MethodPost, "/api/v1/auth/login", "", `{"email":"nopassword@test.com","password":"wrong-password"}`)
+
+	assert.Equal(testingT, http.StatusUnauthorized, wrongPassword.Code)
+	assert.Equal(testingT, wrongPassword.Code, unknownEmail.Code)
+	assert.Equal(testingT, wrongPassword.Code, noPassword.Code)
+
+	// Only The Request ID Differs :
+	expected := decodeProblem(testingT, wrongPassword)
+	expected.RequestID = ""
+	for _, resp := range []*httptest.ResponseRecorder{unknownEmail, noPassword} {
+
+		problem := decodeProblem(testingT, resp)
+		problem.RequestID = ""
+		assert.Equal(testingT, expected, problem)
+	}
+}
+
+func TestRegistrationDoesNotRevealAccounts(testingT *testing.T) {
+
+	env, server := newAuthTestServer(testingT)
+
+	register := func(email string) *httptest.ResponseRecorder {
+
+		return serveWithToken(server, http.MethodPost, "/api/v1/auth/register", "", `{"name":"Member","email":"`+email+`","date_of_birth":"1990-01-01","password":"`+testPassword+`"}`)
+	}
+
+	fresh := register("member@test.com")
+	taken := register("Member@Test.com")
+
+	assert.Equal(testingT, http.StatusAccepted, fresh.Code, fresh.Body.String())
+	assert.Equal(testingT, fresh.Code, taken.Code)
+	assert.Equal(testingT, fresh.Body.String(), taken.Body.String())
+
+	// The Owner Hears About The Attempt, And The Account Keeps Its Password :
+	messages := env.mailer.Messages()
+	require.Len(testingT, messages, 2)
+	assert.Equal(testingT, "Member@Test.com", messages[1].To)
+	assert.Contains(testingT, messages[1].Body, "already belongs to your account")
+
+	status, _ := loginTestAccount(testingT, server, "member@test.com", testPassword)
+	assert.Equal(testingT, http.StatusOK, status)
+}
+
+// failingCredentials Fails Every Password Write Made Inside A Transaction :
+type failingCredentials struct {
+	authRepository.AccountRepository
+}
+
+func (failingCredentials) SaveCredentialTx(*gorm.DB, *authModels.Credential) error {
+
+	return errors.New("credential write failed")
+}
+
+func TestRegistrationSavesUserAndPasswordTogether(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	repository := authRepository.NewAccountRepository(env.db)
+	ctx := context.Background()
+	req := authModels.RegisterReq{Name: "Member", Email: "member@test.com", DateOfBirth: "1990-01-01", Password: testPassword}
+
+	failing := authServices.NewAccountService(env.db, failingCredentials{repository}, userRepository.NewUserRepository(env.db), env.users, env.audit, nil, authServices.AccountConfig{})
+	_, err := failing.Register(ctx, req)
+	require.Error(testingT, err)
+
+	// No User Without A Password Was Left Behind To Block The Email :
+	exists, err := userRepository.NewUserRepository(env.db).IsEmailExists(ctx, "member@test.com")
+	require.NoError(testingT, err)
+	assert.False(testingT, exists)
+
+	accounts := authServices.NewAccountService(env.db, repository, userRepository.NewUserRepository(env.db), env.users, env.audit, nil, authServices.AccountConfig{})
+	user, err := accounts.Register(ctx, req)
+	require.NoError(testingT, err)
+	require.NotNil(testingT, user)
+
+	_, err = repository.GetCredential(ctx, user.ID)
+	assert.NoError(testingT, err)
+}
+
+func TestAccountLookupsReturnTypedNotFound(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	ctx := context.Background()
+
+	// Callers Match The Sentinels, Never GORM's Own Error :
+	_, err := userRepository.NewUserRepository(env.db).GetUserByEmail(ctx, "nobody@test.com")
+	assert.ErrorIs(testingT, err, utils.ErrUserNotFound)
+	assert.NotErrorIs(testingT, err, gorm.ErrRecordNotFound)
+
+	user, err := env.users.CreateUser(ctx, "No Password", "nopassword@test.com", "1990-01-01")
+	require.NoError(testingT, err)
+
+	_, err = authRepository.NewAccountRepository(env.db).GetCredential(ctx, user.ID)
+	assert.ErrorIs(testingT, err, utils.ErrAccountNotFound)
+	assert.NotErrorIs(testingT, err, gorm.ErrRecordNotFound)
+}
+
+func TestRefreshTokenRotationDetectsReuse(testingT *testing.T) {
+
+	_, server := newAuthTestServer(testingT)
+	user := registerTestAccount(testingT, server, "member@test.com")
+
+	_, first := loginTestAccount(testingT, server, "member@test.com", testPassword)
+
+	status, second := refreshTestTokens(server, first.RefreshToken)
+	require.Equal(testingT, http.StatusOK, status)
+	assert.NotEqual(testingT, first.RefreshToken, second.RefreshToken)
+
+	// Replaying The Rotated Token Revokes The Whole Session :
+	status, _ = refreshTestTokens(server, first.RefreshToken)
+	assert.Equal(testingT, http.StatusUnauthorized, status)
+
+	status, _ = refreshTestTokens(server, second.RefreshToken)
+	assert.Equal(testingT, http.StatusUnauthorized, status)
+	assert.Equal(testingT, http.StatusUnauthorized, serveWithToken(server, http.MethodGet, "/api/v1/users/"+user.ID.String(), second.AccessToken, "").Code)
+
+	// Other Sessions Are Unaffected :
+	status, _ = loginTestAccount(testingT, server, "member@test.com", testPassword)
+	assert.Equal(testingT, http.StatusOK, status)
+}
+
+func TestLogoutRevokesSessionTokens(testingT *testing.T) {
+
+	_, server := newAuthTestServer(testingT)
+	user := registerTestAccount(testingT, server, "member@test.com")
+
+	_, tokens := loginTestAccount(testingT, server, "member@test.com", testPassword)
+	require.Equal(testingT, http.StatusOK, serveWithToken(server, http.MethodGet, "/api/v1/users/"+user.ID.String(), tokens.AccessToken, "").Code)
+
+	resp := serveWithToken(server, http.MethodPost, "/api/v1/auth/logout", "", `{"refresh_token":"`+tokens.RefreshToken+`"}`)
+	assert.Equal(testingT, http.StatusNoContent, resp.Code)
+
+	assert.Equal(testingT, http.StatusUnauthorized, serveWithToken(server, http.MethodGet, "/api/v1/users/"+user.ID.String(), tokens.AccessToken, "").Code)
+
+	status, _ := refreshTestTokens(server, tokens.RefreshToken)
+	assert.Equal(testingT, http.StatusUnauthorized, status)
+}
+
+func TestChangePasswordRevokesSessions(testingT *testing.T) {
+
+	_, server := newAuthTestServer(testingT)
+	registerTestAccount(testingT, server, "member@test.com")
+
+	_, tokens := loginTestAccount(testingT, server, "member@test.com", testPassword)
+
+	resp := serveWithToken(server, http.MethodPut, "/api/v1/auth/password", tokens.AccessToken, `{"current_password":"wrong-password","new_password":"a-brand-new-passphrase"}`)
+	assert.Equal(testingT, http.StatusBadRequest, resp.Code)
+
+	resp = serveWithToken(server, http.MethodPut, "/api/v1/auth/password", tokens.AccessToken, `{"current_password":"`+testPassword+`","new_password":"short"}`)
+	assert.Equal(testingT, http.StatusBadRequest, resp.Code)
+
+	resp = serveWithToken(server, http.MethodPut, "/api/v1/auth/password", tokens.AccessToken, `{"current_password":"`+testPassword+`","new_password":"a-brand-new-passphrase"}`)
+	require.Equal(testingT, http.StatusNoContent, resp.Code, resp.Body.String())
+
+	status, _ := refreshTestTokens(server, tokens.RefreshToken)
+	assert.Equal(testingT, http.StatusUnauthorized, status)
+
+	status, _ = loginTestAccount(testingT, server, "member@test.com", testPassword)
+	assert.Equal(testingT, http.StatusUnauthorized, status)
+
+	status, _ = loginTestAccount(testingT, server, "member@test.com", "a-brand-new-passphrase")
+	assert.Equal(testingT, http.StatusOK, status)
+}
+
+func TestLoginLockoutBacksOff(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	issuer, err := authServices.NewTokenIssuer(authServices.TokenIssuerConfig{
+		HMACKeyFile: writeTestFile(testingT, "hmac.key", []byte(testJWTSecret)),
+		TTL:         time.Minute,
+		Now:         clock,
+	})
+	require.NoError(testingT, err)
+
+	accounts := authServices.NewAccountService(env.db, authRepository.NewAccountRepository(env.db), userRepository.NewUserRepository(env.db), env.users, env.audit, issuer, authServices.AccountConfig{
+		RefreshTTL:       time.Hour,
+		LockoutThreshold: 3,
+		LockoutBaseDelay: time.Minute,
+		LockoutMaxDelay:  5 * time.Minute,
+		Now:              clock,
+	})
+
+	ctx := context.Background()
+	_, err = accounts.Register(ctx, authModels.RegisterReq{Name: "Member", Email: "member@test.com", DateOfBirth: "1990-01-01", Password: testPassword})
+	require.NoError(testingT, err)
+
+	login := func(password string) error {
+
+		_, err := accounts.Login(ctx, authModels.LoginReq{Email: "member@test.com", Password: password})
+		return err
+	}
+
+	for i := 0; i < 3; i++ {
+
+		assert.Error(testingT, login("wrong-password"))
+	}
+
+	// Locked For One Minute, Even With The Right Password :
+	assert.Error(testingT, login(testPassword))
+
+	now = now.Add(61 * time.Second)
+	assert.Error(testingT, login("wrong-password")) // Fourth Failure: Locked For Two Minutes.
+
+	now = now.Add(90 * time.Second)
+	assert.Error(testingT, login(testPassword))
+
+	now = now.Add(31 * time.Second)
+	assert.NoError(testingT, login(testPassword))
+
+	// A Success Resets The Counter :
+	assert.Error(testingT, login("wrong-password"))
+	assert.NoError(testingT, login(testPassword))
+}
+
+func TestPasswordHashRoundTrip(testingT *testing.T) {
+
+	hash, err := authServices.HashPassword(testPassword)
+	require.NoError(testingT, err)
+	assert.Contains(testingT, hash, "$argon2id$v=19$")
+
+	matched, err := authServices.VerifyPassword(hash, testPassword)
+	require.NoError(testingT, err)
+	assert.True(testingT, matched)
+
+	matched, err = authServices.VerifyPassword(hash, "wrong-password")
+	require.NoError(testingT, err)
+	assert.False(testingT, matched)
+
+	_, err = authServices.VerifyPassword("$2a$10$notargon", testPassword)
+	assert.Error(testingT, err)
+}
@@ -0,0 +1,175 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	authModels "backend-task/internal/auth/models"
+	"backend-task/internal/constants"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveWithAPIKey(server http.Handler, method, path, key, body string) *httptest.ResponseRecorder {
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(constants.HeaderAPIKey, key)
+	if body != "" {
+
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp := httptest.NewRecorder()
+	server.ServeHTTP(resp, req)
+
+	return resp
+}
+
+func issueTestAPIKey(testingT *testing.T, server http.Handler, admin, body string) authModels.IssuedAPIKey {
+
+	testingT.Helper()
+
+	resp := serveWithToken(server, http.MethodPost, "/api/v1/api-keys", admin, body)
+	require.Equal(testingT, http.StatusCreated, resp.Code, resp.Body.String())
+
+	var issued authModels.IssuedAPIKey
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &issued))
+
+	return issued
+}
+
+func TestAPIKeyLifecycle(testingT *testing.T) {
+
+	_, server := newAuthTestServer(testingT)
+	admin := signHS256(testingT, withMFA(newTestClaims("admin-1", constants.RoleAdmin)))
+
+	// httptest Requests Come From 192.0.2.1 :
+	issued := issueTestAPIKey(testingT, server, admin, `{"name":"nightly-batch","permissions":["users:read"],"allowed_ips":["192.0.2.0/24"]}`)
+	assert.True(testingT, strings.HasPrefix(issued.Key, issued.Prefix+"_"))
+	assert.Equal(testingT, "admin-1", issued.CreatedBy)
+
+	// Scoped To Its Permissions :
+	assert.Equal(testingT, http.StatusOK, serveWithAPIKey(server, http.MethodGet, "/api/v1/users", issued.Key, "").Code)
+	assert.Equal(testingT, http.StatusForbidden, serveWithAPIKey(server, http.MethodPost, "/api/v1/users", issued.Key, `[{"name":"A","email":"a@test.com","date_of_birth":"1990-01-01"}]`).Code)
+	assert.Equal(testingT, http.StatusUnauthorized, serveWithAPIKey(server, http.MethodGet, "/api/v1/users", issued.Key+"x", "").Code)
+
+	// Usage Is Recorded And The Hash Never Leaves The Server :
+	resp := serveWithToken(server, http.MethodGet, "/api/v1/api-keys", admin, "")
+	require.Equal(testingT, http.StatusOK, resp.Code)
+	assert.NotContains(testingT, resp.Body.String(), "hash")
+	assert.NotContains(testingT, resp.Body.String(), issued.Key)
+
+	var listed []authModels.APIKey
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &listed))
+	require.Len(testingT, listed, 1)
+	assert.Equal(testingT, int64(2), listed[0].UsageCount)
+	assert.NotNil(testingT, listed[0].LastUsedAt)
+
+	// Rotation Invalidates The Old Key Immediately :
+	resp = serveWithToken(server, http.MethodPost, "/api/v1/api-keys/"+issued.ID.String()+"/rotate", admin, "")
+	require.Equal(testingT, http.StatusOK, resp.Code, resp.Body.String())
+
+	var rotated authModels.IssuedAPIKey
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &rotated))
+	assert.Equal(testingT, issued.ID, rotated.ID)
+	assert.Equal(testingT, http.StatusUnauthorized, serveWithAPIKey(server, http.MethodGet, "/api/v1/users", issued.Key, "").Code)
+	assert.Equal(testingT, http.StatusOK, serveWithAPIKey(server, http.MethodGet, "/api/v1/users", rotated.Key, "").Code)
+
+	// Revocation Is Final :
+	resp = serveWithToken(server, http.MethodDelete, "/api/v1/api-keys/"+issued.ID.String(), admin, "")
+	assert.Equal(testingT, http.StatusNoContent, resp.Code)
+	assert.Equal(testingT, http.StatusUnauthorized, serveWithAPIKey(server, http.MethodGet, "/api/v1/users", rotated.Key, "").Code)
+	assert.Equal(testingT, http.StatusConflict, serveWithToken(server, http.MethodPost, "/api/v1/api-keys/"+issued.ID.String()+"/rotate", admin, "").Code)
+}
+
+func TestAPIKeyIPAllowlist(testingT *testing.T) {
+
+	_, server := newAuthTestServer(testingT)
+	admin := signHS256(testingT, withMFA(newTestClaims("admin-1", constants.RoleAdmin)))
+
+	issued := issueTestAPIKey(testingT, server, admin, `{"name":"office-only","permissions":["users:read"],"allowed_ips":["10.0.0.0/8","203.0.113.7"]}`)
+
+	resp := serveWithAPIKey(server, http.MethodGet, "/api/v1/users", issued.Key, "")
+	assert.Equal(testingT, http.StatusForbidden, resp.Code)
+	assert.Contains(testingT, resp.Body.String(), "not allowed from this ip")
+
+	// Without Trusted Proxies A Forged X-Forwarded-For Is Ignored :
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users", nil)
+	req.Header.Set(constants.HeaderAPIKey, issued.Key)
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+
+	resp = httptest.NewRecorder()
+	server.ServeHTTP(resp, req)
+	assert.Equal(testingT, http.StatusForbidden, resp.Code)
+}
+
+func TestAPIKeyIPAllowlistBehindTrustedProxy(testingT *testing.T) {
+
+	testingT.Setenv(constants.TRUSTED_PROXIES, "192.0.2.0/24")
+	_, server := newAuthTestServer(testingT)
+	admin := signHS256(testingT, withMFA(newTestClaims("admin-1", constants.RoleAdmin)))
+
+	issued := issueTestAPIKey(testingT, server, admin, `{"name":"office-only","permissions":["users:read"],"allowed_ips":["203.0.113.7"]}`)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users", nil)
+	req.Header.Set(constants.HeaderAPIKey, issued.Key)
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+
+	resp := httptest.NewRecorder()
+	server.ServeHTTP(resp, req)
+	assert.Equal(testingT, http.StatusOK, resp.Code, resp.Body.String())
+}
+
+func TestAPIKeyCannotOutrankItsIssuer(testingT *testing.T) {
+
+	testingT.Setenv(constants.RBAC_CUSTOM_ROLES, `{"keymaster":["apikeys:manage","users:read"]}`)
+	_, server := newAuthTestServer(testingT)
+	admin := signHS256(testingT, withMFA(newTestClaims("admin-1", constants.RoleAdmin)))
+	keymaster := signHS256(testingT, withMFA(newTestClaims("keymaster-1", "keymaster")))
+
+	// Only Permissions The Issuer Holds Can Be Delegated :
+	resp := serveWithToken(server, http.MethodPost, "/api/v1/api-keys", keymaster, `{"name":"k","permissions":["users:read","users:create"]}`)
+	require.Equal(testingT, http.StatusForbidden, resp.Code, resp.Body.String())
+	assert.Equal(testingT, "PERMISSION_DENIED", decodeProblem(testingT, resp).ErrorCode)
+
+	issueTestAPIKey(testingT, server, keymaster, `{"name":"k","permissions":["users:read"]}`)
+
+	// Nor Rotated Into A New Secret :
+	wider := issueTestAPIKey(testingT, server, admin, `{"name":"wide","permissions":["users:read","users:create"]}`)
+	resp = serveWithToken(server, http.MethodPost, "/api/v1/api-keys/"+wider.ID.String()+"/rotate", keymaster, "")
+	assert.Equal(testingT, http.StatusForbidden, resp.Code, resp.Body.String())
+
+	// A Key Is A Single Factor, So Step-Up Protected Permissions Are Refused Even To Admins :
+	for _, permission := range []string{constants.PermissionAuditRead, constants.PermissionUsersMerge, constants.PermissionAccountsManage} {
+
+		resp = serveWithToken(server, http.MethodPost, "/api/v1/api-keys", admin, `{"name":"k","permissions":["`+permission+`"]}`)
+		require.Equal(testingT, http.StatusBadRequest, resp.Code, permission)
+		assert.Equal(testingT, "API_KEY_PERMISSION_REQUIRES_MFA", decodeProblem(testingT, resp).ErrorCode, permission)
+	}
+}
+
+func TestAPIKeyManagementRules(testingT *testing.T) {
+
+	_, server := newAuthTestServer(testingT)
+	admin := signHS256(testingT, withMFA(newTestClaims("admin-1", constants.RoleAdmin)))
+	editor := signHS256(testingT, newTestClaims("editor-1", constants.RoleEditor))
+
+	cases := map[string]string{
+		"unknown permission": `{"name":"k","permissions":["users:everything"]}`,
+		"invalid ip":         `{"name":"k","permissions":["users:read"],"allowed_ips":["not-an-ip"]}`,
+		"blank name":         `{"name":"  ","permissions":["users:read"]}`,
+		"no permissions":     `{"name":"k","permissions":[]}`,
+	}
+
+	for name, body := range cases {
+
+		assert.Equal(testingT, http.StatusBadRequest, serveWithToken(server, http.MethodPost, "/api/v1/api-keys", admin, body).Code, name)
+	}
+
+	assert.Equal(testingT, http.StatusForbidden, serveWithToken(server, http.MethodGet, "/api/v1/api-keys", editor, "").Code)
+	assert.Equal(testingT, http.StatusNotFound, serveWithToken(server, http.MethodDelete, "/api/v1/api-keys/00000000-0000-0000-0000-000000000000", admin, "").Code)
+}
@@ -0,0 +1,180 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	auditHandlers "backend-task/internal/audit/handlers"
+	auditModels "backend-task/internal/audit/models"
+	auditServices "backend-task/internal/audit/services"
+	"backend-task/internal/constants"
+	"backend-task/internal/middleware"
+	"backend-task/internal/utils"
+	webhookModels "backend-task/internal/webhook/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeDiffMasksConfiguredFields(testingT *testing.T) {
+
+	masker := auditServices.NewMasker("email")
+
+	before := map[string]any{"name": "Old", "email": "old@test.com", "updated_at": "t1"}
+	after := map[string]any{"name": "New", "email": "new@test.com", "updated_at": "t2"}
+
+	changes, err := auditServices.ComputeDiff(before, after, masker)
+	require.NoError(testingT, err)
+	require.Len(testingT, changes, 2)
+
+	assert.Equal(testingT, "email", changes[0].Field)
+	assert.Equal(testingT, "o***@test.com", changes[0].Before)
+	assert.Equal(testingT, "n***@test.com", changes[0].After)
+
+	assert.Equal(testingT, "name", changes[1].Field)
+	assert.Equal(testingT, "Old", changes[1].Before)
+	assert.Equal(testingT, "New", changes[1].After)
+}
+
+func TestAuditTrailForUserMutations(testingT *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+	env := newTestServices(testingT)
+	auditService, userService := env.audit, env.users
+
+	ctx := utils.WithRequestMeta(context.Background(), utils.RequestMeta{RequestID: "req-1", ActorID: "admin", ClientIP: "10.0.0.1"})
+
+	user, err := userService.CreateUser(ctx, "Abudalou", "abudalou@test.com", "2000-01-04")
+	require.NoError(testingT, err)
+
+	name := "Abudalou Updated"
+	_, err = userService.UpdateUser(ctx, user.ID.String(), &name, nil)
+	require.NoError(testingT, err)
+
+	// Query Through The HTTP Endpoint :
+	route := gin.New()
+	route.Use(middleware.RequestContext())
+	route.GET("/audit", auditHandlers.NewAuditHandler(auditService).QueryAudit)
+
+	resp := httptest.NewRecorder()
+	route.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/audit?target_id="+user.ID.String()+"&page_size=10", nil))
+	require.Equal(testingT, http.StatusOK, resp.Code)
+
+	var page auditModels.AuditPage
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &page))
+	require.EqualValues(testingT, 2, page.Total)
+
+	update, create := page.Items[0], page.Items[1]
+	assert.Equal(testingT, constants.AuditActionUpdate, update.Action)
+	assert.Equal(testingT, "admin", update.ActorID)
+	assert.Equal(testingT, "req-1", update.RequestID)
+	assert.Equal(testingT, "10.0.0.1", update.ClientIP)
+	require.Len(testingT, update.Changes, 1)
+	assert.Equal(testingT, "name", update.Changes[0].Field)
+
+	assert.Equal(testingT, constants.AuditActionCreate, create.Action)
+	for _, change := range create.Changes {
+
+		if change.Field == "email" {
+
+			assert.Equal(testingT, "a***@test.com", change.After)
+		}
+	}
+
+	// Invalid Time Filters Are Rejected :
+	resp = httptest.NewRecorder()
+	route.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/audit?from=yesterday", nil))
+	assert.Equal(testingT, http.StatusBadRequest, resp.Code)
+}
+
+// queryAudit Returns The Audit Entries About One Target, Newest First :
+func queryAudit(testingT *testing.T, server http.Handler, token, targetType, targetID string) (auditModels.AuditPage, string) {
+
+	testingT.Helper()
+
+	resp := serveWithToken(server, http.MethodGet, "/api/v1/audit?target_type="+targetType+"&target_id="+targetID, token, "")
+	require.Equal(testingT, http.StatusOK, resp.Code, resp.Body.String())
+
+	var page auditModels.AuditPage
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &page))
+
+	return page, resp.Body.String()
+}
+
+// changeOf Returns The Change Recorded For A Field, Or nil :
+func changeOf(entry *auditModels.AuditEntry, field string) *auditModels.FieldChange {
+
+	for index := range entry.Changes {
+
+		if entry.Changes[index].Field == field {
+
+			return &entry.Changes[index]
+		}
+	}
+
+	return nil
+}
+
+func TestAuditTrailForAdminActions(testingT *testing.T) {
+
+	_, server := newAuthTestServer(testingT)
+	admin := signHS256(testingT, withMFA(newTestClaims("admin-1", constants.RoleAdmin)))
+
+	// API Keys : Issue And Revoke, Never The Secret :
+	issued := issueTestAPIKey(testingT, server, admin, `{"name":"nightly-batch","permissions":["users:read"]}`)
+	require.Equal(testingT, http.StatusNoContent, serveWithToken(server, http.MethodDelete, "/api/v1/api-keys/"+issued.ID.String(), admin, "").Code)
+
+	page, body := queryAudit(testingT, server, admin, constants.AuditTargetAPIKey, issued.ID.String())
+	require.Len(testingT, page.Items, 2)
+	assert.NotContains(testingT, body, issued.Key)
+	assert.NotContains(testingT, body, `"hash"`)
+
+	revoke, issue := page.Items[0], page.Items[1]
+	assert.Equal(testingT, constants.AuditActionCreate, issue.Action)
+	assert.Equal(testingT, "admin-1", issue.ActorID)
+	assert.Equal(testingT, issued.Prefix, changeOf(issue, "prefix").After)
+	assert.Equal(testingT, constants.AuditActionUpdate, revoke.Action)
+	require.Len(testingT, revoke.Changes, 1)
+	assert.Equal(testingT, "revoked_at", revoke.Changes[0].Field)
+
+	// Account Roles :
+	user := registerTestAccount(testingT, server, "promoted@test.com")
+	require.Equal(testingT, http.StatusNoContent, serveWithToken(server, http.MethodPut, "/api/v1/auth/accounts/"+user.ID.String()+"/roles", admin, `{"roles":["editor"]}`).Code)
+
+	page, body = queryAudit(testingT, server, admin, constants.AuditTargetAccount, user.ID.String())
+	require.Len(testingT, page.Items, 1)
+	assert.NotContains(testingT, body, "argon2id")
+	require.Len(testingT, page.Items[0].Changes, 1)
+	assert.Equal(testingT, "roles", page.Items[0].Changes[0].Field)
+	assert.Equal(testingT, []any{constants.RoleEditor}, page.Items[0].Changes[0].After)
+
+	// Webhook Subscriptions : Create, Update ( Secret Rotated ), Delete :
+	resp := serveWithToken(server, http.MethodPost, "/api/v1/webhooks", admin, `{"url":"https://partner.test/hooks","event_types":["user.created"],"secret":"`+testWebhookSecret+`"}`)
+	require.Equal(testingT, http.StatusCreated, resp.Code, resp.Body.String())
+
+	var subscription webhookModels.WebhookSubscription
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &subscription))
+
+	path := "/api/v1/webhooks/" + subscription.ID.String()
+	require.Equal(testingT, http.StatusOK, serveWithToken(server, http.MethodPatch, path, admin, `{"active":false,"secret":"a-brand-new-shared-secret"}`).Code)
+	require.Equal(testingT, http.StatusNoContent, serveWithToken(server, http.MethodDelete, path, admin, "").Code)
+
+	page, body = queryAudit(testingT, server, admin, constants.AuditTargetWebhook, subscription.ID.String())
+	require.Len(testingT, page.Items, 3)
+	assert.NotContains(testingT, body, testWebhookSecret)
+	assert.NotContains(testingT, body, "a-brand-new-shared-secret")
+
+	remove, update, create := page.Items[0], page.Items[1], page.Items[2]
+	assert.Equal(testingT, constants.AuditActionCreate, create.Action)
+	assert.Equal(testingT, constants.AuditMaskValue, changeOf(create, "secret").After)
+	assert.Equal(testingT, constants.AuditActionUpdate, update.Action)
+	assert.Equal(testingT, constants.AuditMaskValue, changeOf(update, "secret").After)
+	assert.Equal(testingT, false, changeOf(update, "active").After)
+	assert.Equal(testingT, constants.AuditActionDelete, remove.Action)
+	assert.Equal(testingT, "https://partner.test/hooks", changeOf(remove, "url").Before)
+	assert.Nil(testingT, changeOf(remove, "secret"))
+}
@@ -0,0 +1,263 @@
+package tests
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	auditModels "backend-task/internal/audit/models"
+	authModels "backend-task/internal/auth/models"
+	authServices "backend-task/internal/auth/services"
+	"backend-task/internal/constants"
+	"backend-task/internal/router"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testJWTSecret   = "test-jwt-secret-with-enough-entropy"
+	testJWTIssuer   = "https://issuer.test"
+	testJWTAudience = "backend-task"
+)
+
+// writeTestFile Writes Content Into The Test's Temp Directory And Returns The Path :
+func writeTestFile(testingT *testing.T, name string, content []byte) string {
+
+	testingT.Helper()
+
+	path := filepath.Join(testingT.TempDir(), name)
+	require.NoError(testingT, os.WriteFile(path, content, 0o600))
+
+	return path
+}
+
+// newTestClaims Returns Claims That Pass Every Configured Check :
+func newTestClaims(subject string, roles ...string) *authModels.Claims {
+
+	now := time.Now()
+
+	return &authModels.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject,
+			Issuer:    testJWTIssuer,
+			Audience:  jwt.ClaimStrings{testJWTAudience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		},
+		Roles: roles,
+	}
+}
+
+// withMFA Marks Claims As Issued After A Second Factor, As Admin-Only Routes Require :
+func withMFA(claims *authModels.Claims) *authModels.Claims {
+
+	claims.AMR = []string{constants.AMRPassword, constants.AMRMFA}
+	return claims
+}
+
+func signHS256(testingT *testing.T, claims *authModels.Claims) string {
+
+	testingT.Helper()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testJWTSecret))
+	require.NoError(testingT, err)
+
+	return token
+}
+
+// newAuthTestServer Builds The Full Router Guarded By An HS256 Key File :
+func newAuthTestServer(testingT *testing.T) (*testServices, *router.Server) {
+
+	useTestJWTKey(testingT)
+	env := newTestServices(testingT)
+
+	return env, router.SetupRouters(env.all, newTestBroker(testingT))
+}
+
+// useTestJWTKey Enables Authentication With The Test HS256 Key; Services Built Afterwards Issue Tokens With It :
+func useTestJWTKey(testingT *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+	testingT.Setenv(constants.AUTH_DISABLED, "false")
+	testingT.Setenv(constants.JWT_HMAC_KEY_FILE, writeTestFile(testingT, "hmac.key", []byte(testJWTSecret+"\n")))
+	testingT.Setenv(constants.JWT_ISSUER, testJWTIssuer)
+	testingT.Setenv(constants.JWT_AUDIENCE, testJWTAudience)
+}
+
+func serveWithToken(server http.Handler, method, path, token, body string) *httptest.ResponseRecorder {
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if token != "" {
+
+		req.Header.Set(constants.HeaderAuthorization, constants.BearerPrefix+token)
+	}
+
+	resp := httptest.NewRecorder()
+	server.ServeHTTP(resp, req)
+
+	return resp
+}
+
+func TestAuthRejectsMissingAndInvalidTokens(testingT *testing.T) {
+
+	_, server := newAuthTestServer(testingT)
+
+	expired := newTestClaims("alice")
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+
+	wrongIssuer := newTestClaims("alice")
+	wrongIssuer.Issuer = "https://someone-else.test"
+
+	wrongAudience := newTestClaims("alice")
+	wrongAudience.Audience = jwt.ClaimStrings{"another-api"}
+
+	forged, err := jwt.NewWithClaims(jwt.SigningMethodHS256, newTestClaims("alice")).SignedString([]byte("not-the-secret"))
+	require.NoError(testingT, err)
+
+	cases := map[string]string{
+		"missing":        "",
+		"malformed":      "not-a-jwt",
+		"expired":        signHS256(testingT, expired),
+		"wrong issuer":   signHS256(testingT, wrongIssuer),
+		"wrong audience": signHS256(testingT, wrongAudience),
+		"bad signature":  forged,
+	}
+
+	for name, token := range cases {
+
+		resp := serveWithToken(server, http.MethodGet, "/api/v1/users", token, "")
+		assert.Equal(testingT, http.StatusUnauthorized, resp.Code, name)
+		assert.Contains(testingT, resp.Header().Get(constants.HeaderWWWAuthenticate), "Bearer", name)
+	}
+}
+
+func TestAuthBypassesHealthCheck(testingT *testing.T) {
+
+	_, server := newAuthTestServer(testingT)
+
+	resp := serveWithToken(server, http.MethodGet, "/health", "", "")
+	assert.Equal(testingT, http.StatusOK, resp.Code)
+}
+
+func TestCORSAllowsOnlyConfiguredOrigins(testingT *testing.T) {
+
+	fromOrigin := func(server http.Handler, origin string) *httptest.ResponseRecorder {
+
+		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+		req.Header.Set("Origin", origin)
+
+		resp := httptest.NewRecorder()
+		server.ServeHTTP(resp, req)
+		return resp
+	}
+
+	// Same Origin Only By Default :
+	_, server := newAuthTestServer(testingT)
+	assert.Empty(testingT, fromOrigin(server, "https://evil.example.com").Header().Get("Access-Control-Allow-Origin"))
+
+	testingT.Setenv(constants.CORS_ALLOWED_ORIGINS, "https://app.example.com")
+	_, server = newAuthTestServer(testingT)
+	assert.Equal(testingT, "https://app.example.com", fromOrigin(server, "https://app.example.com").Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(testingT, http.StatusForbidden, fromOrigin(server, "https://evil.example.com").Code)
+}
+
+func TestAuthRecordsTokenSubjectAsAuditActor(testingT *testing.T) {
+
+	_, server := newAuthTestServer(testingT)
+	token := signHS256(testingT, withMFA(newTestClaims("alice", constants.RoleAdmin)))
+
+	resp := serveWithToken(server, http.MethodPost, "/api/v1/users", token, `[{"name":"Abudalou","email":"abudalou@test.com","date_of_birth":"2000-01-04"}]`)
+	require.Equal(testingT, http.StatusCreated, resp.Code, resp.Body.String())
+
+	resp = serveWithToken(server, http.MethodGet, "/api/v1/audit?actor_id=alice", token, "")
+	require.Equal(testingT, http.StatusOK, resp.Code, resp.Body.String())
+
+	var page struct {
+		Items []auditModels.AuditEntry `json:"items"`
+	}
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &page))
+	require.NotEmpty(testingT, page.Items)
+	assert.Equal(testingT, "alice", page.Items[0].ActorID)
+}
+
+func TestJWTVerifierAcceptsRS256FromJWKS(testingT *testing.T) {
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(testingT, err)
+
+	jwks, err := json.Marshal(map[string]any{"keys": []map[string]string{{
+		"kty": "RSA",
+		"kid": "key-1",
+		"use": "sig",
+		"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}}})
+	require.NoError(testingT, err)
+
+	verifier, err := authServices.NewJWTVerifier(authServices.JWTConfig{
+		JWKSFile: writeTestFile(testingT, "jwks.json", jwks),
+		Issuer:   testJWTIssuer,
+		Audience: testJWTAudience,
+	})
+	require.NoError(testingT, err)
+
+	sign := func(kid string) string {
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, newTestClaims("bob"))
+		token.Header["kid"] = kid
+
+		signed, err := token.SignedString(key)
+		require.NoError(testingT, err)
+
+		return signed
+	}
+
+	claims, err := verifier.Verify(sign("key-1"))
+	require.NoError(testingT, err)
+	assert.Equal(testingT, "bob", claims.Subject)
+
+	_, err = verifier.Verify(sign("unknown"))
+	assert.Error(testingT, err)
+}
+
+func TestJWTVerifierHonoursLeewayWithInjectedClock(testingT *testing.T) {
+
+	now := time.Now()
+	verifier, err := authServices.NewJWTVerifier(authServices.JWTConfig{
+		HMACKeyFile: writeTestFile(testingT, "hmac.key", []byte(testJWTSecret)),
+		Leeway:      30 * time.Second,
+		Now:         func() time.Time { return now.Add(time.Hour + 10*time.Second) },
+	})
+	require.NoError(testingT, err)
+
+	// Expired Ten Seconds Ago, Within The Leeway :
+	_, err = verifier.Verify(signHS256(testingT, newTestClaims("alice")))
+	assert.NoError(testingT, err)
+
+	// A Token Without A Subject Cannot Identify The Caller :
+	_, err = verifier.Verify(signHS256(testingT, newTestClaims("")))
+	assert.Error(testingT, err)
+}
+
+func TestJWTVerifierRequiresAKey(testingT *testing.T) {
+
+	_, err := authServices.NewJWTVerifier(authServices.JWTConfig{})
+	assert.Error(testingT, err)
+}
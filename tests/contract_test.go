@@ -0,0 +1,169 @@
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"testing"
+
+	"backend-task/internal/constants"
+	"backend-task/internal/router"
+	userModels "backend-task/internal/user/models"
+	"backend-task/internal/utils"
+	mocks "backend-task/tests/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// routeContract Is The Expected Error Of One Route For A Request That Cannot Succeed :
+type routeContract struct {
+	method string
+	route  string // As Registered, e.g. "/api/v1/users/:id".
+	path   string // As Requested.
+	token  string // "admin", "viewer" Or "" ( Anonymous ).
+	body   string
+	status int
+	code   string
+}
+
+const missingID = "00000000-0000-0000-0000-000000000001"
+
+var routeContracts = []routeContract{
+
+	// ---------------- Auth ----------------
+
+	{http.MethodPost, "/api/v1/auth/register", "/api/v1/auth/register", "", `{}`, http.StatusBadRequest, "VALIDATION_FAILED"},
+	{http.MethodPost, "/api/v1/auth/login", "/api/v1/auth/login", "", `{"email":"nobody@test.com","password":"wrong-password"}`, http.StatusUnauthorized, "AUTH_INVALID_CREDENTIALS"},
+	{http.MethodPost, "/api/v1/auth/refresh", "/api/v1/auth/refresh", "", `{"refresh_token":"unknown"}`, http.StatusUnauthorized, "AUTH_REFRESH_TOKEN_INVALID"},
+	{http.MethodPost, "/api/v1/auth/logout", "/api/v1/auth/logout", "", `{}`, http.StatusBadRequest, "VALIDATION_FAILED"},
+	{http.MethodPut, "/api/v1/auth/password", "/api/v1/auth/password", "admin", `{"current_password":"old-password","new_password":"a-brand-new-passphrase"}`, http.StatusForbidden, "ACCOUNT_REQUIRED"},
+	{http.MethodPost, "/api/v1/auth/mfa/enroll", "/api/v1/auth/mfa/enroll", "admin", ``, http.StatusForbidden, "ACCOUNT_REQUIRED"},
+	{http.MethodPost, "/api/v1/auth/mfa/activate", "/api/v1/auth/mfa/activate", "admin", `{"code":"123456"}`, http.StatusForbidden, "ACCOUNT_REQUIRED"},
+	{http.MethodPost, "/api/v1/auth/mfa/verify", "/api/v1/auth/mfa/verify", "", `{"mfa_token":"unknown","code":"123456"}`, http.StatusUnauthorized, "MFA_CHALLENGE_INVALID"},
+	{http.MethodPost, "/api/v1/auth/mfa/step-up", "/api/v1/auth/mfa/step-up", "admin", `{"code":"123456"}`, http.StatusForbidden, "ACCOUNT_REQUIRED"},
+	{http.MethodPut, "/api/v1/auth/accounts/:id/roles", "/api/v1/auth/accounts/" + missingID + "/roles", "admin", `{"roles":["viewer"]}`, http.StatusNotFound, "ACCOUNT_NOT_FOUND"},
+
+	// ---------------- Users ----------------
+
+	{http.MethodPost, "/api/v1/users", "/api/v1/users", "admin", `{"name":"Jane"}`, http.StatusBadRequest, "INVALID_REQUEST_BODY"},
+	{http.MethodPost, "/api/v1/users/import", "/api/v1/users/import", "admin", `{}`, http.StatusUnsupportedMediaType, "UNSUPPORTED_CONTENT_TYPE"},
+	{http.MethodGet, "/api/v1/users/export", "/api/v1/users/export?format=xml", "admin", ``, http.StatusBadRequest, "EXPORT_FORMAT_UNSUPPORTED"},
+	{http.MethodGet, "/api/v1/groups/export", "/api/v1/groups/export?format=xml", "admin", ``, http.StatusBadRequest, "EXPORT_FORMAT_UNSUPPORTED"},
+	{http.MethodGet, "/api/v1/users/verify", "/api/v1/users/verify?token=unknown", "", ``, http.StatusBadRequest, "EMAIL_VERIFICATION_TOKEN_INVALID"},
+	{http.MethodGet, "/api/v1/users/:id", "/api/v1/users/" + missingID, "admin", ``, http.StatusNotFound, "USER_NOT_FOUND"},
+	{http.MethodPatch, "/api/v1/users/:id", "/api/v1/users/" + missingID, "admin", `{"name":"Jane"}`, http.StatusNotFound, "USER_NOT_FOUND"},
+	{http.MethodGet, "/api/v1/users/:id/export", "/api/v1/users/" + missingID + "/export", "admin", ``, http.StatusNotFound, "USER_NOT_FOUND"},
+	{http.MethodGet, "/api/v1/users", "/api/v1/users?status=banned", "admin", ``, http.StatusBadRequest, "USER_STATUS_INVALID"},
+	{http.MethodGet, "/api/v1/users/search", "/api/v1/users/search?q=jo", "admin", ``, http.StatusBadRequest, "SEARCH_QUERY_INVALID"},
+	{http.MethodPost, "/api/v1/users/:id/suspend", "/api/v1/users/" + missingID + "/suspend", "admin", `{"reason":"spam"}`, http.StatusNotFound, "USER_NOT_FOUND"},
+	{http.MethodPost, "/api/v1/users/:id/deactivate", "/api/v1/users/" + missingID + "/deactivate", "admin", `{"reason":"closed"}`, http.StatusNotFound, "USER_NOT_FOUND"},
+	{http.MethodPost, "/api/v1/users/:id/activate", "/api/v1/users/" + missingID + "/activate", "admin", `{"reason":"appeal"}`, http.StatusNotFound, "USER_NOT_FOUND"},
+	{http.MethodGet, "/api/v1/users/duplicates", "/api/v1/users/duplicates?min_score=2", "admin", ``, http.StatusBadRequest, "INVALID_MIN_SCORE"},
+	{http.MethodPost, "/api/v1/users/merge", "/api/v1/users/merge", "admin", `{"survivor_id":"` + missingID + `","duplicate_id":"` + missingID + `"}`, http.StatusNotFound, "USER_NOT_FOUND"},
+
+	// ---------------- Consents, Audit & Events ----------------
+
+	{http.MethodGet, "/api/v1/consents/grant", "/api/v1/consents/grant?token=unknown", "", ``, http.StatusBadRequest, "CONSENT_TOKEN_INVALID"},
+	{http.MethodGet, "/api/v1/consents", "/api/v1/consents?status=maybe", "admin", ``, http.StatusBadRequest, "CONSENT_STATUS_INVALID"},
+	{http.MethodPost, "/api/v1/consents/:id/revoke", "/api/v1/consents/" + missingID + "/revoke", "admin", ``, http.StatusNotFound, "CONSENT_NOT_FOUND"},
+	{http.MethodGet, "/api/v1/audit", "/api/v1/audit?from=yesterday", "admin", ``, http.StatusBadRequest, "INVALID_TIME_RANGE"},
+	{http.MethodGet, "/api/v1/events/stream", "/api/v1/events/stream", "", ``, http.StatusUnauthorized, "AUTH_TOKEN_MISSING"},
+
+	// ---------------- Webhooks ----------------
+
+	{http.MethodPost, "/api/v1/webhooks", "/api/v1/webhooks", "admin", `{"url":"ftp://example.com","event_types":["user.created"],"secret":"a-long-random-shared-secret"}`, http.StatusBadRequest, "WEBHOOK_URL_INVALID"},
+	{http.MethodGet, "/api/v1/webhooks", "/api/v1/webhooks", "viewer", ``, http.StatusForbidden, "PERMISSION_DENIED"},
+	{http.MethodGet, "/api/v1/webhooks/:id", "/api/v1/webhooks/" + missingID, "admin", ``, http.StatusNotFound, "WEBHOOK_NOT_FOUND"},
+	{http.MethodPatch, "/api/v1/webhooks/:id", "/api/v1/webhooks/" + missingID, "admin", `{"active":false}`, http.StatusNotFound, "WEBHOOK_NOT_FOUND"},
+	{http.MethodDelete, "/api/v1/webhooks/:id", "/api/v1/webhooks/" + missingID, "admin", ``, http.StatusNotFound, "WEBHOOK_NOT_FOUND"},
+	{http.MethodGet, "/api/v1/webhooks/:id/deliveries", "/api/v1/webhooks/" + missingID + "/deliveries", "admin", ``, http.StatusNotFound, "WEBHOOK_NOT_FOUND"},
+	{http.MethodGet, "/api/v1/webhooks/deliveries/:deliveryId/attempts", "/api/v1/webhooks/deliveries/" + missingID + "/attempts", "admin", ``, http.StatusNotFound, "WEBHOOK_DELIVERY_NOT_FOUND"},
+	{http.MethodPost, "/api/v1/webhooks/deliveries/:deliveryId/redeliver", "/api/v1/webhooks/deliveries/" + missingID + "/redeliver", "admin", ``, http.StatusNotFound, "WEBHOOK_DELIVERY_NOT_FOUND"},
+
+	// ---------------- Jobs ----------------
+
+	{http.MethodPost, "/api/v1/jobs/user-imports", "/api/v1/jobs/user-imports", "admin", `{}`, http.StatusUnsupportedMediaType, "UNSUPPORTED_CONTENT_TYPE"},
+	{http.MethodGet, "/api/v1/jobs/:id", "/api/v1/jobs/" + missingID, "admin", ``, http.StatusNotFound, "JOB_NOT_FOUND"},
+	{http.MethodGet, "/api/v1/jobs/:id/errors", "/api/v1/jobs/" + missingID + "/errors", "admin", ``, http.StatusNotFound, "JOB_NOT_FOUND"},
+	{http.MethodPost, "/api/v1/jobs/:id/cancel", "/api/v1/jobs/" + missingID + "/cancel", "admin", ``, http.StatusNotFound, "JOB_NOT_FOUND"},
+
+	// ---------------- API Keys ----------------
+
+	{http.MethodPost, "/api/v1/api-keys", "/api/v1/api-keys", "admin", `{"name":"nightly","permissions":["users:fly"]}`, http.StatusBadRequest, "PERMISSION_UNKNOWN"},
+	{http.MethodGet, "/api/v1/api-keys", "/api/v1/api-keys", "", ``, http.StatusUnauthorized, "AUTH_TOKEN_MISSING"},
+	{http.MethodPost, "/api/v1/api-keys/:id/rotate", "/api/v1/api-keys/" + missingID + "/rotate", "admin", ``, http.StatusNotFound, "API_KEY_NOT_FOUND"},
+	{http.MethodDelete, "/api/v1/api-keys/:id", "/api/v1/api-keys/" + missingID, "admin", ``, http.StatusNotFound, "API_KEY_NOT_FOUND"},
+}
+
+func TestRouteErrorContracts(testingT *testing.T) {
+
+	_, server := newAuthTestServer(testingT)
+
+	tokens := map[string]string{
+
+		"admin":  signHS256(testingT, withMFA(newTestClaims("admin-1", constants.RoleAdmin))),
+		"viewer": signHS256(testingT, newTestClaims("viewer-1", constants.RoleViewer)),
+	}
+
+	for _, contract := range routeContracts {
+
+		resp := serveWithToken(server, contract.method, contract.path, tokens[contract.token], contract.body)
+		name := contract.method + " " + contract.path
+
+		if !assert.Equal(testingT, contract.status, resp.Code, name+": "+resp.Body.String()) {
+
+			continue
+		}
+
+		problem := decodeProblem(testingT, resp)
+		assert.Equal(testingT, contract.code, problem.ErrorCode, name)
+		assert.Equal(testingT, contract.status, problem.Status, name)
+	}
+}
+
+func TestEveryRouteHasAContract(testingT *testing.T) {
+
+	_, server := newAuthTestServer(testingT)
+
+	covered := map[string]bool{"GET /health": true}
+	for _, contract := range routeContracts {
+
+		covered[contract.method+" "+contract.route] = true
+	}
+
+	for _, route := range server.Routes() {
+
+		assert.True(testingT, covered[route.Method+" "+route.Path], "no contract for "+route.Method+" "+route.Path)
+	}
+}
+
+func TestUnknownErrorsAreInternalAndLogged(testingT *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	testingT.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	outage := errors.New("pq: connection refused")
+	mockService := new(mocks.UserService)
+	mockService.On("GetUserByID", mock.Anything, missingID).Return((*userModels.User)(nil), outage)
+	mockService.On("UpdateUser", mock.Anything, missingID, mock.Anything, mock.Anything).Return((*userModels.User)(nil), outage)
+	server := router.SetupRoutersWithService(mockService)
+
+	// Lookup Failures Are Not Reported As A Missing User :
+	for _, method := range []string{http.MethodGet, http.MethodPatch} {
+
+		resp := serveWithToken(server, method, "/users/"+missingID, "", `{"name":"Jane"}`)
+		problem := decodeProblem(testingT, resp)
+
+		assert.Equal(testingT, http.StatusInternalServerError, resp.Code, method)
+		assert.Equal(testingT, "INTERNAL_ERROR", problem.ErrorCode, method)
+		assert.Equal(testingT, utils.ErrInternalError.Error(), problem.Detail, method)
+		assert.Contains(testingT, logs.String(), method+" /users/"+missingID+" ( request "+problem.RequestID+" ): pq: connection refused")
+	}
+}
@@ -0,0 +1,122 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"backend-task/internal/constants"
+	"backend-task/internal/router"
+	models "backend-task/internal/user/models"
+	services "backend-task/internal/user/services"
+	"backend-task/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testExportSigningKey = "test-export-signing-key"
+
+func newDataExportServer(testingT *testing.T, signingKey string) (*testServices, *router.Server) {
+
+	gin.SetMode(gin.TestMode)
+	testingT.Setenv(constants.EXPORT_SIGNING_KEY, signingKey)
+
+	env := newTestServices(testingT)
+
+	return env, newTestServer(testingT, env)
+}
+
+func TestUserDataExportBundle(testingT *testing.T) {
+
+	env, server := newDataExportServer(testingT, testExportSigningKey)
+
+	user, err := env.users.CreateUser(context.Background(), "Alice", "alice@test.com", "1990-01-01")
+	require.NoError(testingT, err)
+
+	name := "Alice Doe"
+	_, err = env.users.UpdateUser(context.Background(), user.ID.String(), &name, nil)
+	require.NoError(testingT, err)
+
+	// Another User's Activity Must Not Leak Into The Bundle :
+	_, err = env.users.CreateUser(context.Background(), "Bob", "bob@test.com", "1990-01-01")
+	require.NoError(testingT, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/"+user.ID.String()+"/export", nil)
+	resp := httptest.NewRecorder()
+	server.ServeHTTP(resp, req)
+	require.Equal(testingT, http.StatusOK, resp.Code)
+
+	var bundle models.UserDataBundle
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &bundle))
+
+	assert.Equal(testingT, "Alice Doe", bundle.User.Name)
+	require.NotNil(testingT, bundle.Group)
+	assert.Equal(testingT, "adult-1", bundle.Group.Name)
+	assert.Equal(testingT, constants.BaseGroupAdult, bundle.Group.Base)
+	assert.Equal(testingT, constants.GroupCapacity, bundle.Group.Capacity)
+
+	require.Len(testingT, bundle.GroupHistory, 1)
+	assert.Equal(testingT, "adult-1", bundle.GroupHistory[0].Group)
+	assert.Equal(testingT, constants.MembershipReasonAllocated, bundle.GroupHistory[0].Reason)
+	assert.Nil(testingT, bundle.GroupHistory[0].LeftAt)
+
+	require.Len(testingT, bundle.AuditEntries, 2)
+	for _, entry := range bundle.AuditEntries {
+
+		assert.Equal(testingT, user.ID.String(), entry.TargetID)
+	}
+
+	// Unknown Users And Formats :
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/users/00000000-0000-0000-0000-000000000000/export", nil)
+	resp = httptest.NewRecorder()
+	server.ServeHTTP(resp, req)
+	assert.Equal(testingT, http.StatusNotFound, resp.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/users/"+user.ID.String()+"/export?format=pdf", nil)
+	resp = httptest.NewRecorder()
+	server.ServeHTTP(resp, req)
+	assert.Equal(testingT, http.StatusBadRequest, resp.Code)
+}
+
+func TestUserDataExportSignedArchive(testingT *testing.T) {
+
+	env, server := newDataExportServer(testingT, testExportSigningKey)
+
+	user, err := env.users.CreateUser(context.Background(), "Alice", "alice@test.com", "1990-01-01")
+	require.NoError(testingT, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/"+user.ID.String()+"/export?format=zip", nil)
+	resp := httptest.NewRecorder()
+	server.ServeHTTP(resp, req)
+
+	require.Equal(testingT, http.StatusOK, resp.Code)
+	assert.Equal(testingT, constants.ContentTypeZip, resp.Header().Get("Content-Type"))
+	assert.Contains(testingT, resp.Header().Get("Content-Disposition"), user.ID.String())
+
+	archive := resp.Body.Bytes()
+	bundle, err := services.VerifyUserDataArchive(archive, []byte(testExportSigningKey))
+	require.NoError(testingT, err)
+	assert.Equal(testingT, user.ID, bundle.User.ID)
+
+	_, err = services.VerifyUserDataArchive(archive, []byte("some-other-key"))
+	assert.ErrorIs(testingT, err, utils.ErrInvalidExportSignature)
+}
+
+func TestUserDataExportArchiveRequiresSigningKey(testingT *testing.T) {
+
+	env, server := newDataExportServer(testingT, "")
+
+	user, err := env.users.CreateUser(context.Background(), "Alice", "alice@test.com", "1990-01-01")
+	require.NoError(testingT, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/"+user.ID.String()+"/export?format=zip", nil)
+	resp := httptest.NewRecorder()
+	server.ServeHTTP(resp, req)
+
+	assert.Equal(testingT, http.StatusInternalServerError, resp.Code)
+	assert.Contains(testingT, resp.Body.String(), utils.ErrExportSigningKeyMissing.Error())
+}
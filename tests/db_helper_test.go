@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+
+	auditServiceInterface "backend-task/internal/audit/services/interface"
+	"backend-task/internal/constants"
+	"backend-task/internal/db"
+	eventRepository "backend-task/internal/events/repository"
+	eventServices "backend-task/internal/events/services"
+	eventServiceInterface "backend-task/internal/events/services/interface"
+	jobServiceInterface "backend-task/internal/job/services/interface"
+	"backend-task/internal/mail"
+	"backend-task/internal/router"
+	userServiceInterface "backend-task/internal/user/services/interface"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestDB Opens A Private In-Memory SQLite Database With The Application Schema :
+func newTestDB(testingT *testing.T) *gorm.DB {
+
+	testingT.Helper()
+
+	gormDB := openTestDB(testingT)
+	if err := db.Migrate(gormDB); err != nil {
+
+		testingT.Fatalf("migrate: %v", err)
+	}
+
+	return gormDB
+}
+
+// openTestDB Opens A Private, Empty In-Memory SQLite Database :
+func openTestDB(testingT *testing.T) *gorm.DB {
+
+	testingT.Helper()
+
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", uuid.NewString())
+	gormDB, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+
+		testingT.Fatalf("open sqlite: %v", err)
+	}
+
+	sqlDB, err := gormDB.DB()
+	if err != nil {
+
+		testingT.Fatalf("sql db: %v", err)
+	}
+
+	// A Single Connection Keeps The In-Memory Database Alive And Serializes Writers.
+	sqlDB.SetMaxOpenConns(1)
+	testingT.Cleanup(func() { sqlDB.Close() })
+
+	return gormDB
+}
+
+// testServices Bundles The Real Service Graph Wired Against A Test Database :
+type testServices struct {
+	db     *gorm.DB
+	audit  auditServiceInterface.AuditService
+	events eventServiceInterface.EventService
+	outbox eventRepository.OutboxRepository
+	users  userServiceInterface.UserService
+	jobs   jobServiceInterface.JobService
+	mailer *mail.InMemoryMailer
+	all    *router.Services
+}
+
+// newTestServices Wires The Production Services On Top Of A Fresh Test Database :
+func newTestServices(testingT *testing.T) *testServices {
+
+	return newTestServicesOn(testingT, newTestDB(testingT))
+}
+
+// newTestServicesOn Wires The Production Services On Top Of An Already Migrated Database :
+func newTestServicesOn(testingT *testing.T, gormDB *gorm.DB) *testServices {
+
+	testingT.Setenv(constants.MAIL_DRIVER, constants.MailDriverMemory)
+
+	services := router.NewServices(gormDB)
+	mailer, _ := services.Mailer.(*mail.InMemoryMailer)
+
+	return &testServices{db: gormDB, audit: services.Audit, events: services.Events, outbox: services.Outbox, users: services.Users, jobs: services.Jobs, mailer: mailer, all: services}
+}
+
+// newTestServer Builds The Full Router With Authentication Disabled ( Auth Has Its Own Tests ) :
+func newTestServer(testingT *testing.T, env *testServices) *router.Server {
+
+	testingT.Helper()
+	testingT.Setenv(constants.AUTH_DISABLED, "true")
+
+	return router.SetupRouters(env.all, newTestBroker(testingT))
+}
+
+func newTestBroker(testingT *testing.T) *eventServices.Broker {
+
+	broker := eventServices.NewBroker(constants.DefaultSSEReplayBuffer)
+	testingT.Cleanup(broker.Close)
+
+	return broker
+}
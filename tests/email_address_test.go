@@ -0,0 +1,141 @@
+package tests
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"backend-task/internal/constants"
+	"backend-task/internal/mail"
+	userModels "backend-task/internal/user/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAddress(testingT *testing.T) {
+
+	valid := map[string]string{
+		" Jane.Doe@Example.COM ":    "Jane.Doe@example.com",
+		"Jürgen@Bücher.de":          "Jürgen@xn--bcher-kva.de",
+		`"john doe"@example.com`:    `"john doe"@example.com`,
+		`"a\"b"@example.com`:        `"a\"b"@example.com`,
+		"用户@例子.广告":                  "用户@xn--fsqu00a.xn--4rr70v",
+		"o'brien+tag@example.co.uk": "o'brien+tag@example.co.uk",
+	}
+
+	for raw, want := range valid {
+
+		address, err := mail.ParseAddress(raw)
+		if assert.NoError(testingT, err, raw) {
+
+			assert.Equal(testingT, want, address.String(), raw)
+		}
+	}
+
+	invalid := []string{
+		"", "jane", "@example.com", "jane@", "a..b@example.com", ".jane@example.com", "jane.@example.com",
+		"jane@localhost", "jane@example.c", "jane@192.168.0.1", "jane@[192.0.2.1]", "jane@exa_mple.com",
+		"jane@example.com.", `"unterminated@example.com`, `"quoted"x@example.com`, "a b@example.com",
+		strings.Repeat("a", 65) + "@example.com",
+	}
+
+	for _, raw := range invalid {
+
+		_, err := mail.ParseAddress(raw)
+		assert.Error(testingT, err, raw)
+	}
+}
+
+func TestCanonicalEmail(testingT *testing.T) {
+
+	cases := []struct {
+		raw      string
+		fold     bool
+		expected string
+	}{
+		{"J.A.N.E+news@GoogleMail.com", true, "jane@gmail.com"},
+		{"J.A.N.E+news@GoogleMail.com", false, "j.a.n.e+news@googlemail.com"},
+		{"jane+work@outlook.com", true, "jane@outlook.com"},
+		{"jane.doe+work@me.com", true, "jane.doe@icloud.com"},
+		{"+news@gmail.com", true, "+news@gmail.com"},
+		{"jane+work@example.com", true, "jane+work@example.com"},
+		{`"jane"@example.com`, true, "jane@example.com"},
+		{`"John Doe"@Example.com`, true, `"john doe"@example.com`},
+		{"JÜRGEN@bücher.de", true, "jürgen@xn--bcher-kva.de"},
+	}
+
+	for _, testCase := range cases {
+
+		address, err := mail.ParseAddress(testCase.raw)
+		require.NoError(testingT, err, testCase.raw)
+		assert.Equal(testingT, testCase.expected, address.Canonical(testCase.fold), testCase.raw)
+	}
+}
+
+func TestDomainBlocklist(testingT *testing.T) {
+
+	path := filepath.Join(testingT.TempDir(), "disposable.txt")
+	require.NoError(testingT, os.WriteFile(path, []byte("# Throwaway Inboxes\nmailinator.com\n\n  Wegwerf-Bücher.de  # IDN\n"), 0o600))
+
+	list, err := mail.LoadDomainBlocklist(path)
+	require.NoError(testingT, err)
+
+	assert.True(testingT, list.Blocks("mailinator.com"))
+	assert.True(testingT, list.Blocks("eu.mailinator.com"))
+	assert.True(testingT, list.Blocks("xn--wegwerf-bcher-4ob.de"))
+	assert.False(testingT, list.Blocks("notmailinator.com"))
+	assert.False(testingT, list.Blocks("example.com"))
+
+	// No File Means No Blocklist :
+	none, err := mail.LoadDomainBlocklist("")
+	require.NoError(testingT, err)
+	assert.False(testingT, none.Blocks("mailinator.com"))
+}
+
+func TestUsersStoreRawAndCanonicalEmail(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	server := newTestServer(testingT, env)
+
+	created := createInTenant(testingT, server, constants.DefaultTenantID, `{"name":"Jane","email":"Jane.Doe+News@GMail.com","date_of_birth":"1990-01-01"}`)
+	assert.Equal(testingT, "Jane.Doe+News@GMail.com", created.Email)
+	assert.Equal(testingT, "janedoe@gmail.com", created.EmailCanonical)
+
+	// Another Spelling Of The Same Gmail Inbox Is A Duplicate :
+	resp := serveWithToken(server, http.MethodPost, "/api/v1/users", "", `[{"name":"Jane Again","email":"janedoe@googlemail.com","date_of_birth":"1990-01-01"}]`)
+	require.Equal(testingT, http.StatusConflict, resp.Code, resp.Body.String())
+	assert.Equal(testingT, "USER_EMAIL_TAKEN", decodeProblem(testingT, resp).ErrorCode)
+
+	// Internationalized Addresses Are Accepted :
+	idn := createInTenant(testingT, server, constants.DefaultTenantID, `{"name":"Jürgen","email":"jürgen@bücher.de","date_of_birth":"1990-01-01"}`)
+	assert.Equal(testingT, "jürgen@xn--bcher-kva.de", idn.EmailCanonical)
+
+	// Re-Spelling One's Own Address Is Not A Conflict And Keeps The Canonical Form :
+	resp = serveWithToken(server, http.MethodPatch, "/api/v1/users/"+created.ID.String(), "", `{"email":"jane.doe@gmail.com"}`)
+	require.Equal(testingT, http.StatusOK, resp.Code, resp.Body.String())
+}
+
+func TestDisposableEmailsAreRejected(testingT *testing.T) {
+
+	path := filepath.Join(testingT.TempDir(), "disposable.txt")
+	require.NoError(testingT, os.WriteFile(path, []byte("mailinator.com\n"), 0o600))
+	testingT.Setenv(constants.DISPOSABLE_EMAIL_DOMAINS_FILE, path)
+
+	env := newTestServices(testingT)
+	server := newTestServer(testingT, env)
+
+	resp := serveWithToken(server, http.MethodPost, "/api/v1/users", "", `[{"name":"Jane","email":"jane@mailinator.com","date_of_birth":"1990-01-01"}]`)
+	require.Equal(testingT, http.StatusBadRequest, resp.Code, resp.Body.String())
+	assert.Equal(testingT, []userModels.FieldError{{Field: "[0].email", Rule: constants.RuleNotDisposable, Message: "disposable email addresses are not allowed"}}, decodeProblem(testingT, resp).Errors)
+
+	// Subdomains Are Blocked Too, Also For Guardians :
+	body := `[{"name":"Kid","email":"kid@test.com","date_of_birth":"` + birthDateAged(10) + `","guardian":{"name":"Parent","email":"parent@eu.mailinator.com"}}]`
+	resp = serveWithToken(server, http.MethodPost, "/api/v1/users", "", body)
+	require.Equal(testingT, http.StatusBadRequest, resp.Code, resp.Body.String())
+	assert.Equal(testingT, []userModels.FieldError{{Field: "[0].guardian.email", Rule: constants.RuleNotDisposable, Message: "disposable email addresses are not allowed"}}, decodeProblem(testingT, resp).Errors)
+
+	assert.Empty(testingT, listUserIDs(testingT, server, ""))
+}
@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"backend-task/internal/db"
+	userModels "backend-task/internal/user/models"
+	userRepository "backend-task/internal/user/repository"
+	userServices "backend-task/internal/user/services"
+	"backend-task/internal/utils"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// racyUserRepository Always Passes The Advisory Email Check, As If A Concurrent
+// Create Landed Between The Check And The Insert :
+type racyUserRepository struct {
+	userRepository.UserRepository
+}
+
+func (racyUserRepository) IsEmailExists(context.Context, string) (bool, error) {
+
+	return false, nil
+}
+
+func TestConcurrentCreateLosesWithConflict(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+
+	_, err := env.users.CreateUser(context.Background(), "Jane", "jane@test.com", "1990-01-01")
+	require.NoError(testingT, err)
+
+	racy := userServices.NewUserService(env.db, racyUserRepository{userRepository.NewUserRepository(env.db)}, userRepository.NewGroupRepository(env.db), userRepository.NewConsentRepository(env.db), env.audit, env.events, userServices.VerificationConfig{}, userServices.EmailPolicy{}, nil, nil)
+
+	_, err = racy.CreateUser(context.Background(), "Jane Again", "jane@test.com", "1991-02-02")
+	require.ErrorIs(testingT, err, utils.ErrEmailAlreadyExists)
+
+	problem := utils.NewProblem(err, "/api/v1/users", "")
+	assert.Equal(testingT, http.StatusConflict, problem.Status)
+	assert.Equal(testingT, "USER_EMAIL_TAKEN", problem.ErrorCode)
+	assert.Equal(testingT, utils.ErrEmailAlreadyExists.Error(), problem.Detail)
+
+	var count int64
+	require.NoError(testingT, env.db.Model(&userModels.User{}).Count(&count).Error)
+	assert.Equal(testingT, int64(1), count)
+}
+
+func TestEmailIndexIgnoresCase(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	repository := userRepository.NewUserRepository(env.db)
+	birth := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	require.NoError(testingT, repository.CreateNewUser(context.Background(), &userModels.User{Name: "Jane", Email: "jane@test.com", EmailCanonical: "jane@test.com", DateOfBirth: birth}))
+
+	// Rows Written Without Canonicalization Still Collide :
+	err := repository.CreateNewUser(context.Background(), &userModels.User{Name: "Jane", Email: "Jane@Test.com", EmailCanonical: "Jane@Test.com", DateOfBirth: birth})
+	assert.ErrorIs(testingT, err, utils.ErrEmailAlreadyExists)
+
+	bob := &userModels.User{Name: "Bob", Email: "bob@test.com", EmailCanonical: "bob@test.com", DateOfBirth: birth}
+	require.NoError(testingT, repository.CreateNewUser(context.Background(), bob))
+
+	bob.Email = "JANE@TEST.COM"
+	err = repository.UpdateUser(context.Background(), bob, "email")
+	assert.ErrorIs(testingT, err, utils.ErrEmailAlreadyExists)
+
+	// The Advisory Check Agrees With The Index :
+	exists, err := repository.IsEmailExists(context.Background(), "jane@test.com")
+	require.NoError(testingT, err)
+	assert.True(testingT, exists)
+}
+
+func TestIsUniqueViolation(testingT *testing.T) {
+
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"postgres unique", fmt.Errorf("insert: %w", &pgconn.PgError{Code: "23505"}), true},
+		{"postgres foreign key", &pgconn.PgError{Code: "23503"}, false},
+		{"translated by gorm", gorm.ErrDuplicatedKey, true},
+		{"other", errors.New("connection reset"), false},
+		{"nil", nil, false},
+	}
+
+	for _, testCase := range cases {
+
+		assert.Equal(testingT, testCase.want, db.IsUniqueViolation(testCase.err), testCase.name)
+	}
+}
@@ -0,0 +1,156 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"backend-task/internal/constants"
+	"backend-task/internal/i18n"
+	"backend-task/internal/mail"
+	userModels "backend-task/internal/user/models"
+	userRepository "backend-task/internal/user/repository"
+	userServices "backend-task/internal/user/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var verificationLink = regexp.MustCompile(`\S+/api/v1/users/verify\?token=([^&\s]+)`)
+
+// lastVerificationToken Extracts The Token From The Newest Email Sent To The Address :
+func lastVerificationToken(testingT *testing.T, mailer *mail.InMemoryMailer, to string) string {
+
+	testingT.Helper()
+
+	messages := mailer.Messages()
+	for index := len(messages) - 1; index >= 0; index-- {
+
+		if messages[index].To != to {
+
+			continue
+		}
+
+		assert.Equal(testingT, i18n.Text(constants.LanguageEnglish, constants.MessageEmailVerificationSubject), messages[index].Subject)
+
+		match := verificationLink.FindStringSubmatch(messages[index].Body)
+		require.NotNil(testingT, match, messages[index].Body)
+
+		token, err := url.QueryUnescape(match[1])
+		require.NoError(testingT, err)
+
+		return token
+	}
+
+	require.FailNow(testingT, "no verification email sent to "+to)
+	return ""
+}
+
+func verifyEmail(server http.Handler, token string) (int, userModels.User) {
+
+	resp := serveWithToken(server, http.MethodGet, "/api/v1/users/verify?token="+url.QueryEscape(token), "", "")
+
+	var user userModels.User
+	json.Unmarshal(resp.Body.Bytes(), &user)
+
+	return resp.Code, user
+}
+
+func TestNewUsersVerifyTheirEmail(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	server := newTestServer(testingT, env)
+
+	resp := serveWithToken(server, http.MethodPost, "/api/v1/users", "", `[{"name":"Jane","email":"jane@test.com","date_of_birth":"1990-01-01"}]`)
+	require.Equal(testingT, http.StatusCreated, resp.Code, resp.Body.String())
+
+	var created []userModels.User
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &created))
+	assert.False(testingT, created[0].EmailVerified)
+
+	token := lastVerificationToken(testingT, env.mailer, "jane@test.com")
+
+	// Tampered Tokens Are Rejected :
+	status, _ := verifyEmail(server, token+"x")
+	assert.Equal(testingT, http.StatusBadRequest, status)
+
+	status, user := verifyEmail(server, token)
+	require.Equal(testingT, http.StatusOK, status)
+	assert.True(testingT, user.EmailVerified)
+	assert.NotNil(testingT, user.EmailVerifiedAt)
+
+	// Verifying Twice Is Harmless :
+	status, _ = verifyEmail(server, token)
+	assert.Equal(testingT, http.StatusOK, status)
+}
+
+func TestEmailChangeResetsVerification(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	server := newTestServer(testingT, env)
+
+	user, err := env.users.CreateUser(context.Background(), "Jane", "jane@test.com", "1990-01-01")
+	require.NoError(testingT, err)
+
+	oldToken := lastVerificationToken(testingT, env.mailer, "jane@test.com")
+	status, _ := verifyEmail(server, oldToken)
+	require.Equal(testingT, http.StatusOK, status)
+
+	// Renaming Keeps The Verification :
+	resp := serveWithToken(server, http.MethodPatch, "/api/v1/users/"+user.ID.String(), "", `{"name":"Jane Doe"}`)
+	require.Equal(testingT, http.StatusOK, resp.Code)
+	assert.Len(testingT, env.mailer.Messages(), 1)
+
+	resp = serveWithToken(server, http.MethodPatch, "/api/v1/users/"+user.ID.String(), "", `{"email":"jane.doe@test.com"}`)
+	require.Equal(testingT, http.StatusOK, resp.Code, resp.Body.String())
+
+	var updated userModels.User
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &updated))
+	assert.False(testingT, updated.EmailVerified)
+	assert.Nil(testingT, updated.EmailVerifiedAt)
+
+	// Tokens For The Previous Address No Longer Work :
+	status, _ = verifyEmail(server, oldToken)
+	assert.Equal(testingT, http.StatusBadRequest, status)
+
+	status, verified := verifyEmail(server, lastVerificationToken(testingT, env.mailer, "jane.doe@test.com"))
+	require.Equal(testingT, http.StatusOK, status)
+	assert.True(testingT, verified.EmailVerified)
+}
+
+func TestVerificationTokensExpire(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	mailer := mail.NewInMemoryMailer()
+	users := userServices.NewUserService(env.db, userRepository.NewUserRepository(env.db), userRepository.NewGroupRepository(env.db), userRepository.NewConsentRepository(env.db), env.audit, env.events, userServices.VerificationConfig{
+		Mailer:  mailer,
+		Key:     []byte("verification-test-key"),
+		TTL:     time.Hour,
+		BaseURL: "https://api.example.com/",
+		Now:     func() time.Time { return now },
+	}, userServices.EmailPolicy{}, nil, nil)
+
+	_, err := users.CreateUser(context.Background(), "Jane", "jane@test.com", "1990-01-01")
+	require.NoError(testingT, err)
+
+	token := lastVerificationToken(testingT, mailer, "jane@test.com")
+	assert.Contains(testingT, mailer.Messages()[0].Body, "https://api.example.com/api/v1/users/verify?token=")
+
+	now = now.Add(time.Hour)
+	_, err = users.VerifyEmail(context.Background(), token)
+	assert.ErrorContains(testingT, err, "invalid or expired verification token")
+}
+
+func TestVerifyEmailIsPublic(testingT *testing.T) {
+
+	_, server := newAuthTestServer(testingT)
+
+	status, _ := verifyEmail(server, "not-a-token")
+	assert.Equal(testingT, http.StatusBadRequest, status)
+}
@@ -0,0 +1,144 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"backend-task/internal/constants"
+	eventHandlers "backend-task/internal/events/handlers"
+	eventModels "backend-task/internal/events/models"
+	eventServices "backend-task/internal/events/services"
+	userModels "backend-task/internal/user/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEnvelope(testingT *testing.T, eventType, groupBase string) eventModels.Envelope {
+
+	payload, err := json.Marshal(eventModels.UserEventPayload{User: userModels.User{ID: uuid.New()}, GroupBase: groupBase})
+	require.NoError(testingT, err)
+
+	return eventModels.Envelope{ID: uuid.New(), TenantID: constants.DefaultTenantID, Type: eventType, Version: 1, OccurredAt: time.Now().UTC(), Payload: payload}
+}
+
+// readSSEIDs Reads "id:" Lines From The Stream Until n Were Seen :
+func readSSEIDs(testingT *testing.T, reader *bufio.Reader, n int) []string {
+
+	testingT.Helper()
+
+	var ids []string
+	for len(ids) < n {
+
+		line, err := reader.ReadString('\n')
+		require.NoError(testingT, err)
+
+		if strings.HasPrefix(line, "id:") {
+
+			ids = append(ids, strings.TrimSpace(strings.TrimPrefix(line, "id:")))
+		}
+	}
+
+	return ids
+}
+
+func TestEventStreamFiltersAndResumes(testingT *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+	env := newTestServices(testingT)
+
+	broker := eventServices.NewBroker(10)
+	route := gin.New()
+	route.GET("/events/stream", eventHandlers.NewStreamHandler(broker, env.outbox, 20*time.Millisecond).Stream)
+
+	server := httptest.NewServer(route)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	request, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/events/stream?types=user.created&group_base=adult", nil)
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(testingT, err)
+	defer response.Body.Close()
+
+	assert.Contains(testingT, response.Header.Get("Content-Type"), "text/event-stream")
+	reader := bufio.NewReader(response.Body)
+
+	// Wait For The Subscription To Register ( First Heartbeat ) :
+	for {
+
+		line, err := reader.ReadString('\n')
+		require.NoError(testingT, err)
+		if strings.HasPrefix(line, ": heartbeat") {
+
+			break
+		}
+	}
+
+	first := newTestEnvelope(testingT, constants.EventUserCreated, constants.BaseGroupAdult)
+	ignoredType := newTestEnvelope(testingT, constants.EventUserUpdated, constants.BaseGroupAdult)
+	ignoredBase := newTestEnvelope(testingT, constants.EventUserCreated, constants.BaseGroupTeen)
+	second := newTestEnvelope(testingT, constants.EventUserCreated, constants.BaseGroupAdult)
+
+	for _, envelope := range []eventModels.Envelope{first, ignoredType, ignoredBase, second} {
+
+		require.NoError(testingT, broker.Publish(ctx, envelope))
+	}
+
+	assert.Equal(testingT, []string{first.ID.String(), second.ID.String()}, readSSEIDs(testingT, reader, 2))
+	cancel()
+
+	// Reconnect With Last-Event-ID : Only Later Events Are Replayed.
+	third := newTestEnvelope(testingT, constants.EventUserCreated, constants.BaseGroupAdult)
+	require.NoError(testingT, broker.Publish(context.Background(), third))
+
+	resumeContext, resumeCancel := context.WithCancel(context.Background())
+	defer resumeCancel()
+
+	request, _ = http.NewRequestWithContext(resumeContext, http.MethodGet, server.URL+"/events/stream?types=user.created", nil)
+	request.Header.Set(constants.HeaderLastEventID, first.ID.String())
+	response, err = http.DefaultClient.Do(request)
+	require.NoError(testingT, err)
+	defer response.Body.Close()
+
+	assert.Equal(testingT, []string{ignoredBase.ID.String(), second.ID.String(), third.ID.String()}, readSSEIDs(testingT, bufio.NewReader(response.Body), 3))
+}
+
+func TestSlowStreamConsumerDoesNotBlockPublishers(testingT *testing.T) {
+
+	broker := eventServices.NewBroker(10)
+	subscription, _, _ := broker.Subscribe(eventModels.NewStreamFilter(constants.DefaultTenantID, "", ""), "")
+
+	done := make(chan struct{})
+	go func() {
+
+		for i := 0; i < constants.SSESubscriberBuffer*4; i++ {
+
+			broker.Publish(context.Background(), newTestEnvelope(testingT, constants.EventUserCreated, constants.BaseGroupAdult))
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		testingT.Fatal("publisher blocked on a slow consumer")
+	}
+
+	// The Slow Subscriber Was Disconnected After Its Queue Filled Up :
+	received := 0
+	for range subscription.Events {
+
+		received++
+	}
+	assert.Equal(testingT, constants.SSESubscriberBuffer, received)
+}
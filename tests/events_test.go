@@ -0,0 +1,172 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"backend-task/internal/constants"
+	eventModels "backend-task/internal/events/models"
+	"backend-task/internal/events/publisher"
+	eventServices "backend-task/internal/events/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingPublisher Rejects The First N Envelopes :
+type failingPublisher struct {
+	failures int
+	inner    *publisher.InMemoryPublisher
+}
+
+func (failing *failingPublisher) Publish(context context.Context, envelope eventModels.Envelope) error {
+
+	if failing.failures > 0 {
+
+		failing.failures--
+		return errors.New("downstream unavailable")
+	}
+
+	return failing.inner.Publish(context, envelope)
+}
+
+func TestOutboxEventsAreRelayedAtLeastOnce(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	ctx := context.Background()
+
+	// Fill One Adult Group ( Capacity 3 ) :
+	for i := 0; i < constants.GroupCapacity; i++ {
+
+		_, err := env.users.CreateUser(ctx, "User", fmt.Sprintf("user%d@test.com", i), "1990-01-01")
+		require.NoError(testingT, err)
+	}
+
+	sink := &failingPublisher{failures: 1, inner: publisher.NewInMemoryPublisher()}
+	relay := eventServices.NewRelay(env.outbox, sink, time.Second, 100)
+
+	// First Pass Fails And Leaves Everything Pending :
+	published, err := relay.RelayOnce(ctx)
+	assert.Error(testingT, err)
+	assert.Equal(testingT, 0, published)
+
+	// Second Pass Delivers Everything In Commit Order :
+	published, err = relay.RelayOnce(ctx)
+	require.NoError(testingT, err)
+
+	envelopes := sink.inner.Envelopes()
+	require.Equal(testingT, published, len(envelopes))
+
+	var types []string
+	for _, envelope := range envelopes {
+
+		assert.Equal(testingT, constants.EventEnvelopeVersion, envelope.Version)
+		types = append(types, envelope.Type)
+	}
+
+	assert.Equal(testingT, []string{
+		constants.EventGroupCreated, constants.EventUserCreated, constants.EventUserGroupChanged,
+		constants.EventUserCreated, constants.EventUserGroupChanged,
+		constants.EventUserCreated, constants.EventUserGroupChanged, constants.EventGroupFull,
+	}, types)
+
+	var payload eventModels.UserEventPayload
+	require.NoError(testingT, json.Unmarshal(envelopes[1].Payload, &payload))
+	assert.Equal(testingT, "user0@test.com", payload.User.Email)
+	assert.Equal(testingT, constants.BaseGroupAdult, payload.GroupBase)
+
+	// Nothing Left To Relay :
+	pending, err := env.outbox.FetchPending(ctx, 100)
+	require.NoError(testingT, err)
+	assert.Empty(testingT, pending)
+}
+
+func TestUpdateEmitsUserUpdatedEvent(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	ctx := context.Background()
+
+	user, err := env.users.CreateUser(ctx, "Abudalou", "abudalou@test.com", "2000-01-04")
+	require.NoError(testingT, err)
+
+	name := "Renamed"
+	_, err = env.users.UpdateUser(ctx, user.ID.String(), &name, nil)
+	require.NoError(testingT, err)
+
+	sink := publisher.NewInMemoryPublisher()
+	_, err = eventServices.NewRelay(env.outbox, sink, time.Second, 100).RelayOnce(ctx)
+	require.NoError(testingT, err)
+
+	envelopes := sink.Envelopes()
+	last := envelopes[len(envelopes)-1]
+	assert.Equal(testingT, constants.EventUserUpdated, last.Type)
+	assert.Contains(testingT, string(last.Payload), "Renamed")
+}
+
+func TestOutboxReplayDoesNotSkipEventsStampedAtTheSameInstant(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	ctx := context.Background()
+
+	// One Timestamp For All, With IDs Out Of Insert Order :
+	occurredAt := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	var events []*eventModels.OutboxEvent
+	for i := 0; i < 4; i++ {
+
+		event := &eventModels.OutboxEvent{TenantID: constants.DefaultTenantID, Type: constants.EventUserCreated, Version: 1, OccurredAt: occurredAt, Payload: "{}"}
+		require.NoError(testingT, env.outbox.AppendTx(env.db, event))
+		events = append(events, event)
+	}
+
+	pending, err := env.outbox.FetchPending(ctx, 100)
+	require.NoError(testingT, err)
+	require.Len(testingT, pending, 4)
+
+	for index, event := range pending {
+
+		assert.Equal(testingT, events[index].ID, event.ID)
+		require.NoError(testingT, env.outbox.MarkPublished(ctx, event.ID))
+	}
+
+	replayed, err := env.outbox.ListSince(ctx, events[1].ID, 100)
+	require.NoError(testingT, err)
+	require.Len(testingT, replayed, 2)
+	assert.Equal(testingT, events[2].ID, replayed[0].ID)
+	assert.Equal(testingT, events[3].ID, replayed[1].ID)
+}
+
+func TestOutboxReplayFollowsPublishOrder(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	ctx := context.Background()
+
+	var events []*eventModels.OutboxEvent
+	for i := 0; i < 3; i++ {
+
+		event := &eventModels.OutboxEvent{TenantID: constants.DefaultTenantID, Type: constants.EventUserCreated, Version: 1, OccurredAt: time.Now().UTC(), Payload: "{}"}
+		require.NoError(testingT, env.outbox.AppendTx(env.db, event))
+		events = append(events, event)
+	}
+
+	// The Lowest Sequence Commits Last ( A Long Transaction ), So It Is Published After The Others :
+	for _, index := range []int{1, 2, 0} {
+
+		require.NoError(testingT, env.outbox.MarkPublished(ctx, events[index].ID))
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	// A Client That Last Saw The Second Sequence Has Not Seen The First Yet :
+	replayed, err := env.outbox.ListSince(ctx, events[1].ID, 100)
+	require.NoError(testingT, err)
+	require.Len(testingT, replayed, 2)
+	assert.Equal(testingT, events[2].ID, replayed[0].ID)
+	assert.Equal(testingT, events[0].ID, replayed[1].ID)
+
+	replayed, err = env.outbox.ListSince(ctx, events[0].ID, 100)
+	require.NoError(testingT, err)
+	assert.Empty(testingT, replayed)
+}
@@ -0,0 +1,174 @@
+package tests
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"backend-task/internal/constants"
+	models "backend-task/internal/user/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedUsers Creates Adults And Children, Filling adult-1, adult-2 And child-1 :
+func seedUsers(testingT *testing.T, env *testServices) {
+
+	for i := 0; i < 5; i++ {
+
+		_, err := env.users.CreateUser(context.Background(), fmt.Sprintf("Adult %d", i), fmt.Sprintf("adult%d@test.com", i), "1990-01-01")
+		require.NoError(testingT, err)
+	}
+
+	_, err := env.users.CreateUserWithGuardian(context.Background(), "Kid", "kid@test.com", "2020-01-01", &models.GuardianReq{Name: "Parent", Email: "parent@test.com"})
+	require.NoError(testingT, err)
+
+	grantConsent(testingT, env, "parent@test.com")
+}
+
+func TestExportUsersStreamsAllBatches(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	seedUsers(testingT, env)
+
+	// A Batch Size Of 2 Forces Several Keyset Pages :
+	var buffer bytes.Buffer
+	require.NoError(testingT, env.users.ExportUsers(context.Background(), &buffer, models.ExportOptions{Format: constants.ExportFormatCSV, BatchSize: 2}))
+
+	records, err := csv.NewReader(&buffer).ReadAll()
+	require.NoError(testingT, err)
+	require.Len(testingT, records, 7)
+	assert.Equal(testingT, []string{"id", "name", "email", "date_of_birth", "group", "created_at", "updated_at"}, records[0])
+
+	seen := map[string]bool{}
+	for _, record := range records[1:] {
+
+		assert.False(testingT, seen[record[0]], "user exported twice")
+		seen[record[0]] = true
+	}
+
+	// Group Filter, NDJSON :
+	buffer.Reset()
+	require.NoError(testingT, env.users.ExportUsers(context.Background(), &buffer, models.ExportOptions{Format: constants.ExportFormatNDJSON, Group: "adult-2", BatchSize: 1}))
+
+	var users []models.User
+	scanner := bufio.NewScanner(&buffer)
+	for scanner.Scan() {
+
+		var user models.User
+		require.NoError(testingT, json.Unmarshal(scanner.Bytes(), &user))
+		users = append(users, user)
+	}
+
+	require.Len(testingT, users, 2)
+	assert.Equal(testingT, "adult-2", users[0].Group)
+	assert.Equal(testingT, "adult-2", users[1].Group)
+}
+
+func TestExportUsersFiltersByStatus(testingT *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	env := newTestServices(testingT)
+	seedUsers(testingT, env)
+
+	users, err := env.users.ListUsersByStatus(context.Background(), "adult-1", "")
+	require.NoError(testingT, err)
+
+	_, err = env.users.ChangeUserStatus(context.Background(), users[0].ID.String(), constants.UserStatusSuspended, models.ChangeStatusReq{Reason: "spam"})
+	require.NoError(testingT, err)
+
+	// Without A Status Suspended Users Are Left Out, As In The List :
+	var buffer bytes.Buffer
+	require.NoError(testingT, env.users.ExportUsers(context.Background(), &buffer, models.ExportOptions{Format: constants.ExportFormatCSV}))
+
+	records, err := csv.NewReader(&buffer).ReadAll()
+	require.NoError(testingT, err)
+	assert.Len(testingT, records, 6)
+
+	server := newTestServer(testingT, env)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/export?format=csv&status=suspended", nil)
+	resp := httptest.NewRecorder()
+	server.ServeHTTP(resp, req)
+
+	require.Equal(testingT, http.StatusOK, resp.Code)
+	records, err = csv.NewReader(resp.Body).ReadAll()
+	require.NoError(testingT, err)
+	require.Len(testingT, records, 2)
+	assert.Equal(testingT, users[0].Email, records[1][2])
+
+	// Unknown Statuses Are Rejected Before Streaming Starts :
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/users/export?status=banned", nil)
+	resp = httptest.NewRecorder()
+	server.ServeHTTP(resp, req)
+
+	assert.Equal(testingT, http.StatusBadRequest, resp.Code)
+	assert.Equal(testingT, "USER_STATUS_INVALID", decodeProblem(testingT, resp).ErrorCode)
+}
+
+func TestExportGroupsFiltersByBase(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	seedUsers(testingT, env)
+
+	var buffer bytes.Buffer
+	require.NoError(testingT, env.users.ExportGroups(context.Background(), &buffer, models.ExportOptions{Format: constants.ExportFormatCSV, BatchSize: 1}))
+
+	records, err := csv.NewReader(&buffer).ReadAll()
+	require.NoError(testingT, err)
+	require.Len(testingT, records, 4)
+	assert.Equal(testingT, []string{"adult-1", "adult", "1", "3", "3"}, records[1][:5])
+	assert.Equal(testingT, []string{"adult-2", "adult", "2", "3", "2"}, records[2][:5])
+	assert.Equal(testingT, "child-1", records[3][0])
+
+	buffer.Reset()
+	require.NoError(testingT, env.users.ExportGroups(context.Background(), &buffer, models.ExportOptions{Format: constants.ExportFormatCSV, Base: constants.BaseGroupAdult, BatchSize: 1}))
+
+	records, err = csv.NewReader(&buffer).ReadAll()
+	require.NoError(testingT, err)
+	assert.Len(testingT, records, 3)
+}
+
+func TestExportUsersOverHTTPWithGzip(testingT *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	env := newTestServices(testingT)
+	seedUsers(testingT, env)
+
+	server := newTestServer(testingT, env)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/export?format=csv&group=child-1&gzip=true", nil)
+	resp := httptest.NewRecorder()
+	server.ServeHTTP(resp, req)
+
+	require.Equal(testingT, http.StatusOK, resp.Code)
+	assert.Equal(testingT, constants.ContentTypeGzip, resp.Header().Get("Content-Type"))
+	assert.Contains(testingT, resp.Header().Get("Content-Disposition"), "users.csv.gz")
+
+	reader, err := gzip.NewReader(resp.Body)
+	require.NoError(testingT, err)
+
+	records, err := csv.NewReader(reader).ReadAll()
+	require.NoError(testingT, err)
+	require.Len(testingT, records, 2)
+	assert.Equal(testingT, "kid@test.com", records[1][2])
+
+	// Unsupported Formats Are Rejected Before Streaming Starts :
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/users/export?format=xml", nil)
+	resp = httptest.NewRecorder()
+	server.ServeHTTP(resp, req)
+
+	assert.Equal(testingT, http.StatusBadRequest, resp.Code)
+	assert.Equal(testingT, constants.ContentTypeProblemJSON, resp.Header().Get("Content-Type"))
+}
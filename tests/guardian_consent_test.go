@@ -0,0 +1,192 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"backend-task/internal/constants"
+	"backend-task/internal/i18n"
+	"backend-task/internal/mail"
+	userModels "backend-task/internal/user/models"
+	userRepository "backend-task/internal/user/repository"
+	userServices "backend-task/internal/user/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var consentLink = regexp.MustCompile(`\S+/api/v1/consents/grant\?token=([^&\s]+)`)
+
+// lastConsentToken Extracts The Token From The Newest Consent Email Sent To The Guardian :
+func lastConsentToken(testingT *testing.T, mailer *mail.InMemoryMailer, guardian string) string {
+
+	testingT.Helper()
+
+	messages := mailer.Messages()
+	for index := len(messages) - 1; index >= 0; index-- {
+
+		if messages[index].To != guardian {
+
+			continue
+		}
+
+		assert.Equal(testingT, i18n.Text(constants.LanguageEnglish, constants.MessageConsentEmailSubject), messages[index].Subject)
+
+		match := consentLink.FindStringSubmatch(messages[index].Body)
+		require.NotNil(testingT, match, messages[index].Body)
+
+		token, err := url.QueryUnescape(match[1])
+		require.NoError(testingT, err)
+
+		return token
+	}
+
+	require.FailNow(testingT, "no consent email sent to "+guardian)
+	return ""
+}
+
+// grantConsent Follows The Newest Consent Link Sent To The Guardian :
+func grantConsent(testingT *testing.T, env *testServices, guardian string) *userModels.User {
+
+	testingT.Helper()
+
+	user, err := env.users.GrantConsent(context.Background(), lastConsentToken(testingT, env.mailer, guardian))
+	require.NoError(testingT, err)
+
+	return user
+}
+
+func TestMinorsWaitForGuardianConsent(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	server := newTestServer(testingT, env)
+
+	// Minors Need Guardian Details :
+	resp := serveWithToken(server, http.MethodPost, "/api/v1/users", "", `[{"name":"Kid","email":"kid@test.com","date_of_birth":"2015-01-01"}]`)
+	assert.Equal(testingT, http.StatusBadRequest, resp.Code)
+	assert.Contains(testingT, resp.Body.String(), "guardian")
+
+	resp = serveWithToken(server, http.MethodPost, "/api/v1/users", "", `[{"name":"Kid","email":"kid@test.com","date_of_birth":"2015-01-01","guardian":{"name":"Parent","email":"kid@test.com"}}]`)
+	assert.Equal(testingT, http.StatusBadRequest, resp.Code)
+
+	resp = serveWithToken(server, http.MethodPost, "/api/v1/users", "", `[{"name":"Kid","email":"kid@test.com","date_of_birth":"2015-01-01","guardian":{"name":"Parent","email":"parent@test.com"}}]`)
+	require.Equal(testingT, http.StatusCreated, resp.Code, resp.Body.String())
+
+	var created []userModels.User
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &created))
+
+	kid := created[0]
+	assert.Equal(testingT, constants.UserStatusPendingConsent, kid.Status)
+	assert.Empty(testingT, kid.Group)
+
+	var seats int64
+	require.NoError(testingT, env.db.Model(&userModels.GroupMembership{}).Where("user_id = ?", kid.ID).Count(&seats).Error)
+	assert.Zero(testingT, seats)
+
+	// Consent Allocates The Minor :
+	token := lastConsentToken(testingT, env.mailer, "parent@test.com")
+	resp = serveWithToken(server, http.MethodGet, "/api/v1/consents/grant?token="+url.QueryEscape(token), "", "")
+	require.Equal(testingT, http.StatusOK, resp.Code, resp.Body.String())
+
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &kid))
+	assert.Equal(testingT, "child-1", kid.Group)
+	assert.Equal(testingT, constants.UserStatusActive, kid.Status)
+
+	// A Link Works Once :
+	resp = serveWithToken(server, http.MethodGet, "/api/v1/consents/grant?token="+url.QueryEscape(token), "", "")
+	assert.Equal(testingT, http.StatusBadRequest, resp.Code)
+
+	// The Record Is Queryable :
+	resp = serveWithToken(server, http.MethodGet, "/api/v1/consents?user_id="+kid.ID.String(), "", "")
+	require.Equal(testingT, http.StatusOK, resp.Code)
+
+	var consents []userModels.GuardianConsent
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &consents))
+	require.Len(testingT, consents, 1)
+	assert.Equal(testingT, "parent@test.com", consents[0].GuardianEmail)
+	assert.Equal(testingT, constants.ConsentStatusGranted, consents[0].Status)
+	assert.Equal(testingT, constants.ConsentMethodEmailLink, consents[0].Method)
+	assert.NotNil(testingT, consents[0].GrantedAt)
+
+	assert.Equal(testingT, http.StatusBadRequest, serveWithToken(server, http.MethodGet, "/api/v1/consents?status=maybe", "", "").Code)
+}
+
+func TestRevokingConsentReleasesTheSeat(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	server := newTestServer(testingT, env)
+
+	_, err := env.users.CreateUserWithGuardian(context.Background(), "Teen", "teen@test.com", "2010-01-01", &userModels.GuardianReq{Name: "Parent", Email: "parent@test.com"})
+	require.NoError(testingT, err)
+
+	teen := grantConsent(testingT, env, "parent@test.com")
+	require.Equal(testingT, "teen-1", teen.Group)
+
+	consents, err := env.users.ListConsents(context.Background(), teen.ID.String(), constants.ConsentStatusGranted)
+	require.NoError(testingT, err)
+	require.Len(testingT, consents, 1)
+
+	revokePath := "/api/v1/consents/" + consents[0].ID.String() + "/revoke"
+	resp := serveWithToken(server, http.MethodPost, revokePath, "", `{"reason":"guardian request"}`)
+	require.Equal(testingT, http.StatusOK, resp.Code, resp.Body.String())
+
+	var revoked userModels.GuardianConsent
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &revoked))
+	assert.Equal(testingT, constants.ConsentStatusRevoked, revoked.Status)
+	assert.Equal(testingT, "guardian request", revoked.RevocationReason)
+	assert.NotNil(testingT, revoked.RevokedAt)
+
+	// The Minor Is Unallocated Again :
+	teen, err = env.users.GetUserByID(context.Background(), teen.ID.String())
+	require.NoError(testingT, err)
+	assert.Empty(testingT, teen.Group)
+	assert.Equal(testingT, constants.UserStatusPendingConsent, teen.Status)
+
+	var group userModels.Group
+	require.NoError(testingT, env.db.First(&group, "name = ?", "teen-1").Error)
+	assert.Zero(testingT, group.MemberCount)
+
+	var membership userModels.GroupMembership
+	require.NoError(testingT, env.db.First(&membership, "user_id = ?", teen.ID).Error)
+	assert.NotNil(testingT, membership.LeftAt)
+
+	assert.Equal(testingT, http.StatusConflict, serveWithToken(server, http.MethodPost, revokePath, "", "").Code)
+	assert.Equal(testingT, http.StatusNotFound, serveWithToken(server, http.MethodPost, "/api/v1/consents/"+teen.ID.String()+"/revoke", "", "").Code)
+}
+
+func TestConsentLinksExpire(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	mailer := mail.NewInMemoryMailer()
+	users := userServices.NewUserService(env.db, userRepository.NewUserRepository(env.db), userRepository.NewGroupRepository(env.db), userRepository.NewConsentRepository(env.db), env.audit, env.events, userServices.VerificationConfig{
+		Mailer:     mailer,
+		ConsentTTL: time.Hour,
+		BaseURL:    "https://api.example.com",
+		Now:        func() time.Time { return now },
+	}, userServices.EmailPolicy{}, nil, nil)
+
+	_, err := users.CreateUserWithGuardian(context.Background(), "Kid", "kid@test.com", "2015-01-01", &userModels.GuardianReq{Name: "Parent", Email: "parent@test.com"})
+	require.NoError(testingT, err)
+
+	now = now.Add(time.Hour)
+	_, err = users.GrantConsent(context.Background(), lastConsentToken(testingT, mailer, "parent@test.com"))
+	assert.ErrorContains(testingT, err, "invalid or expired consent token")
+}
+
+func TestGrantConsentIsPublic(testingT *testing.T) {
+
+	_, server := newAuthTestServer(testingT)
+
+	resp := serveWithToken(server, http.MethodGet, "/api/v1/consents/grant?token=not-a-token", "", "")
+	assert.Equal(testingT, http.StatusBadRequest, resp.Code)
+
+	// Listing Consents Is Not :
+	assert.Equal(testingT, http.StatusUnauthorized, serveWithToken(server, http.MethodGet, "/api/v1/consents", "", "").Code)
+}
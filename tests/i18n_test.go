@@ -0,0 +1,128 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"backend-task/internal/constants"
+	"backend-task/internal/i18n"
+	userModels "backend-task/internal/user/models"
+	"backend-task/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveInLanguage Sends The Request With An Accept-Language Header :
+func serveInLanguage(server http.Handler, method, path, acceptLanguage, body string) *httptest.ResponseRecorder {
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(constants.HeaderAcceptLanguage, acceptLanguage)
+	if body != "" {
+
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp := httptest.NewRecorder()
+	server.ServeHTTP(resp, req)
+
+	return resp
+}
+
+func TestNegotiateLanguage(testingT *testing.T) {
+
+	cases := map[string]string{
+
+		"":                        constants.LanguageEnglish,
+		"ar":                      constants.LanguageArabic,
+		"ar-JO,ar;q=0.9,en;q=0.8": constants.LanguageArabic,
+		"fr-FR,de;q=0.7,en;q=0.5": constants.LanguageGerman,
+		"en;q=0.4, DE-at;q=0.6":   constants.LanguageGerman,
+		"fr, *;q=0.5":             constants.LanguageEnglish,
+		"de;q=0, ar;q=bogus, en":  constants.LanguageEnglish,
+	}
+
+	for header, expected := range cases {
+
+		assert.Equal(testingT, expected, i18n.Negotiate(header), header)
+	}
+}
+
+func TestErrorsAreLocalized(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	server := newTestServer(testingT, env)
+
+	body := `[{"name":"Jane","email":"jane@test.com","date_of_birth":"1990-01-01"}]`
+	require.Equal(testingT, http.StatusCreated, serveWithToken(server, http.MethodPost, "/api/v1/users", "", body).Code)
+
+	// The Code Stays The Same In Every Language :
+	resp := serveInLanguage(server, http.MethodPost, "/api/v1/users", "ar-JO,ar;q=0.9", body)
+	problem := decodeProblem(testingT, resp)
+	assert.Equal(testingT, constants.LanguageArabic, resp.Header().Get(constants.HeaderContentLanguage))
+	assert.Equal(testingT, "USER_EMAIL_TAKEN", problem.ErrorCode)
+	assert.Equal(testingT, "البريد الإلكتروني مستخدم بالفعل", problem.Detail)
+
+	resp = serveInLanguage(server, http.MethodPost, "/api/v1/users", "de-DE", body)
+	problem = decodeProblem(testingT, resp)
+	assert.Equal(testingT, "USER_EMAIL_TAKEN", problem.ErrorCode)
+	assert.Equal(testingT, "E-Mail-Adresse ist bereits vergeben", problem.Detail)
+	assert.Equal(testingT, "Konflikt", problem.Title)
+
+	// Unsupported Languages Fall Back To English :
+	resp = serveInLanguage(server, http.MethodPost, "/api/v1/users", "fr-FR", body)
+	assert.Equal(testingT, constants.LanguageEnglish, resp.Header().Get(constants.HeaderContentLanguage))
+	assert.Equal(testingT, utils.ErrEmailAlreadyExists.Error(), decodeProblem(testingT, resp).Detail)
+
+	// Field Messages Too :
+	resp = serveInLanguage(server, http.MethodPost, "/api/v1/users", "de", `[{"email":"kid@test.com","date_of_birth":"1990-01-01"}]`)
+	problem = decodeProblem(testingT, resp)
+	assert.Equal(testingT, "VALIDATION_FAILED", problem.ErrorCode)
+	assert.Equal(testingT, []userModels.FieldError{{Field: "[0].name", Rule: "required", Message: "name ist erforderlich"}}, problem.Errors)
+}
+
+func TestEmailsAreLocalized(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	server := newTestServer(testingT, env)
+
+	resp := serveInLanguage(server, http.MethodPost, "/api/v1/users", "de", `[{"name":"Jane","email":"jane@test.com","date_of_birth":"1990-01-01"}]`)
+	require.Equal(testingT, http.StatusCreated, resp.Code, resp.Body.String())
+
+	messages := env.mailer.Messages()
+	require.NotEmpty(testingT, messages)
+	assert.Equal(testingT, "Bestätigen Sie Ihre E-Mail-Adresse", messages[len(messages)-1].Subject)
+	assert.Contains(testingT, messages[len(messages)-1].Body, "Hallo Jane,")
+	assert.Contains(testingT, messages[len(messages)-1].Body, constants.EmailVerificationPath+"?token=")
+}
+
+func TestCatalogsAreComplete(testingT *testing.T) {
+
+	for _, language := range i18n.Languages() {
+
+		if language == constants.DefaultLanguage {
+
+			continue
+		}
+
+		// Every Registered Error And Every English Message Has A Translation :
+		for _, code := range utils.ErrorCodes() {
+
+			_, found := i18n.Lookup(language, code)
+			assert.True(testingT, found, language+" is missing "+code)
+		}
+
+		for _, key := range []string{
+
+			constants.MessageEmailVerificationSubject, constants.MessageEmailVerificationBody,
+			constants.MessageConsentEmailSubject, constants.MessageConsentEmailBody,
+			constants.MessageRulePrefix + "required", constants.MessageRulePrefix + "default",
+		} {
+
+			_, found := i18n.Lookup(language, key)
+			assert.True(testingT, found, language+" is missing "+key)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package tests
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"backend-task/internal/constants"
+	jobModels "backend-task/internal/job/models"
+	jobRepository "backend-task/internal/job/repository"
+	jobServices "backend-task/internal/job/services"
+	models "backend-task/internal/user/models"
+	services "backend-task/internal/user/services"
+	userServiceInterface "backend-task/internal/user/services/interface"
+	"backend-task/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// interruptingUserService Simulates A Crash Right After The First Chunk Is Committed,
+// Before The Worker Records Its Progress :
+type interruptingUserService struct {
+	userServiceInterface.UserService
+	interrupt func()
+	calls     int
+}
+
+func (interrupting *interruptingUserService) ImportUsers(context context.Context, rows []models.ImportRow, options models.ImportOptions) (*models.ImportReport, error) {
+
+	report, err := interrupting.UserService.ImportUsers(context, rows, options)
+	interrupting.calls++
+	if interrupting.calls == 1 {
+
+		interrupting.interrupt()
+	}
+
+	return report, err
+}
+
+func newJobWorker(env *testServices, users userServiceInterface.UserService) *jobServices.Worker {
+
+	return jobServices.NewWorker(jobRepository.NewJobRepository(env.db), users, jobServices.WorkerConfig{PollInterval: time.Second, ChunkSize: 2})
+}
+
+func TestImportJobLifecycleOverHTTP(testingT *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	env := newTestServices(testingT)
+	server := newTestServer(testingT, env)
+	serve := func(method, path, contentType, body string) *httptest.ResponseRecorder {
+
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if contentType != "" {
+
+			req.Header.Set("Content-Type", contentType)
+		}
+
+		resp := httptest.NewRecorder()
+		server.ServeHTTP(resp, req)
+		return resp
+	}
+
+	file := "name,email,date_of_birth\n" +
+		"A,a@test.com,1990-01-01\n" +
+		"B,b@test.com,1990-01-01\n" +
+		"Broken,broken,1990-01-01\n" +
+		"A Again,A@test.com,1990-01-01\n" +
+		"C,c@test.com,1990-01-01\n"
+
+	resp := serve(http.MethodPost, "/api/v1/jobs/user-imports", "text/csv", file)
+	require.Equal(testingT, http.StatusAccepted, resp.Code)
+
+	var job jobModels.Job
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &job))
+	assert.Equal(testingT, constants.JobQueued, job.State)
+	assert.Equal(testingT, 5, job.Total)
+	assert.Equal(testingT, 1, job.Failed) // The In-File Duplicate Is Rejected On Submission.
+	assert.Equal(testingT, "/api/v1/jobs/"+job.ID.String(), resp.Header().Get(constants.HeaderLocation))
+
+	processed, err := newJobWorker(env, env.users).RunNext(context.Background())
+	require.NoError(testingT, err)
+	assert.True(testingT, processed)
+
+	resp = serve(http.MethodGet, "/api/v1/jobs/"+job.ID.String(), "", "")
+	require.Equal(testingT, http.StatusOK, resp.Code)
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &job))
+
+	assert.Equal(testingT, constants.JobCompleted, job.State)
+	assert.Equal(testingT, 5, job.Processed)
+	assert.Equal(testingT, 3, job.Succeeded)
+	assert.Equal(testingT, 2, job.Failed)
+	assert.NotNil(testingT, job.FinishedAt)
+	assert.Equal(testingT, fmt.Sprintf("/api/v1/jobs/%s/errors", job.ID), job.ErrorFile)
+
+	// The Error File Lists The Rejected Rows With Their Line Numbers :
+	resp = serve(http.MethodGet, job.ErrorFile, "", "")
+	require.Equal(testingT, http.StatusOK, resp.Code)
+	assert.Equal(testingT, constants.ContentTypeCSV, resp.Header().Get("Content-Type"))
+
+	records, err := csv.NewReader(resp.Body).ReadAll()
+	require.NoError(testingT, err)
+	require.Len(testingT, records, 3)
+	assert.Equal(testingT, []string{"4", "Broken", "broken", "1990-01-01", constants.ImportRowInvalid, utils.ErrInvalidEmailFormat.Error()}, records[1])
+	assert.Equal(testingT, services.DuplicateEmailMessage(2), records[2][5])
+
+	// Finished Jobs Cannot Be Cancelled; Unknown Jobs Are 404 :
+	assert.Equal(testingT, http.StatusConflict, serve(http.MethodPost, "/api/v1/jobs/"+job.ID.String()+"/cancel", "", "").Code)
+	assert.Equal(testingT, http.StatusNotFound, serve(http.MethodGet, "/api/v1/jobs/00000000-0000-0000-0000-000000000000", "", "").Code)
+	assert.Equal(testingT, http.StatusUnsupportedMediaType, serve(http.MethodPost, "/api/v1/jobs/user-imports", "application/json", "[]").Code)
+}
+
+func TestImportJobResumesFromCheckpointWithoutDuplicates(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+
+	var rows []models.ImportRow
+	for i := 0; i < 5; i++ {
+
+		rows = append(rows, models.ImportRow{Row: i + 2, Name: "User", Email: fmt.Sprintf("user%d@test.com", i), DateOfBirth: "1990-01-01"})
+	}
+
+	job, err := env.jobs.SubmitUserImport(context.Background(), rows, false)
+	require.NoError(testingT, err)
+
+	// First Run Is Interrupted After Committing The First Chunk :
+	crashContext, crash := context.WithCancel(context.Background())
+	_, err = newJobWorker(env, &interruptingUserService{UserService: env.users, interrupt: crash}).RunNext(crashContext)
+	require.NoError(testingT, err)
+
+	job, err = env.jobs.GetJob(context.Background(), job.ID.String())
+	require.NoError(testingT, err)
+	assert.Equal(testingT, constants.JobRunning, job.State)
+
+	// After A Restart The Job Is Requeued And Finishes :
+	worker := newJobWorker(env, env.users)
+	requeued, err := worker.Resume(context.Background())
+	require.NoError(testingT, err)
+	assert.Equal(testingT, int64(1), requeued)
+
+	processed, err := worker.RunNext(context.Background())
+	require.NoError(testingT, err)
+	assert.True(testingT, processed)
+
+	job, err = env.jobs.GetJob(context.Background(), job.ID.String())
+	require.NoError(testingT, err)
+	assert.Equal(testingT, constants.JobCompleted, job.State)
+	assert.Equal(testingT, 5, job.Succeeded)
+	assert.Equal(testingT, 0, job.Failed)
+
+	users, err := env.users.ListUsersByFilter(context.Background(), "")
+	require.NoError(testingT, err)
+	assert.Len(testingT, users, 5)
+}
+
+func TestCancelledJobIsNotProcessed(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+
+	job, err := env.jobs.SubmitUserImport(context.Background(), []models.ImportRow{{Row: 2, Name: "A", Email: "a@test.com", DateOfBirth: "1990-01-01"}}, false)
+	require.NoError(testingT, err)
+
+	job, err = env.jobs.CancelJob(context.Background(), job.ID.String())
+	require.NoError(testingT, err)
+	assert.Equal(testingT, constants.JobCancelled, job.State)
+
+	processed, err := newJobWorker(env, env.users).RunNext(context.Background())
+	require.NoError(testingT, err)
+	assert.False(testingT, processed)
+
+	users, err := env.users.ListUsersByFilter(context.Background(), "")
+	require.NoError(testingT, err)
+	assert.Empty(testingT, users)
+}
+
+func TestImportJobCarriesGuardians(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+
+	rows := []models.ImportRow{{Row: 2, Name: "Kid", Email: "kid@test.com", DateOfBirth: "2020-01-01", GuardianName: "Parent", GuardianEmail: "parent@test.com"}}
+	job, err := env.jobs.SubmitUserImport(context.Background(), rows, false)
+	require.NoError(testingT, err)
+
+	processed, err := newJobWorker(env, env.users).RunNext(context.Background())
+	require.NoError(testingT, err)
+	assert.True(testingT, processed)
+
+	job, err = env.jobs.GetJob(context.Background(), job.ID.String())
+	require.NoError(testingT, err)
+	assert.Equal(testingT, 1, job.Succeeded)
+
+	// The Guardian Stored With The Job Row Received The Consent Request :
+	kid, err := env.users.GrantConsent(context.Background(), lastConsentToken(testingT, env.mailer, "parent@test.com"))
+	require.NoError(testingT, err)
+	assert.Equal(testingT, "child-1", kid.Group)
+}
+
+func TestImportJobDetectsDuplicatesByCanonicalEmail(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+
+	// One Gmail Mailbox, Spelled Three Ways :
+	rows := []models.ImportRow{
+		{Row: 2, Name: "Jane", Email: "jane.doe@gmail.com", DateOfBirth: "1990-01-01"},
+		{Row: 3, Name: "Jane", Email: "JaneDoe+news@gmail.com", DateOfBirth: "1990-01-01"},
+		{Row: 4, Name: "Jane", Email: "janedoe@googlemail.com", DateOfBirth: "1990-01-01"},
+	}
+
+	job, err := env.jobs.SubmitUserImport(context.Background(), rows, false)
+	require.NoError(testingT, err)
+	assert.Equal(testingT, 2, job.Failed)
+
+	processed, err := newJobWorker(env, env.users).RunNext(context.Background())
+	require.NoError(testingT, err)
+	assert.True(testingT, processed)
+
+	job, err = env.jobs.GetJob(context.Background(), job.ID.String())
+	require.NoError(testingT, err)
+	assert.Equal(testingT, 1, job.Succeeded)
+	assert.Equal(testingT, 2, job.Failed)
+}
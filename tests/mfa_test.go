@@ -0,0 +1,274 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	authModels "backend-task/internal/auth/models"
+	authRepository "backend-task/internal/auth/repository"
+	authServices "backend-task/internal/auth/services"
+	serviceInterface "backend-task/internal/auth/services/interface"
+	"backend-task/internal/constants"
+	userRepository "backend-task/internal/user/repository"
+
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mfaTestAccount Is A Registered Account With Activated TOTP, Driven By An Injected Clock :
+type mfaTestAccount struct {
+	accounts      serviceInterface.AccountService
+	verifier      *authServices.JWTVerifier
+	now           *time.Time
+	userID        string
+	secret        string
+	recoveryCodes []string
+}
+
+func newMFATestAccount(testingT *testing.T) *mfaTestAccount {
+
+	testingT.Helper()
+
+	env := newTestServices(testingT)
+
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	keyFile := writeTestFile(testingT, "hmac.key", []byte(testJWTSecret))
+
+	issuer, err := authServices.NewTokenIssuer(authServices.TokenIssuerConfig{HMACKeyFile: keyFile, TTL: time.Minute, Now: clock})
+	require.NoError(testingT, err)
+
+	verifier, err := authServices.NewJWTVerifier(authServices.JWTConfig{HMACKeyFile: keyFile, Now: clock})
+	require.NoError(testingT, err)
+
+	accounts := authServices.NewAccountService(env.db, authRepository.NewAccountRepository(env.db), userRepository.NewUserRepository(env.db), env.users, env.audit, issuer, authServices.AccountConfig{
+		RefreshTTL:       time.Hour,
+		LockoutThreshold: 20,
+		LockoutBaseDelay: time.Minute,
+		LockoutMaxDelay:  5 * time.Minute,
+		MFAIssuer:        "Backend Task",
+		Now:              clock,
+	})
+
+	ctx := context.Background()
+	user, err := accounts.Register(ctx, authModels.RegisterReq{Name: "Admin", Email: "admin@test.com", DateOfBirth: "1990-01-01", Password: testPassword})
+	require.NoError(testingT, err)
+
+	enrollment, err := accounts.EnrollMFA(ctx, user.ID.String())
+	require.NoError(testingT, err)
+
+	uri, err := url.Parse(enrollment.OTPAuthURI)
+	require.NoError(testingT, err)
+	assert.Equal(testingT, "otpauth", uri.Scheme)
+	assert.Equal(testingT, "totp", uri.Host)
+	assert.Equal(testingT, "/Backend Task:admin@test.com", uri.Path)
+	assert.Equal(testingT, enrollment.Secret, uri.Query().Get("secret"))
+
+	account := &mfaTestAccount{accounts: accounts, verifier: verifier, now: &now, userID: user.ID.String(), secret: enrollment.Secret}
+
+	_, err = accounts.ActivateMFA(ctx, account.userID, "000000")
+	require.Error(testingT, err)
+
+	codes, err := accounts.ActivateMFA(ctx, account.userID, account.code(testingT, 0))
+	require.NoError(testingT, err)
+	require.Len(testingT, codes.RecoveryCodes, constants.RecoveryCodeCount)
+	account.recoveryCodes = codes.RecoveryCodes
+
+	return account
+}
+
+// code Returns The TOTP Code For The Time Step At offset Steps From The Injected Clock :
+func (account *mfaTestAccount) code(testingT *testing.T, offset int) string {
+
+	code, err := totp.GenerateCode(account.secret, account.now.Add(time.Duration(offset*constants.TOTPPeriodSeconds)*time.Second))
+	require.NoError(testingT, err)
+
+	return code
+}
+
+func (account *mfaTestAccount) challenge(testingT *testing.T) string {
+
+	tokens, err := account.accounts.Login(context.Background(), authModels.LoginReq{Email: "admin@test.com", Password: testPassword})
+	require.NoError(testingT, err)
+	require.True(testingT, tokens.MFARequired)
+	assert.Empty(testingT, tokens.AccessToken)
+	assert.Empty(testingT, tokens.RefreshToken)
+
+	return tokens.MFAToken
+}
+
+func (account *mfaTestAccount) verify(challenge string, req authModels.MFACodeReq) (*authModels.TokenPair, error) {
+
+	return account.accounts.VerifyMFA(context.Background(), authModels.MFAVerifyReq{MFAToken: challenge, MFACodeReq: req})
+}
+
+func TestMFALoginChallengeAcceptsDriftAndRejectsReplay(testingT *testing.T) {
+
+	account := newMFATestAccount(testingT)
+
+	// Enrolment Is Finished, Enrolling Again Conflicts :
+	_, err := account.accounts.EnrollMFA(context.Background(), account.userID)
+	assert.ErrorContains(testingT, err, "mfa is already enabled")
+
+	// The Activation Code's Time Step Cannot Be Used Again :
+	challenge := account.challenge(testingT)
+	_, err = account.verify(challenge, authModels.MFACodeReq{Code: account.code(testingT, 0)})
+	assert.Error(testingT, err)
+
+	// One Step Of Drift Is Accepted, Two Are Not :
+	*account.now = account.now.Add(time.Duration(constants.TOTPPeriodSeconds) * time.Second)
+	_, err = account.verify(challenge, authModels.MFACodeReq{Code: account.code(testingT, -2)})
+	assert.Error(testingT, err)
+
+	tokens, err := account.verify(challenge, authModels.MFACodeReq{Code: account.code(testingT, 1)})
+	require.NoError(testingT, err)
+	assert.NotEmpty(testingT, tokens.RefreshToken)
+
+	claims, err := account.verifier.Verify(tokens.AccessToken)
+	require.NoError(testingT, err)
+	assert.Equal(testingT, []string{constants.AMRPassword, constants.AMRMFA, constants.AMROTP}, claims.AMR)
+
+	// A Challenge Works Once :
+	*account.now = account.now.Add(time.Duration(constants.TOTPPeriodSeconds) * time.Second)
+	_, err = account.verify(challenge, authModels.MFACodeReq{Code: account.code(testingT, 0)})
+	assert.ErrorContains(testingT, err, "invalid or expired mfa challenge")
+
+	// Refreshing Keeps The Second Factor :
+	refreshed, err := account.accounts.Refresh(context.Background(), tokens.RefreshToken)
+	require.NoError(testingT, err)
+
+	claims, err = account.verifier.Verify(refreshed.AccessToken)
+	require.NoError(testingT, err)
+	assert.Contains(testingT, claims.AMR, constants.AMRMFA)
+}
+
+func TestMFARecoveryCodesAndChallengeLimits(testingT *testing.T) {
+
+	account := newMFATestAccount(testingT)
+
+	// Recovery Codes Ignore Case And Work Once :
+	recoveryCode := strings.ToUpper(account.recoveryCodes[0])
+	_, err := account.verify(account.challenge(testingT), authModels.MFACodeReq{RecoveryCode: recoveryCode})
+	require.NoError(testingT, err)
+
+	_, err = account.verify(account.challenge(testingT), authModels.MFACodeReq{RecoveryCode: recoveryCode})
+	assert.ErrorContains(testingT, err, "invalid mfa code")
+
+	// Challenges Expire :
+	challenge := account.challenge(testingT)
+	*account.now = account.now.Add(time.Duration(constants.MFAChallengeTTL+1) * time.Second)
+	_, err = account.verify(challenge, authModels.MFACodeReq{Code: account.code(testingT, 0)})
+	assert.ErrorContains(testingT, err, "invalid or expired mfa challenge")
+
+	// Too Many Wrong Codes Burn The Challenge :
+	challenge = account.challenge(testingT)
+	for i := 0; i < constants.MFAChallengeMaxAttempts; i++ {
+
+		_, err = account.verify(challenge, authModels.MFACodeReq{Code: "000000"})
+		assert.ErrorContains(testingT, err, "invalid mfa code")
+	}
+
+	_, err = account.verify(challenge, authModels.MFACodeReq{Code: account.code(testingT, 0)})
+	assert.ErrorContains(testingT, err, "invalid or expired mfa challenge")
+
+	_, err = account.verify(account.challenge(testingT), authModels.MFACodeReq{})
+	assert.ErrorContains(testingT, err, "either code or recovery_code is required")
+}
+
+func TestMFAStepUpFailuresLockTheAccount(testingT *testing.T) {
+
+	account := newMFATestAccount(testingT)
+	ctx := context.Background()
+
+	tokens, err := account.verify(account.challenge(testingT), authModels.MFACodeReq{RecoveryCode: account.recoveryCodes[0]})
+	require.NoError(testingT, err)
+
+	claims, err := account.verifier.Verify(tokens.AccessToken)
+	require.NoError(testingT, err)
+
+	stepUp := func(code string) error {
+
+		_, err := account.accounts.StepUp(ctx, account.userID, claims.SessionID, authModels.MFACodeReq{Code: code})
+		return err
+	}
+
+	// A Stolen Session Cannot Guess Codes Past The Lockout Threshold ( 20 ) :
+	for i := 0; i < 20; i++ {
+
+		assert.ErrorContains(testingT, stepUp("000000"), "invalid mfa code")
+	}
+
+	*account.now = account.now.Add(time.Duration(constants.TOTPPeriodSeconds) * time.Second)
+	assert.ErrorContains(testingT, stepUp(account.code(testingT, 0)), "invalid mfa code")
+
+	// The Lock Covers Password Login Too :
+	_, err = account.accounts.Login(ctx, authModels.LoginReq{Email: "admin@test.com", Password: testPassword})
+	assert.ErrorContains(testingT, err, "invalid email or password")
+
+	// Once It Expires, A Valid Code Works And Resets The Counter :
+	*account.now = account.now.Add(time.Minute)
+	assert.NoError(testingT, stepUp(account.code(testingT, 0)))
+	assert.NotEmpty(testingT, account.challenge(testingT))
+}
+
+func TestAdminRoutesRequireMFA(testingT *testing.T) {
+
+	_, server := newAuthTestServer(testingT)
+
+	// Signed Tokens Need An MFA Claim :
+	admin := signHS256(testingT, newTestClaims("admin-1", constants.RoleAdmin))
+	resp := serveWithToken(server, http.MethodGet, "/api/v1/audit", admin, "")
+	assert.Equal(testingT, http.StatusUnauthorized, resp.Code)
+	assert.Contains(testingT, resp.Header().Get(constants.HeaderWWWAuthenticate), "insufficient_user_authentication")
+
+	// Non-Sensitive Permissions Do Not :
+	assert.Equal(testingT, http.StatusOK, serveWithToken(server, http.MethodGet, "/api/v1/users", admin, "").Code)
+
+	// Promoting An Account Takes An MFA-Verified Admin :
+	user := registerTestAccount(testingT, server, "promoted@test.com")
+	rolesPath := "/api/v1/auth/accounts/" + user.ID.String() + "/roles"
+	assert.Equal(testingT, http.StatusUnauthorized, serveWithToken(server, http.MethodPut, rolesPath, admin, `{"roles":["admin"]}`).Code)
+	assert.Equal(testingT, http.StatusNoContent, serveWithToken(server, http.MethodPut, rolesPath, signHS256(testingT, withMFA(newTestClaims("admin-1", constants.RoleAdmin))), `{"roles":["admin"]}`).Code)
+
+	status, tokens := loginTestAccount(testingT, server, "promoted@test.com", testPassword)
+	require.Equal(testingT, http.StatusOK, status)
+	assert.Equal(testingT, http.StatusUnauthorized, serveWithToken(server, http.MethodGet, "/api/v1/audit", tokens.AccessToken, "").Code)
+
+	// Enrol, Then Step Up The Running Session :
+	resp = serveWithToken(server, http.MethodPost, "/api/v1/auth/mfa/enroll", tokens.AccessToken, "")
+	require.Equal(testingT, http.StatusOK, resp.Code, resp.Body.String())
+
+	var enrollment authModels.MFAEnrollment
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &enrollment))
+
+	code, err := totp.GenerateCode(enrollment.Secret, time.Now())
+	require.NoError(testingT, err)
+
+	resp = serveWithToken(server, http.MethodPost, "/api/v1/auth/mfa/activate", tokens.AccessToken, `{"code":"`+code+`"}`)
+	require.Equal(testingT, http.StatusOK, resp.Code, resp.Body.String())
+
+	var recovery authModels.RecoveryCodes
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &recovery))
+
+	resp = serveWithToken(server, http.MethodPost, "/api/v1/auth/mfa/step-up", tokens.AccessToken, `{"recovery_code":"`+recovery.RecoveryCodes[0]+`"}`)
+	require.Equal(testingT, http.StatusOK, resp.Code, resp.Body.String())
+
+	var stepped authModels.TokenPair
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &stepped))
+	assert.Equal(testingT, http.StatusOK, serveWithToken(server, http.MethodGet, "/api/v1/audit", stepped.AccessToken, "").Code)
+
+	// From Now On Login Stops At The Challenge :
+	status, tokens = loginTestAccount(testingT, server, "promoted@test.com", testPassword)
+	require.Equal(testingT, http.StatusOK, status)
+	assert.True(testingT, tokens.MFARequired)
+	assert.Empty(testingT, tokens.AccessToken)
+
+	resp = serveWithToken(server, http.MethodPost, "/api/v1/auth/mfa/verify", "", `{"mfa_token":"`+tokens.MFAToken+`","recovery_code":"`+recovery.RecoveryCodes[1]+`"}`)
+	require.Equal(testingT, http.StatusOK, resp.Code, resp.Body.String())
+}
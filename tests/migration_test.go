@@ -0,0 +1,74 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"backend-task/internal/constants"
+	"backend-task/internal/db"
+	"backend-task/internal/router"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// baselineUser And baselineGroup Mirror The Schema Before Tenants Existed ( Global Unique Email, Group Name As Key ) :
+type baselineUser struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Name        string    `gorm:"not null;size:255"`
+	Email       string    `gorm:"not null;uniqueIndex;size:320"`
+	DateOfBirth time.Time `gorm:"type:date;not null"`
+	Group       string    `gorm:"not null;index;size:64"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (baselineUser) TableName() string { return "users" }
+
+type baselineGroup struct {
+	Name        string `gorm:"primaryKey;size:64"`
+	Base        string `gorm:"not null;index;size:32"`
+	Index       int    `gorm:"not null;index"`
+	Capacity    int    `gorm:"not null;default:3"`
+	MemberCount int    `gorm:"not null;default:0"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (baselineGroup) TableName() string { return "groups" }
+
+func TestMigrateUpgradesBaselineSchemaToTenants(testingT *testing.T) {
+
+	gormDB := openTestDB(testingT)
+	require.NoError(testingT, gormDB.AutoMigrate(&baselineUser{}, &baselineGroup{}))
+
+	dob := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(testingT, gormDB.Create(&baselineUser{ID: uuid.New(), Name: "Jane", Email: "jane@test.com", DateOfBirth: dob, Group: "adult-1"}).Error)
+	require.NoError(testingT, gormDB.Create(&baselineGroup{Name: "adult-1", Base: "adult", Index: 1, Capacity: 3, MemberCount: 1}).Error)
+
+	require.NoError(testingT, db.Migrate(gormDB))
+
+	// Migrating Again Is A No-Op :
+	require.NoError(testingT, db.Migrate(gormDB))
+	assert.False(testingT, gormDB.Migrator().HasIndex("users", constants.IndexUsersEmail))
+	assert.True(testingT, gormDB.Migrator().HasIndex("users", constants.IndexUsersTenantEmailCanonical))
+	assert.False(testingT, gormDB.Migrator().HasTable(constants.TableGroupsLegacy))
+
+	useTestJWTKey(testingT)
+	env := newTestServicesOn(testingT, gormDB)
+	server := router.SetupRouters(env.all, newTestBroker(testingT))
+
+	// Existing Rows Land In The Default Tenant :
+	assert.Equal(testingT, 1, groupMemberCount(testingT, env, "adult-1"))
+	assert.Len(testingT, tenantUserIDs(testingT, server, tenantAdmin(testingT, constants.DefaultTenantID)), 1)
+
+	// Another Tenant Reuses The Email And Numbers Its Groups From adult-1 Again :
+	acme := createInTenant(testingT, server, "acme", `{"name":"Jane","email":"jane@test.com","date_of_birth":"1990-01-01"}`)
+	assert.Equal(testingT, "adult-1", acme.Group)
+
+	// The Default Tenant Keeps Its Seat Count And Still Rejects The Duplicate :
+	resp := serveInTenant(server, http.MethodPost, "/api/v1/users", tenantAdmin(testingT, constants.DefaultTenantID), constants.DefaultTenantID, `[{"name":"Jane","email":"jane@test.com","date_of_birth":"1990-01-01"}]`)
+	assert.Equal(testingT, http.StatusConflict, resp.Code, resp.Body.String())
+}
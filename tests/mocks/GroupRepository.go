@@ -3,10 +3,17 @@
 package mocks
 
 import (
+	context "context"
+
 	models "backend-task/internal/user/models"
 
 	mock "github.com/stretchr/testify/mock"
+
 	gorm "gorm.io/gorm"
+
+	uuid "github.com/google/uuid"
+
+	time "time"
 )
 
 // GroupRepository is an autogenerated mock type for the GroupRepository type
@@ -14,29 +21,102 @@ type GroupRepository struct {
 	mock.Mock
 }
 
-// FindAllocatableGroupTx provides a mock function with given fields: gormDB, base
-func (_m *GroupRepository) FindAllocatableGroupTx(gormDB *gorm.DB, base string) (*models.Group, error) {
-	ret := _m.Called(gormDB, base)
+// CloseMembershipTx provides a mock function with given fields: gormDB, userID, leftAt
+func (_m *GroupRepository) CloseMembershipTx(gormDB *gorm.DB, userID uuid.UUID, leftAt time.Time) error {
+	ret := _m.Called(gormDB, userID, leftAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloseMembershipTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, uuid.UUID, time.Time) error); ok {
+		r0 = rf(gormDB, userID, leftAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DecrementGroupCountTx provides a mock function with given fields: gormDB, name
+func (_m *GroupRepository) DecrementGroupCountTx(gormDB *gorm.DB, name string) error {
+	ret := _m.Called(gormDB, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DecrementGroupCountTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, string) error); ok {
+		r0 = rf(gormDB, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindAllocatableGroupTx provides a mock function with given fields: gormDB, base, capacity
+func (_m *GroupRepository) FindAllocatableGroupTx(gormDB *gorm.DB, base string, capacity int) (*models.Group, bool, error) {
+	ret := _m.Called(gormDB, base, capacity)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindAllocatableGroupTx")
 	}
 
+	var r0 *models.Group
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, string, int) (*models.Group, bool, error)); ok {
+		return rf(gormDB, base, capacity)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, string, int) *models.Group); ok {
+		r0 = rf(gormDB, base, capacity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, string, int) bool); ok {
+		r1 = rf(gormDB, base, capacity)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(*gorm.DB, string, int) error); ok {
+		r2 = rf(gormDB, base, capacity)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetGroup provides a mock function with given fields: _a0, name
+func (_m *GroupRepository) GetGroup(_a0 context.Context, name string) (*models.Group, error) {
+	ret := _m.Called(_a0, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGroup")
+	}
+
 	var r0 *models.Group
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gorm.DB, string) (*models.Group, error)); ok {
-		return rf(gormDB, base)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Group, error)); ok {
+		return rf(_a0, name)
 	}
-	if rf, ok := ret.Get(0).(func(*gorm.DB, string) *models.Group); ok {
-		r0 = rf(gormDB, base)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Group); ok {
+		r0 = rf(_a0, name)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Group)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*gorm.DB, string) error); ok {
-		r1 = rf(gormDB, base)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, name)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -62,6 +142,102 @@ func (_m *GroupRepository) IncrementGroupCountTx(gormDB *gorm.DB, name string) e
 	return r0
 }
 
+// ListGroupsAfter provides a mock function with given fields: _a0, base, after, limit
+func (_m *GroupRepository) ListGroupsAfter(_a0 context.Context, base string, after *models.Group, limit int) ([]*models.Group, error) {
+	ret := _m.Called(_a0, base, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListGroupsAfter")
+	}
+
+	var r0 []*models.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.Group, int) ([]*models.Group, error)); ok {
+		return rf(_a0, base, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.Group, int) []*models.Group); ok {
+		r0 = rf(_a0, base, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.Group, int) error); ok {
+		r1 = rf(_a0, base, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListMemberships provides a mock function with given fields: _a0, userID
+func (_m *GroupRepository) ListMemberships(_a0 context.Context, userID uuid.UUID) ([]*models.GroupMembership, error) {
+	ret := _m.Called(_a0, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListMemberships")
+	}
+
+	var r0 []*models.GroupMembership
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*models.GroupMembership, error)); ok {
+		return rf(_a0, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*models.GroupMembership); ok {
+		r0 = rf(_a0, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.GroupMembership)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(_a0, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReassignMembershipsTx provides a mock function with given fields: gormDB, fromUserID, toUserID
+func (_m *GroupRepository) ReassignMembershipsTx(gormDB *gorm.DB, fromUserID uuid.UUID, toUserID uuid.UUID) error {
+	ret := _m.Called(gormDB, fromUserID, toUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReassignMembershipsTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(gormDB, fromUserID, toUserID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RecordMembershipTx provides a mock function with given fields: gormDB, membership
+func (_m *GroupRepository) RecordMembershipTx(gormDB *gorm.DB, membership *models.GroupMembership) error {
+	ret := _m.Called(gormDB, membership)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordMembershipTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, *models.GroupMembership) error); ok {
+		r0 = rf(gormDB, membership)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewGroupRepository creates a new instance of GroupRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewGroupRepository(t interface {
@@ -6,6 +6,8 @@ import (
 	models "backend-task/internal/user/models"
 	context "context"
 
+	gorm "gorm.io/gorm"
+
 	mock "github.com/stretchr/testify/mock"
 
 	uuid "github.com/google/uuid"
@@ -34,6 +36,112 @@ func (_m *UserRepository) CreateNewUser(_a0 context.Context, user *models.User)
 	return r0
 }
 
+// CreateNewUserTx provides a mock function with given fields: gormDB, user
+func (_m *UserRepository) CreateNewUserTx(gormDB *gorm.DB, user *models.User) error {
+	ret := _m.Called(gormDB, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateNewUserTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, *models.User) error); ok {
+		r0 = rf(gormDB, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteUserTx provides a mock function with given fields: gormDB, user
+func (_m *UserRepository) DeleteUserTx(gormDB *gorm.DB, user *models.User) (bool, error) {
+	ret := _m.Called(gormDB, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUserTx")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, *models.User) (bool, error)); ok {
+		return rf(gormDB, user)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, *models.User) bool); ok {
+		r0 = rf(gormDB, user)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, *models.User) error); ok {
+		r1 = rf(gormDB, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindExistingEmails provides a mock function with given fields: _a0, emails
+func (_m *UserRepository) FindExistingEmails(_a0 context.Context, emails []string) ([]string, error) {
+	ret := _m.Called(_a0, emails)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindExistingEmails")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]string, error)); ok {
+		return rf(_a0, emails)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []string); ok {
+		r0 = rf(_a0, emails)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(_a0, emails)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserByEmail provides a mock function with given fields: _a0, email
+func (_m *UserRepository) GetUserByEmail(_a0 context.Context, email string) (*models.User, error) {
+	ret := _m.Called(_a0, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByEmail")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.User, error)); ok {
+		return rf(_a0, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.User); ok {
+		r0 = rf(_a0, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserByID provides a mock function with given fields: _a0, userID
 func (_m *UserRepository) GetUserByID(_a0 context.Context, userID uuid.UUID) (*models.User, error) {
 	ret := _m.Called(_a0, userID)
@@ -122,6 +230,138 @@ func (_m *UserRepository) ListUsers(_a0 context.Context, group string) ([]*model
 	return r0, r1
 }
 
+// ListUsersAfter provides a mock function with given fields: _a0, group, statuses, afterID, limit
+func (_m *UserRepository) ListUsersAfter(_a0 context.Context, group string, statuses []string, afterID uuid.UUID, limit int) ([]*models.User, error) {
+	ret := _m.Called(_a0, group, statuses, afterID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsersAfter")
+	}
+
+	var r0 []*models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, uuid.UUID, int) ([]*models.User, error)); ok {
+		return rf(_a0, group, statuses, afterID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, uuid.UUID, int) []*models.User); ok {
+		r0 = rf(_a0, group, statuses, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, uuid.UUID, int) error); ok {
+		r1 = rf(_a0, group, statuses, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUsersByStatus provides a mock function with given fields: _a0, group, statuses
+func (_m *UserRepository) ListUsersByStatus(_a0 context.Context, group string, statuses []string) ([]*models.User, error) {
+	ret := _m.Called(_a0, group, statuses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsersByStatus")
+	}
+
+	var r0 []*models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) ([]*models.User, error)); ok {
+		return rf(_a0, group, statuses)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) []*models.User); ok {
+		r0 = rf(_a0, group, statuses)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(_a0, group, statuses)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchUsers provides a mock function with given fields: _a0, filter, statuses
+func (_m *UserRepository) SearchUsers(_a0 context.Context, filter models.UserSearchFilter, statuses []string) ([]*models.User, int64, error) {
+	ret := _m.Called(_a0, filter, statuses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchUsers")
+	}
+
+	var r0 []*models.User
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UserSearchFilter, []string) ([]*models.User, int64, error)); ok {
+		return rf(_a0, filter, statuses)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.UserSearchFilter, []string) []*models.User); ok {
+		r0 = rf(_a0, filter, statuses)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.UserSearchFilter, []string) int64); ok {
+		r1 = rf(_a0, filter, statuses)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, models.UserSearchFilter, []string) error); ok {
+		r2 = rf(_a0, filter, statuses)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// TransitionUserStatusTx provides a mock function with given fields: gormDB, user, from, fields
+func (_m *UserRepository) TransitionUserStatusTx(gormDB *gorm.DB, user *models.User, from string, fields ...string) (bool, error) {
+	_va := make([]interface{}, len(fields))
+	for _i := range fields {
+		_va[_i] = fields[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, gormDB, user, from)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransitionUserStatusTx")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, *models.User, string, ...string) (bool, error)); ok {
+		return rf(gormDB, user, from, fields...)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, *models.User, string, ...string) bool); ok {
+		r0 = rf(gormDB, user, from, fields...)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, *models.User, string, ...string) error); ok {
+		r1 = rf(gormDB, user, from, fields...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateUser provides a mock function with given fields: _a0, user, fields
 func (_m *UserRepository) UpdateUser(_a0 context.Context, user *models.User, fields ...string) error {
 	_va := make([]interface{}, len(fields))
@@ -147,6 +387,31 @@ func (_m *UserRepository) UpdateUser(_a0 context.Context, user *models.User, fie
 	return r0
 }
 
+// UpdateUserTx provides a mock function with given fields: gormDB, user, fields
+func (_m *UserRepository) UpdateUserTx(gormDB *gorm.DB, user *models.User, fields ...string) error {
+	_va := make([]interface{}, len(fields))
+	for _i := range fields {
+		_va[_i] = fields[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, gormDB, user)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, *models.User, ...string) error); ok {
+		r0 = rf(gormDB, user, fields...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewUserRepository creates a new instance of UserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserRepository(t interface {
@@ -3,6 +3,12 @@
 package mocks
 
 import (
+	context "context"
+
+	gorm "gorm.io/gorm"
+
+	io "io"
+
 	models "backend-task/internal/user/models"
 
 	mock "github.com/stretchr/testify/mock"
@@ -13,9 +19,39 @@ type UserService struct {
 	mock.Mock
 }
 
-// CreateUser provides a mock function with given fields: name, email, dob
-func (_m *UserService) CreateUser(name string, email string, dob string) (*models.User, error) {
-	ret := _m.Called(name, email, dob)
+// ChangeUserStatus provides a mock function with given fields: _a0, id, status, req
+func (_m *UserService) ChangeUserStatus(_a0 context.Context, id string, status string, req models.ChangeStatusReq) (*models.User, error) {
+	ret := _m.Called(_a0, id, status, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeUserStatus")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.ChangeStatusReq) (*models.User, error)); ok {
+		return rf(_a0, id, status, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.ChangeStatusReq) *models.User); ok {
+		r0 = rf(_a0, id, status, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, models.ChangeStatusReq) error); ok {
+		r1 = rf(_a0, id, status, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateUser provides a mock function with given fields: _a0, name, email, dob
+func (_m *UserService) CreateUser(_a0 context.Context, name string, email string, dob string) (*models.User, error) {
+	ret := _m.Called(_a0, name, email, dob)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateUser")
@@ -23,19 +59,19 @@ func (_m *UserService) CreateUser(name string, email string, dob string) (*model
 
 	var r0 *models.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, string) (*models.User, error)); ok {
-		return rf(name, email, dob)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*models.User, error)); ok {
+		return rf(_a0, name, email, dob)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, string) *models.User); ok {
-		r0 = rf(name, email, dob)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *models.User); ok {
+		r0 = rf(_a0, name, email, dob)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
-		r1 = rf(name, email, dob)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(_a0, name, email, dob)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -43,9 +79,165 @@ func (_m *UserService) CreateUser(name string, email string, dob string) (*model
 	return r0, r1
 }
 
-// GetUserByID provides a mock function with given fields: id
-func (_m *UserService) GetUserByID(id string) (*models.User, error) {
-	ret := _m.Called(id)
+// CreateUserWithGuardian provides a mock function with given fields: _a0, name, email, dob, guardian
+func (_m *UserService) CreateUserWithGuardian(_a0 context.Context, name string, email string, dob string, guardian *models.GuardianReq) (*models.User, error) {
+	ret := _m.Called(_a0, name, email, dob, guardian)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUserWithGuardian")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *models.GuardianReq) (*models.User, error)); ok {
+		return rf(_a0, name, email, dob, guardian)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *models.GuardianReq) *models.User); ok {
+		r0 = rf(_a0, name, email, dob, guardian)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, *models.GuardianReq) error); ok {
+		r1 = rf(_a0, name, email, dob, guardian)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateUserWithGuardianTx provides a mock function with given fields: _a0, name, email, dob, guardian, withinTx
+func (_m *UserService) CreateUserWithGuardianTx(_a0 context.Context, name string, email string, dob string, guardian *models.GuardianReq, withinTx func(*gorm.DB, *models.User) error) (*models.User, error) {
+	ret := _m.Called(_a0, name, email, dob, guardian, withinTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUserWithGuardianTx")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *models.GuardianReq, func(*gorm.DB, *models.User) error) (*models.User, error)); ok {
+		return rf(_a0, name, email, dob, guardian, withinTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *models.GuardianReq, func(*gorm.DB, *models.User) error) *models.User); ok {
+		r0 = rf(_a0, name, email, dob, guardian, withinTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, *models.GuardianReq, func(*gorm.DB, *models.User) error) error); ok {
+		r1 = rf(_a0, name, email, dob, guardian, withinTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateUsers provides a mock function with given fields: _a0, reqs
+func (_m *UserService) CreateUsers(_a0 context.Context, reqs []models.CreateUserReq) ([]*models.User, error) {
+	ret := _m.Called(_a0, reqs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUsers")
+	}
+
+	var r0 []*models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.CreateUserReq) ([]*models.User, error)); ok {
+		return rf(_a0, reqs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []models.CreateUserReq) []*models.User); ok {
+		r0 = rf(_a0, reqs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []models.CreateUserReq) error); ok {
+		r1 = rf(_a0, reqs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExportGroups provides a mock function with given fields: _a0, writer, options
+func (_m *UserService) ExportGroups(_a0 context.Context, writer io.Writer, options models.ExportOptions) error {
+	ret := _m.Called(_a0, writer, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportGroups")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, io.Writer, models.ExportOptions) error); ok {
+		r0 = rf(_a0, writer, options)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExportUsers provides a mock function with given fields: _a0, writer, options
+func (_m *UserService) ExportUsers(_a0 context.Context, writer io.Writer, options models.ExportOptions) error {
+	ret := _m.Called(_a0, writer, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportUsers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, io.Writer, models.ExportOptions) error); ok {
+		r0 = rf(_a0, writer, options)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindDuplicates provides a mock function with given fields: _a0, filter
+func (_m *UserService) FindDuplicates(_a0 context.Context, filter models.DuplicateFilter) (*models.DuplicatePage, error) {
+	ret := _m.Called(_a0, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDuplicates")
+	}
+
+	var r0 *models.DuplicatePage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.DuplicateFilter) (*models.DuplicatePage, error)); ok {
+		return rf(_a0, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.DuplicateFilter) *models.DuplicatePage); ok {
+		r0 = rf(_a0, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DuplicatePage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.DuplicateFilter) error); ok {
+		r1 = rf(_a0, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserByID provides a mock function with given fields: _a0, id
+func (_m *UserService) GetUserByID(_a0 context.Context, id string) (*models.User, error) {
+	ret := _m.Called(_a0, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetUserByID")
@@ -53,19 +245,19 @@ func (_m *UserService) GetUserByID(id string) (*models.User, error) {
 
 	var r0 *models.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*models.User, error)); ok {
-		return rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.User, error)); ok {
+		return rf(_a0, id)
 	}
-	if rf, ok := ret.Get(0).(func(string) *models.User); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.User); ok {
+		r0 = rf(_a0, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -73,9 +265,99 @@ func (_m *UserService) GetUserByID(id string) (*models.User, error) {
 	return r0, r1
 }
 
-// ListUsersByFilter provides a mock function with given fields: group
-func (_m *UserService) ListUsersByFilter(group string) ([]*models.User, error) {
-	ret := _m.Called(group)
+// GrantConsent provides a mock function with given fields: _a0, token
+func (_m *UserService) GrantConsent(_a0 context.Context, token string) (*models.User, error) {
+	ret := _m.Called(_a0, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GrantConsent")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.User, error)); ok {
+		return rf(_a0, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.User); ok {
+		r0 = rf(_a0, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportUsers provides a mock function with given fields: _a0, rows, options
+func (_m *UserService) ImportUsers(_a0 context.Context, rows []models.ImportRow, options models.ImportOptions) (*models.ImportReport, error) {
+	ret := _m.Called(_a0, rows, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportUsers")
+	}
+
+	var r0 *models.ImportReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.ImportRow, models.ImportOptions) (*models.ImportReport, error)); ok {
+		return rf(_a0, rows, options)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []models.ImportRow, models.ImportOptions) *models.ImportReport); ok {
+		r0 = rf(_a0, rows, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ImportReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []models.ImportRow, models.ImportOptions) error); ok {
+		r1 = rf(_a0, rows, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListConsents provides a mock function with given fields: _a0, userID, status
+func (_m *UserService) ListConsents(_a0 context.Context, userID string, status string) ([]*models.GuardianConsent, error) {
+	ret := _m.Called(_a0, userID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListConsents")
+	}
+
+	var r0 []*models.GuardianConsent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]*models.GuardianConsent, error)); ok {
+		return rf(_a0, userID, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*models.GuardianConsent); ok {
+		r0 = rf(_a0, userID, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.GuardianConsent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(_a0, userID, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUsersByFilter provides a mock function with given fields: _a0, group
+func (_m *UserService) ListUsersByFilter(_a0 context.Context, group string) ([]*models.User, error) {
+	ret := _m.Called(_a0, group)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListUsersByFilter")
@@ -83,19 +365,144 @@ func (_m *UserService) ListUsersByFilter(group string) ([]*models.User, error) {
 
 	var r0 []*models.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]*models.User, error)); ok {
-		return rf(group)
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.User, error)); ok {
+		return rf(_a0, group)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.User); ok {
+		r0 = rf(_a0, group)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, group)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUsersByStatus provides a mock function with given fields: _a0, group, status
+func (_m *UserService) ListUsersByStatus(_a0 context.Context, group string, status string) ([]*models.User, error) {
+	ret := _m.Called(_a0, group, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsersByStatus")
+	}
+
+	var r0 []*models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]*models.User, error)); ok {
+		return rf(_a0, group, status)
 	}
-	if rf, ok := ret.Get(0).(func(string) []*models.User); ok {
-		r0 = rf(group)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*models.User); ok {
+		r0 = rf(_a0, group, status)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(group)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(_a0, group, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MergeUsers provides a mock function with given fields: _a0, req
+func (_m *UserService) MergeUsers(_a0 context.Context, req models.MergeUsersReq) (*models.User, error) {
+	ret := _m.Called(_a0, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeUsers")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.MergeUsersReq) (*models.User, error)); ok {
+		return rf(_a0, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.MergeUsersReq) *models.User); ok {
+		r0 = rf(_a0, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.MergeUsersReq) error); ok {
+		r1 = rf(_a0, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyEmailTaken provides a mock function with given fields: _a0, email
+func (_m *UserService) NotifyEmailTaken(_a0 context.Context, email string) {
+	_m.Called(_a0, email)
+}
+
+// RevokeConsent provides a mock function with given fields: _a0, id, reason
+func (_m *UserService) RevokeConsent(_a0 context.Context, id string, reason string) (*models.GuardianConsent, error) {
+	ret := _m.Called(_a0, id, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeConsent")
+	}
+
+	var r0 *models.GuardianConsent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.GuardianConsent, error)); ok {
+		return rf(_a0, id, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.GuardianConsent); ok {
+		r0 = rf(_a0, id, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.GuardianConsent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(_a0, id, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchUsers provides a mock function with given fields: _a0, filter
+func (_m *UserService) SearchUsers(_a0 context.Context, filter models.UserSearchFilter) (*models.UserPage, error) {
+	ret := _m.Called(_a0, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchUsers")
+	}
+
+	var r0 *models.UserPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UserSearchFilter) (*models.UserPage, error)); ok {
+		return rf(_a0, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.UserSearchFilter) *models.UserPage); ok {
+		r0 = rf(_a0, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.UserSearchFilter) error); ok {
+		r1 = rf(_a0, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -103,9 +510,9 @@ func (_m *UserService) ListUsersByFilter(group string) ([]*models.User, error) {
 	return r0, r1
 }
 
-// UpdateUser provides a mock function with given fields: id, name, email
-func (_m *UserService) UpdateUser(id string, name *string, email *string) (*models.User, error) {
-	ret := _m.Called(id, name, email)
+// UpdateUser provides a mock function with given fields: _a0, id, name, email
+func (_m *UserService) UpdateUser(_a0 context.Context, id string, name *string, email *string) (*models.User, error) {
+	ret := _m.Called(_a0, id, name, email)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateUser")
@@ -113,19 +520,49 @@ func (_m *UserService) UpdateUser(id string, name *string, email *string) (*mode
 
 	var r0 *models.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, *string, *string) (*models.User, error)); ok {
-		return rf(id, name, email)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *string, *string) (*models.User, error)); ok {
+		return rf(_a0, id, name, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *string, *string) *models.User); ok {
+		r0 = rf(_a0, id, name, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *string, *string) error); ok {
+		r1 = rf(_a0, id, name, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyEmail provides a mock function with given fields: _a0, token
+func (_m *UserService) VerifyEmail(_a0 context.Context, token string) (*models.User, error) {
+	ret := _m.Called(_a0, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyEmail")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.User, error)); ok {
+		return rf(_a0, token)
 	}
-	if rf, ok := ret.Get(0).(func(string, *string, *string) *models.User); ok {
-		r0 = rf(id, name, email)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.User); ok {
+		r0 = rf(_a0, token)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, *string, *string) error); ok {
-		r1 = rf(id, name, email)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, token)
 	} else {
 		r1 = ret.Error(1)
 	}
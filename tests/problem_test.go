@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"backend-task/internal/constants"
+	userModels "backend-task/internal/user/models"
+	"backend-task/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decodeProblem Parses An application/problem+json Response :
+func decodeProblem(testingT *testing.T, resp *httptest.ResponseRecorder) userModels.ErrorResponse {
+
+	testingT.Helper()
+
+	require.Equal(testingT, constants.ContentTypeProblemJSON, resp.Header().Get(constants.HeaderContentType), resp.Body.String())
+
+	var problem userModels.ErrorResponse
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &problem))
+
+	return problem
+}
+
+func TestErrorsAreProblemDocuments(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	server := newTestServer(testingT, env)
+
+	body := `[{"name":"Jane","email":"jane@test.com","date_of_birth":"1990-01-01"}]`
+	require.Equal(testingT, http.StatusCreated, serveWithToken(server, http.MethodPost, "/api/v1/users", "", body).Code)
+
+	resp := serveWithToken(server, http.MethodPost, "/api/v1/users", "", body)
+	problem := decodeProblem(testingT, resp)
+
+	assert.Equal(testingT, "USER_EMAIL_TAKEN", problem.ErrorCode)
+	assert.Equal(testingT, "urn:backend-task:problem:user-email-taken", problem.Type)
+	assert.Equal(testingT, resp.Code, problem.Status)
+	assert.Equal(testingT, http.StatusText(resp.Code), problem.Title)
+	assert.Equal(testingT, utils.ErrEmailAlreadyExists.Error(), problem.Detail)
+	assert.Equal(testingT, "/api/v1/users", problem.Instance)
+	assert.Equal(testingT, resp.Header().Get(constants.HeaderRequestID), problem.RequestID)
+	assert.NotEmpty(testingT, problem.RequestID)
+
+	// Errors Handed To The Responder Unwrapped Take Their Status From The Registry :
+	resp = serveWithToken(server, http.MethodPost, "/api/v1/users", "", `{"name":"Jane"}`)
+	assert.Equal(testingT, http.StatusBadRequest, resp.Code)
+	assert.Equal(testingT, "INVALID_REQUEST_BODY", decodeProblem(testingT, resp).ErrorCode)
+}
+
+func TestProblemsForUnregisteredErrors(testingT *testing.T) {
+
+	// Unknown Causes Are Hidden :
+	problem := utils.NewProblem(errors.New("pq: connection refused"), "/api/v1/users", "request-1")
+	assert.Equal(testingT, http.StatusInternalServerError, problem.Status)
+	assert.Equal(testingT, "INTERNAL_ERROR", problem.ErrorCode)
+	assert.Equal(testingT, utils.ErrInternalError.Error(), problem.Detail)
+	assert.Equal(testingT, "request-1", problem.RequestID)
+
+	// Client Errors Without An Entry Are Named By Their Status :
+	problem = utils.NewProblem(utils.NewConflict(errors.New("already done")), "/api/v1/jobs", "")
+	assert.Equal(testingT, http.StatusConflict, problem.Status)
+	assert.Equal(testingT, constants.ErrorCodeConflict, problem.ErrorCode)
+	assert.Equal(testingT, "already done", problem.Detail)
+
+	// Wrapped Registered Errors Keep Their Code :
+	problem = utils.NewProblem(utils.NewBadRequest(fmt.Errorf("%w: users:fly", utils.ErrUnknownPermission)), "/api/v1/api-keys", "")
+	assert.Equal(testingT, "PERMISSION_UNKNOWN", problem.ErrorCode)
+	assert.Equal(testingT, "unknown permission: users:fly", problem.Detail)
+}
+
+func TestErrorCodesAreUnique(testingT *testing.T) {
+
+	format := regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+	seen := map[string]bool{}
+
+	for _, code := range utils.ErrorCodes() {
+
+		assert.Regexp(testingT, format, code)
+		assert.False(testingT, seen[code], "duplicate error code "+code)
+		seen[code] = true
+	}
+}
@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	authModels "backend-task/internal/auth/models"
+	authServices "backend-task/internal/auth/services"
+	"backend-task/internal/constants"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRolesGateRoutes(testingT *testing.T) {
+
+	_, server := newAuthTestServer(testingT)
+
+	viewer := signHS256(testingT, newTestClaims("viewer-1", constants.RoleViewer))
+	editor := signHS256(testingT, newTestClaims("editor-1", constants.RoleEditor))
+	admin := signHS256(testingT, withMFA(newTestClaims("admin-1", constants.RoleAdmin)))
+	nobody := signHS256(testingT, newTestClaims("nobody-1", "unknown-role"))
+
+	body := `[{"name":"Abudalou","email":"abudalou@test.com","date_of_birth":"2000-01-04"}]`
+
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		body   string
+		status int
+	}{
+		{"viewer reads users", http.MethodGet, "/api/v1/users", viewer, "", http.StatusOK},
+		{"viewer cannot create", http.MethodPost, "/api/v1/users", viewer, body, http.StatusForbidden},
+		{"unknown role grants nothing", http.MethodGet, "/api/v1/users", nobody, "", http.StatusForbidden},
+		{"editor creates", http.MethodPost, "/api/v1/users", editor, body, http.StatusCreated},
+		{"editor cannot read audit", http.MethodGet, "/api/v1/audit", editor, "", http.StatusForbidden},
+		{"editor cannot manage webhooks", http.MethodGet, "/api/v1/webhooks", editor, "", http.StatusForbidden},
+		{"admin reads audit", http.MethodGet, "/api/v1/audit", admin, "", http.StatusOK},
+		{"admin manages webhooks", http.MethodGet, "/api/v1/webhooks", admin, "", http.StatusOK},
+	}
+
+	for _, tc := range cases {
+
+		resp := serveWithToken(server, tc.method, tc.path, tc.token, tc.body)
+		assert.Equal(testingT, tc.status, resp.Code, tc.name)
+
+		if tc.status == http.StatusForbidden {
+
+			problem := decodeProblem(testingT, resp)
+			assert.Equal(testingT, "PERMISSION_DENIED", problem.ErrorCode, tc.name)
+			assert.Contains(testingT, problem.Detail, "permission denied: requires ", tc.name)
+		}
+	}
+}
+
+func TestViewerMayOnlyPatchOwnRecord(testingT *testing.T) {
+
+	env, server := newAuthTestServer(testingT)
+
+	self, err := env.users.CreateUser(context.Background(), "Self", "self@test.com", "1990-01-01")
+	require.NoError(testingT, err)
+
+	other, err := env.users.CreateUser(context.Background(), "Other", "other@test.com", "1990-01-01")
+	require.NoError(testingT, err)
+
+	viewer := signHS256(testingT, newTestClaims(self.ID.String(), constants.RoleViewer))
+	editor := signHS256(testingT, newTestClaims("editor-1", constants.RoleEditor))
+
+	resp := serveWithToken(server, http.MethodPatch, "/api/v1/users/"+self.ID.String(), viewer, `{"name":"Renamed"}`)
+	assert.Equal(testingT, http.StatusOK, resp.Code, resp.Body.String())
+
+	resp = serveWithToken(server, http.MethodPatch, "/api/v1/users/"+other.ID.String(), viewer, `{"name":"Hijacked"}`)
+	assert.Equal(testingT, http.StatusForbidden, resp.Code)
+	assert.Contains(testingT, resp.Body.String(), constants.PermissionUsersUpdate)
+
+	resp = serveWithToken(server, http.MethodPatch, "/api/v1/users/"+other.ID.String(), editor, `{"name":"Edited"}`)
+	assert.Equal(testingT, http.StatusOK, resp.Code, resp.Body.String())
+}
+
+func TestCustomRolesFromConfig(testingT *testing.T) {
+
+	testingT.Setenv(constants.RBAC_CUSTOM_ROLES, `{"auditor":["audit:read"]}`)
+	_, server := newAuthTestServer(testingT)
+
+	auditor := signHS256(testingT, withMFA(newTestClaims("auditor-1", "auditor")))
+
+	assert.Equal(testingT, http.StatusOK, serveWithToken(server, http.MethodGet, "/api/v1/audit", auditor, "").Code)
+	assert.Equal(testingT, http.StatusForbidden, serveWithToken(server, http.MethodGet, "/api/v1/users", auditor, "").Code)
+}
+
+func TestAuthorizerRejectsInvalidCustomRoles(testingT *testing.T) {
+
+	_, err := authServices.NewAuthorizer(map[string][]string{"support": {"users:delete-everything"}})
+	assert.Error(testingT, err)
+
+	_, err = authServices.NewAuthorizer(map[string][]string{constants.RoleViewer: {constants.PermissionAuditRead}})
+	assert.Error(testingT, err)
+
+	authorizer, err := authServices.NewAuthorizer(map[string][]string{"support": {constants.PermissionUsersRead}})
+	require.NoError(testingT, err)
+
+	principal := authorizer.Principal(&authModels.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "s"}, Roles: []string{"support", constants.RoleViewer}})
+	assert.True(testingT, principal.Can(constants.PermissionUsersRead))
+	assert.True(testingT, principal.Can(constants.PermissionEventsRead))
+	assert.False(testingT, principal.Can(constants.PermissionUsersCreate))
+}
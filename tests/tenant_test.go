@@ -0,0 +1,328 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	auditModels "backend-task/internal/audit/models"
+	authModels "backend-task/internal/auth/models"
+	"backend-task/internal/constants"
+	eventModels "backend-task/internal/events/models"
+	eventServices "backend-task/internal/events/services"
+	"backend-task/internal/tenant"
+	userModels "backend-task/internal/user/models"
+	"backend-task/internal/utils"
+	webhookModels "backend-task/internal/webhook/models"
+	webhookRepository "backend-task/internal/webhook/repository"
+	webhookServices "backend-task/internal/webhook/services"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveInTenant Sends The Request With The X-Tenant-ID Header ( And A Bearer Token When Given ) :
+func serveInTenant(server http.Handler, method, path, token, tenantID, body string) *httptest.ResponseRecorder {
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(constants.HeaderTenantID, tenantID)
+	if body != "" {
+
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if token != "" {
+
+		req.Header.Set(constants.HeaderAuthorization, constants.BearerPrefix+token)
+	}
+
+	resp := httptest.NewRecorder()
+	server.ServeHTTP(resp, req)
+
+	return resp
+}
+
+// tenantAdmin Signs An MFA-Verified Admin Token Bound To The Tenant :
+func tenantAdmin(testingT *testing.T, tenantID string) string {
+
+	claims := withMFA(newTestClaims("admin-"+tenantID, constants.RoleAdmin))
+	claims.TenantID = tenantID
+
+	return signHS256(testingT, claims)
+}
+
+// createInTenant Creates A User As An Admin Of The Tenant ( Servers Without Auth Ignore The Token ) :
+func createInTenant(testingT *testing.T, server http.Handler, tenantID, body string) userModels.User {
+
+	testingT.Helper()
+
+	resp := serveInTenant(server, http.MethodPost, "/api/v1/users", tenantAdmin(testingT, tenantID), tenantID, "["+body+"]")
+	require.Equal(testingT, http.StatusCreated, resp.Code, resp.Body.String())
+
+	var created []userModels.User
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &created))
+
+	return created[0]
+}
+
+func birthDateAged(years int) string {
+
+	return time.Now().AddDate(-years, 0, -1).Format("2006-01-02")
+}
+
+// tenantUserIDs Lists The IDs Of The Users The Token's Tenant Holds :
+func tenantUserIDs(testingT *testing.T, server http.Handler, token string) []string {
+
+	testingT.Helper()
+
+	resp := serveWithToken(server, http.MethodGet, "/api/v1/users", token, "")
+	require.Equal(testingT, http.StatusOK, resp.Code, resp.Body.String())
+
+	var users []userModels.User
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &users))
+
+	ids := []string{}
+	for _, user := range users {
+
+		ids = append(ids, user.ID.String())
+	}
+
+	return ids
+}
+
+func TestTenantsAreIsolated(testingT *testing.T) {
+
+	_, server := newAuthTestServer(testingT)
+	acmeAdmin := tenantAdmin(testingT, "acme")
+
+	// The Same Email Registers Once Per Tenant, And Group Numbering Starts Over :
+	acme := createInTenant(testingT, server, "acme", `{"name":"Jane","email":"jane@test.com","date_of_birth":"1990-01-01"}`)
+	assert.Equal(testingT, "acme", acme.TenantID)
+	assert.Equal(testingT, "adult-1", acme.Group)
+
+	local := createInTenant(testingT, server, constants.DefaultTenantID, `{"name":"Jane","email":"jane@test.com","date_of_birth":"1990-01-01"}`)
+	assert.Equal(testingT, constants.DefaultTenantID, local.TenantID)
+	assert.Equal(testingT, "adult-1", local.Group)
+
+	resp := serveWithToken(server, http.MethodPost, "/api/v1/users", acmeAdmin, `[{"name":"Jane","email":"jane@test.com","date_of_birth":"1990-01-01"}]`)
+	assert.Equal(testingT, http.StatusConflict, resp.Code)
+
+	// Tokens Without A Tenant Use The Default One :
+	admin := signHS256(testingT, newTestClaims("admin-1", constants.RoleAdmin))
+	assert.Equal(testingT, []string{local.ID.String()}, tenantUserIDs(testingT, server, admin))
+
+	// Users Of Other Tenants Do Not Exist For The Caller :
+	resp = serveWithToken(server, http.MethodGet, "/api/v1/users/"+local.ID.String(), acmeAdmin, "")
+	assert.Equal(testingT, http.StatusNotFound, resp.Code)
+
+	resp = serveWithToken(server, http.MethodPatch, "/api/v1/users/"+local.ID.String(), acmeAdmin, `{"name":"Mallory"}`)
+	assert.Equal(testingT, http.StatusNotFound, resp.Code)
+	assert.Equal(testingT, http.StatusOK, serveWithToken(server, http.MethodGet, "/api/v1/users/"+acme.ID.String(), acmeAdmin, "").Code)
+
+	assert.Equal(testingT, http.StatusBadRequest, serveInTenant(server, http.MethodGet, "/api/v1/users", acmeAdmin, "Not A Tenant", "").Code)
+}
+
+func TestTenantSettingsOverrideGrouping(testingT *testing.T) {
+
+	testingT.Setenv(constants.TENANT_SETTINGS, `{"acme":{"group_capacity":1,"age_bands":{"child_max":10,"teen_max":15,"adult_max":59}}}`)
+
+	env, server := newAuthTestServer(testingT)
+
+	// Each acme Group Holds A Single Member :
+	first := createInTenant(testingT, server, "acme", `{"name":"First","email":"first@test.com","date_of_birth":"1990-01-01"}`)
+	second := createInTenant(testingT, server, "acme", `{"name":"Second","email":"second@test.com","date_of_birth":"1990-01-01"}`)
+	assert.Equal(testingT, "adult-1", first.Group)
+	assert.Equal(testingT, "adult-2", second.Group)
+
+	var group userModels.Group
+	require.NoError(testingT, env.db.WithContext(tenant.WithID(context.Background(), "acme")).First(&group, "name = ?", "adult-1").Error)
+	assert.Equal(testingT, 1, group.Capacity)
+
+	// A 16 Year Old Is An Adult In acme ( No Guardian Needed ), A 60 Year Old A Senior :
+	sixteen := createInTenant(testingT, server, "acme", `{"name":"Sixteen","email":"sixteen@test.com","date_of_birth":"`+birthDateAged(16)+`"}`)
+	assert.Equal(testingT, constants.UserStatusActive, sixteen.Status)
+	assert.Equal(testingT, "adult-3", sixteen.Group)
+
+	sixty := createInTenant(testingT, server, "acme", `{"name":"Sixty","email":"sixty@test.com","date_of_birth":"`+birthDateAged(60)+`"}`)
+	assert.Equal(testingT, "senior-1", sixty.Group)
+
+	// Other Tenants Keep The Defaults :
+	resp := serveWithToken(server, http.MethodPost, "/api/v1/users", tenantAdmin(testingT, constants.DefaultTenantID), `[{"name":"Sixteen","email":"sixteen@test.com","date_of_birth":"`+birthDateAged(16)+`"}]`)
+	assert.Equal(testingT, http.StatusBadRequest, resp.Code)
+	assert.Contains(testingT, resp.Body.String(), "guardian")
+
+	local := createInTenant(testingT, server, constants.DefaultTenantID, `{"name":"First","email":"first@test.com","date_of_birth":"1990-01-01"}`)
+	assert.Equal(testingT, "adult-1", local.Group)
+	assert.Equal(testingT, 1, groupMemberCount(testingT, env, "adult-1"))
+}
+
+func TestConsentLinksCarryTheTenant(testingT *testing.T) {
+
+	env, server := newAuthTestServer(testingT)
+
+	kid := createInTenant(testingT, server, "acme", `{"name":"Kid","email":"kid@test.com","date_of_birth":"2015-01-01","guardian":{"name":"Parent","email":"parent@test.com"}}`)
+	assert.Empty(testingT, kid.Group)
+
+	messages := env.mailer.Messages()
+	require.NotEmpty(testingT, messages)
+	assert.Contains(testingT, messages[len(messages)-1].Body, "&tenant=acme")
+
+	// The Guardian's Browser Sends No Header, The Link Names The Tenant :
+	token := lastConsentToken(testingT, env.mailer, "parent@test.com")
+	resp := serveWithToken(server, http.MethodGet, "/api/v1/consents/grant?token="+url.QueryEscape(token)+"&tenant=acme", "", "")
+	require.Equal(testingT, http.StatusOK, resp.Code, resp.Body.String())
+
+	var granted userModels.User
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &granted))
+	assert.Equal(testingT, kid.ID, granted.ID)
+	assert.Equal(testingT, "child-1", granted.Group)
+}
+
+func TestTenantClaimCannotBeOverridden(testingT *testing.T) {
+
+	_, server := newAuthTestServer(testingT)
+
+	claims := newTestClaims("editor-1", constants.RoleEditor)
+	claims.TenantID = "acme"
+	editor := signHS256(testingT, claims)
+
+	// Tokens Bound To A Tenant May Not Name Another :
+	resp := serveInTenant(server, http.MethodGet, "/api/v1/users", editor, "globex", "")
+	assert.Equal(testingT, http.StatusForbidden, resp.Code)
+	assert.Contains(testingT, resp.Body.String(), "tenant")
+
+	assert.Equal(testingT, http.StatusOK, serveInTenant(server, http.MethodGet, "/api/v1/users", editor, "acme", "").Code)
+
+	// Without The Header The Claim Decides :
+	resp = serveWithToken(server, http.MethodPost, "/api/v1/users", editor, `[{"name":"Jane","email":"jane@test.com","date_of_birth":"1990-01-01"}]`)
+	require.Equal(testingT, http.StatusCreated, resp.Code, resp.Body.String())
+
+	var created []userModels.User
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &created))
+	assert.Equal(testingT, "acme", created[0].TenantID)
+
+	// Tokens Without The Claim Are Bound To The Default Tenant And Cannot Pick Another :
+	viewer := signHS256(testingT, newTestClaims("viewer-1", constants.RoleViewer))
+	assert.Equal(testingT, http.StatusNotFound, serveWithToken(server, http.MethodGet, "/api/v1/users/"+created[0].ID.String(), viewer, "").Code)
+	assert.Equal(testingT, http.StatusForbidden, serveInTenant(server, http.MethodGet, "/api/v1/users/"+created[0].ID.String(), viewer, "acme", "").Code)
+	assert.Equal(testingT, http.StatusOK, serveInTenant(server, http.MethodGet, "/api/v1/users", viewer, constants.DefaultTenantID, "").Code)
+}
+
+func TestTenantHeaderIsIgnoredWhenAuthIsDisabled(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	server := newTestServer(testingT, env)
+
+	// Without Authentication Nobody Is Trusted To Pick A Tenant; Only The Default One Is Served :
+	resp := serveInTenant(server, http.MethodGet, "/api/v1/users", "", "acme", "")
+	assert.Equal(testingT, http.StatusForbidden, resp.Code)
+	assert.Equal(testingT, http.StatusOK, serveInTenant(server, http.MethodGet, "/api/v1/users", "", constants.DefaultTenantID, "").Code)
+}
+
+func TestSessionsStayInTheirTenant(testingT *testing.T) {
+
+	_, server := newAuthTestServer(testingT)
+
+	// Public Calls Name The Tenant; Registration And Login Happen In acme :
+	resp := serveInTenant(server, http.MethodPost, "/api/v1/auth/register", "", "acme", `{"name":"Member","email":"member@acme.test","date_of_birth":"1990-01-01","password":"`+testPassword+`"}`)
+	require.Equal(testingT, http.StatusAccepted, resp.Code, resp.Body.String())
+
+	resp = serveInTenant(server, http.MethodPost, "/api/v1/auth/login", "", "acme", `{"email":"member@acme.test","password":"`+testPassword+`"}`)
+	require.Equal(testingT, http.StatusOK, resp.Code, resp.Body.String())
+
+	var tokens authModels.TokenPair
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &tokens))
+
+	// The Same Login Is Unknown In The Default Tenant :
+	status, _ := loginTestAccount(testingT, server, "member@acme.test", testPassword)
+	assert.Equal(testingT, http.StatusUnauthorized, status)
+
+	// Refreshing Without ( Or With A Forged ) Header Still Runs In The Session's Tenant :
+	status, refreshed := refreshTestTokens(server, tokens.RefreshToken)
+	require.Equal(testingT, http.StatusOK, status)
+
+	resp = serveInTenant(server, http.MethodPost, "/api/v1/auth/refresh", "", "globex", `{"refresh_token":"`+refreshed.RefreshToken+`"}`)
+	require.Equal(testingT, http.StatusOK, resp.Code, resp.Body.String())
+
+	var again authModels.TokenPair
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &again))
+
+	claims, _, err := jwt.NewParser().ParseUnverified(again.AccessToken, &authModels.Claims{})
+	require.NoError(testingT, err)
+	assert.Equal(testingT, "acme", claims.Claims.(*authModels.Claims).TenantID)
+
+	// The Issued Token Is Bound To acme :
+	assert.Equal(testingT, http.StatusForbidden, serveInTenant(server, http.MethodGet, "/api/v1/users/"+claims.Claims.(*authModels.Claims).Subject, again.AccessToken, constants.DefaultTenantID, "").Code)
+	assert.Equal(testingT, http.StatusOK, serveWithToken(server, http.MethodGet, "/api/v1/users/"+claims.Claims.(*authModels.Claims).Subject, again.AccessToken, "").Code)
+}
+
+func TestAuditEventsAndWebhooksAreTenantScoped(testingT *testing.T) {
+
+	env, server := newAuthTestServer(testingT)
+	ctx := context.Background()
+	acmeCtx := tenant.WithID(ctx, "acme")
+
+	createInTenant(testingT, server, "acme", `{"name":"Jane","email":"jane@acme.test","date_of_birth":"1990-01-01"}`)
+	bob := createInTenant(testingT, server, constants.DefaultTenantID, `{"name":"Bob","email":"bob@default.test","date_of_birth":"1990-01-01"}`)
+
+	// The Audit Trail Only Shows The Caller's Tenant :
+	resp := serveWithToken(server, http.MethodGet, "/api/v1/audit", tenantAdmin(testingT, "acme"), "")
+	require.Equal(testingT, http.StatusOK, resp.Code, resp.Body.String())
+
+	var page auditModels.AuditPage
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &page))
+	require.NotEmpty(testingT, page.Items)
+	for _, entry := range page.Items {
+
+		assert.Equal(testingT, "acme", entry.TenantID)
+		assert.NotEqual(testingT, bob.ID.String(), entry.TargetID)
+	}
+
+	// Subscriptions Belong To A Tenant And Only Receive Its Events :
+	webhookRepo := webhookRepository.NewWebhookRepository(env.db)
+	webhookService := webhookServices.NewWebhookService(env.db, webhookRepo, env.audit)
+
+	subscription, err := webhookService.CreateSubscription(acmeCtx, webhookModels.CreateSubscriptionReq{URL: "https://partner.test/hooks", EventTypes: []string{constants.WebhookAllEvents}, Secret: testWebhookSecret})
+	require.NoError(testingT, err)
+
+	listed, err := webhookService.ListSubscriptions(ctx)
+	require.NoError(testingT, err)
+	assert.Empty(testingT, listed)
+
+	_, err = eventServices.NewRelay(env.outbox, webhookServices.NewDispatcher(webhookRepo, nil), time.Second, 100).RelayOnce(ctx)
+	require.NoError(testingT, err)
+
+	deliveries, err := webhookService.ListDeliveries(acmeCtx, subscription.ID.String())
+	require.NoError(testingT, err)
+	require.NotEmpty(testingT, deliveries)
+	for _, delivery := range deliveries {
+
+		stored, err := webhookRepo.GetDelivery(acmeCtx, delivery.ID)
+		require.NoError(testingT, err)
+		assert.Equal(testingT, "acme", stored.TenantID)
+		assert.Contains(testingT, stored.Payload, `"tenant_id":"acme"`)
+		assert.NotContains(testingT, stored.Payload, bob.Email)
+	}
+
+	_, err = webhookService.ListDeliveries(ctx, subscription.ID.String())
+	assert.ErrorIs(testingT, err, utils.ErrWebhookNotFound)
+
+	// Stream Subscribers Only See Their Tenant's Events :
+	broker := newTestBroker(testingT)
+	stream, _, _ := broker.Subscribe(eventModels.NewStreamFilter("acme", "", ""), "")
+
+	other := newTestEnvelope(testingT, constants.EventUserCreated, constants.BaseGroupAdult)
+	mine := newTestEnvelope(testingT, constants.EventUserCreated, constants.BaseGroupAdult)
+	mine.TenantID = "acme"
+	require.NoError(testingT, broker.Publish(ctx, other))
+	require.NoError(testingT, broker.Publish(ctx, mine))
+
+	assert.Equal(testingT, mine.ID, (<-stream.Events).ID)
+	assert.Empty(testingT, stream.Events)
+}
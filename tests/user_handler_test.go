@@ -15,6 +15,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestHandler(testingT *testing.T) {
@@ -32,17 +33,19 @@ func TestHandler(testingT *testing.T) {
 	name := "Abudalou1"
 	email := "abudalou1@test.com"
 
-	// Mock CreateUser.
-	mockService.On("CreateUser", "Abudalou", "abudalou@test.com", "2000-01-04").
-		Return(&models.User{
-			ID:          testUUID,
-			Name:        "Abudalou",
-			Email:       "abudalou@test.com",
-			DateOfBirth: dateOfBirth,
+	// Mock CreateUser ( Adults Need No Guardian ).
+	mockService.On("CreateUsers", mock.Anything, []models.CreateUserReq{{Name: "Abudalou", Email: "abudalou@test.com", DateOfBirth: "2000-01-04"}}).
+		Return([]*models.User{
+			{
+				ID:          testUUID,
+				Name:        "Abudalou",
+				Email:       "abudalou@test.com",
+				DateOfBirth: dateOfBirth,
+			},
 		}, nil)
 
 	// Mock UpdateUser.
-	mockService.On("UpdateUser", testUUID.String(), &name, &email).
+	mockService.On("UpdateUser", mock.Anything, testUUID.String(), &name, &email).
 		Return(&models.User{
 			ID:          testUUID,
 			Name:        name,
@@ -51,7 +54,7 @@ func TestHandler(testingT *testing.T) {
 		}, nil)
 
 	// Mock GetUserByID.
-	mockService.On("GetUserByID", testUUID.String()).
+	mockService.On("GetUserByID", mock.Anything, testUUID.String()).
 		Return(&models.User{
 			ID:          testUUID,
 			Name:        "Abudalou1",
@@ -59,8 +62,8 @@ func TestHandler(testingT *testing.T) {
 			DateOfBirth: dateOfBirth,
 		}, nil)
 
-	// Mock ListUsersByFilter.
-	mockService.On("ListUsersByFilter", "adult-1").
+	// Mock ListUsersByStatus ( No Status Filter ).
+	mockService.On("ListUsersByStatus", mock.Anything, "adult-1", "").
 		Return([]*models.User{
 			{
 				ID:          testUUID,
@@ -123,7 +126,7 @@ func TestHandler(testingT *testing.T) {
 	assert.Equal(testingT, http.StatusOK, resp.Code)
 	assert.Contains(testingT, resp.Body.String(), "Abudalou")
 
-	// 4. ListUsersByFilter : GET /users?group=adult-1
+	// 4. ListUsersByStatus : GET /users?group=adult-1
 	req = httptest.NewRequest(http.MethodGet, "/users?group=adult-1", nil)
 	req.Header.Set("Content-Type", "application/json")
 
@@ -0,0 +1,261 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"backend-task/internal/cli"
+	"backend-task/internal/constants"
+	"backend-task/internal/router"
+	models "backend-task/internal/user/models"
+	services "backend-task/internal/user/services"
+	"backend-task/internal/utils"
+	mocks "backend-task/tests/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestParseImportCSV(testingT *testing.T) {
+
+	// BOM, Reordered And Extra Columns, A Quoted Multi-Line Field And A Short Row :
+	file := "\ufeffEmail, Date_Of_Birth ,name,team\n" +
+		"a@test.com,1990-01-01,Alice,x\n" +
+		"b@test.com,1991-02-02,\"Bob\nBuilder\",y\n" +
+		"c@test.com\n"
+
+	rows, err := services.ParseImportCSV(strings.NewReader(file))
+	require.NoError(testingT, err)
+	require.Len(testingT, rows, 3)
+
+	assert.Equal(testingT, models.ImportRow{Row: 2, Name: "Alice", Email: "a@test.com", DateOfBirth: "1990-01-01"}, rows[0])
+	assert.Equal(testingT, 3, rows[1].Row)
+	assert.Equal(testingT, "Bob\nBuilder", rows[1].Name)
+	assert.Equal(testingT, models.ImportRow{Row: 5, Email: "c@test.com"}, rows[2])
+
+	rows, err = services.ParseImportCSV(strings.NewReader("name,email,date_of_birth,guardian_email,guardian_name\nKid,kid@test.com,2020-01-01,parent@test.com,Parent\n"))
+	require.NoError(testingT, err)
+	assert.Equal(testingT, &models.GuardianReq{Name: "Parent", Email: "parent@test.com"}, rows[0].Guardian())
+
+	_, err = services.ParseImportCSV(strings.NewReader("name,email\nA,a@test.com\n"))
+	assert.EqualError(testingT, err, utils.ErrImportMissingColumns.Error())
+
+	_, err = services.ParseImportCSV(strings.NewReader("name,email,date_of_birth\n"))
+	assert.EqualError(testingT, err, utils.ErrImportNoRows.Error())
+}
+
+func TestImportUsersDryRunReportsEveryRow(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	ctx := context.Background()
+
+	_, err := env.users.CreateUser(ctx, "Existing", "taken@test.com", "1990-01-01")
+	require.NoError(testingT, err)
+
+	rows := []models.ImportRow{
+		{Row: 2, Name: "Valid", Email: "valid@test.com", DateOfBirth: "1990-01-01"},
+		{Row: 3, Name: "", Email: "noname@test.com", DateOfBirth: "1990-01-01"},
+		{Row: 4, Name: "Bad Email", Email: "not-an-email", DateOfBirth: "1990-01-01"},
+		{Row: 5, Name: "Future", Email: "future@test.com", DateOfBirth: "2999-01-01"},
+		{Row: 6, Name: "Twice", Email: " VALID@test.com ", DateOfBirth: "1990-01-01"},
+		{Row: 7, Name: "Taken", Email: "taken@test.com", DateOfBirth: "1990-01-01"},
+	}
+
+	report, err := env.users.ImportUsers(ctx, rows, models.ImportOptions{DryRun: true})
+	require.NoError(testingT, err)
+
+	assert.True(testingT, report.DryRun)
+	assert.Equal(testingT, 6, report.Total)
+	assert.Equal(testingT, 1, report.Valid)
+	assert.Equal(testingT, 5, report.Invalid)
+	assert.Equal(testingT, 0, report.Created)
+
+	assert.Equal(testingT, constants.ImportRowValid, report.Rows[0].Status)
+	assert.Equal(testingT, []string{utils.ErrNameIsRequired.Error()}, report.Rows[1].Errors)
+	assert.Equal(testingT, []string{utils.ErrInvalidEmailFormat.Error()}, report.Rows[2].Errors)
+	assert.Equal(testingT, []string{utils.ErrDateOfBirthCannotBeFuture.Error()}, report.Rows[3].Errors)
+	assert.Contains(testingT, report.Rows[4].Errors[0], utils.ErrDuplicateEmailInFile.Error())
+	assert.Contains(testingT, report.Rows[4].Errors[0], "row 2")
+	assert.Equal(testingT, []string{utils.ErrEmailAlreadyExists.Error()}, report.Rows[5].Errors)
+
+	// Nothing Was Written :
+	users, err := env.users.ListUsersByFilter(ctx, "")
+	require.NoError(testingT, err)
+	assert.Len(testingT, users, 1)
+}
+
+func TestImportUsersCommitsValidRowsInBatches(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	ctx := context.Background()
+
+	file := "name,email,date_of_birth,guardian_name,guardian_email\n" +
+		"A,a@test.com,1990-01-01,,\n" +
+		"B,b@test.com,1990-01-01,,\n" +
+		"Broken,broken,1990-01-01,,\n" +
+		"C,c@test.com,1990-01-01,,\n" +
+		"D,d@test.com,1990-01-01,,\n" +
+		"Kid,kid@test.com,2020-01-01,Parent,parent@test.com\n" +
+		"Alone,alone@test.com,2020-01-01,,\n"
+
+	rows, err := services.ParseImportCSV(strings.NewReader(file))
+	require.NoError(testingT, err)
+
+	report, err := env.users.ImportUsers(ctx, rows, models.ImportOptions{BatchSize: 2})
+	require.NoError(testingT, err)
+
+	assert.Equal(testingT, 5, report.Created)
+	assert.Equal(testingT, 2, report.Invalid)
+	assert.Equal(testingT, 0, report.Failed)
+	assert.Equal(testingT, constants.ImportRowInvalid, report.Rows[2].Status)
+
+	// Groups Fill In File Order, Exactly As With Individual Creates :
+	assert.Equal(testingT, "adult-1", report.Rows[0].Group)
+	assert.Equal(testingT, "adult-1", report.Rows[3].Group)
+	assert.Equal(testingT, "adult-2", report.Rows[4].Group)
+
+	// Minors Are Created With Their Guardian And Wait For Consent :
+	assert.Equal(testingT, constants.ImportRowCreated, report.Rows[5].Status)
+	assert.Empty(testingT, report.Rows[5].Group)
+
+	// A Minor Without Guardian Columns Is Rejected :
+	assert.Equal(testingT, constants.ImportRowInvalid, report.Rows[6].Status)
+	assert.Contains(testingT, strings.Join(report.Rows[6].Errors, "; "), "guardian")
+
+	for _, row := range report.Rows {
+
+		if row.Status == constants.ImportRowCreated {
+
+			require.NotNil(testingT, row.UserID)
+			user, err := env.users.GetUserByID(ctx, row.UserID.String())
+			require.NoError(testingT, err)
+			assert.Equal(testingT, row.Group, user.Group)
+		}
+	}
+
+	// The Guardian's Consent Allocates The Minor :
+	kid, err := env.users.GrantConsent(ctx, lastConsentToken(testingT, env.mailer, "parent@test.com"))
+	require.NoError(testingT, err)
+	assert.Equal(testingT, *report.Rows[5].UserID, kid.ID)
+	assert.Equal(testingT, "child-1", kid.Group)
+
+	// Importing The Same File Again Creates Nothing :
+	report, err = env.users.ImportUsers(ctx, rows, models.ImportOptions{})
+	require.NoError(testingT, err)
+	assert.Equal(testingT, 0, report.Created)
+	assert.Equal(testingT, 7, report.Invalid)
+}
+
+func TestImportUsersOnlyFailsTheRowThatBreaksItsBatch(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	ctx := context.Background()
+
+	file := "name,email,date_of_birth\n" +
+		"A,a@test.com,1990-01-01\n" +
+		"B,b@test.com,1990-01-01\n" +
+		"C,c@test.com,1990-01-01\n" +
+		"D,d@test.com,1990-01-01\n"
+
+	rows, err := services.ParseImportCSV(strings.NewReader(file))
+	require.NoError(testingT, err)
+
+	// Row B Loses An Email Race At Commit Time, Whichever Transaction It Is In :
+	var committed []string
+	options := models.ImportOptions{BatchSize: 3, OnBatchTx: func(gormDB *gorm.DB, batch []models.ImportRowResult) error {
+
+		for _, result := range batch {
+
+			if result.Email == "b@test.com" {
+
+				return utils.ErrEmailAlreadyExists
+			}
+		}
+
+		for _, result := range batch {
+
+			committed = append(committed, result.Email)
+		}
+
+		return nil
+	}}
+
+	report, err := env.users.ImportUsers(ctx, rows, options)
+	require.NoError(testingT, err)
+
+	assert.Equal(testingT, 3, report.Created)
+	assert.Equal(testingT, 1, report.Failed)
+	assert.Equal(testingT, []string{"a@test.com", "c@test.com", "d@test.com"}, committed)
+
+	assert.Equal(testingT, constants.ImportRowFailed, report.Rows[1].Status)
+	assert.Equal(testingT, []string{utils.ErrEmailAlreadyExists.Error()}, report.Rows[1].Errors)
+	assert.Nil(testingT, report.Rows[1].UserID)
+
+	for _, index := range []int{0, 2, 3} {
+
+		assert.Equal(testingT, constants.ImportRowCreated, report.Rows[index].Status)
+		require.NotNil(testingT, report.Rows[index].UserID)
+	}
+
+	users, err := env.users.ListUsersByStatus(ctx, "", "")
+	require.NoError(testingT, err)
+	assert.Len(testingT, users, 3)
+}
+
+func TestImportUsersHandler(testingT *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(mocks.UserService)
+	mockService.On("ImportUsers", mock.Anything, mock.MatchedBy(func(rows []models.ImportRow) bool {
+
+		return len(rows) == 1 && rows[0].Email == "a@test.com"
+	}), models.ImportOptions{DryRun: true}).Return(&models.ImportReport{DryRun: true, Total: 1, Valid: 1}, nil)
+
+	route := router.SetupRoutersWithService(mockService)
+	file := "name,email,date_of_birth\nA,a@test.com,1990-01-01\n"
+
+	post := func(path, contentType, body string) *httptest.ResponseRecorder {
+
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+
+		resp := httptest.NewRecorder()
+		route.ServeHTTP(resp, req)
+		return resp
+	}
+
+	resp := post("/users/import?dry_run=true", "text/csv; charset=utf-8", file)
+	require.Equal(testingT, http.StatusOK, resp.Code)
+
+	var report models.ImportReport
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &report))
+	assert.True(testingT, report.DryRun)
+	assert.Equal(testingT, 1, report.Valid)
+
+	assert.Equal(testingT, http.StatusUnsupportedMediaType, post("/users/import", "application/json", "[]").Code)
+	assert.Equal(testingT, http.StatusBadRequest, post("/users/import?dry_run=maybe", "text/csv", file).Code)
+	assert.Equal(testingT, http.StatusBadRequest, post("/users/import", "text/csv", "name,email\n").Code)
+
+	mockService.AssertExpectations(testingT)
+}
+
+func TestCLIRejectsUnknownCommands(testingT *testing.T) {
+
+	var stdout, stderr bytes.Buffer
+
+	assert.Equal(testingT, cli.ExitUsageErr, cli.Run([]string{"nope"}, &stdout, &stderr))
+	assert.Contains(testingT, stderr.String(), "unknown command")
+
+	stderr.Reset()
+	assert.Equal(testingT, cli.ExitUsageErr, cli.Run([]string{"import"}, &stdout, &stderr))
+	assert.Contains(testingT, stderr.String(), "-file is required")
+}
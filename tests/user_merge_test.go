@@ -0,0 +1,145 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	auditModels "backend-task/internal/audit/models"
+	"backend-task/internal/constants"
+	eventModels "backend-task/internal/events/models"
+	userModels "backend-task/internal/user/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func findDuplicates(testingT *testing.T, server http.Handler, query string) userModels.DuplicatePage {
+
+	testingT.Helper()
+
+	resp := serveWithToken(server, http.MethodGet, "/api/v1/users/duplicates"+query, "", "")
+	require.Equal(testingT, http.StatusOK, resp.Code, resp.Body.String())
+
+	var page userModels.DuplicatePage
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &page))
+
+	return page
+}
+
+func TestFindDuplicatesScoresPairs(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	server := newTestServer(testingT, env)
+
+	john := createInTenant(testingT, server, constants.DefaultTenantID, `{"name":"John Doe","email":"jdoe@test.com","date_of_birth":"1990-01-01"}`)
+	johnAgain := createInTenant(testingT, server, constants.DefaultTenantID, `{"name":"Doe, Jöhn","email":"john.doe@other.com","date_of_birth":"1990-01-01"}`)
+	createInTenant(testingT, server, constants.DefaultTenantID, `{"name":"Jane Roe","email":"jane@test.com","date_of_birth":"1990-01-01"}`)
+	jon := createInTenant(testingT, server, constants.DefaultTenantID, `{"name":"Jon Doe","email":"john.doe@example.org","date_of_birth":"1985-06-15"}`)
+
+	// Same Birth Date And The Same Name Once Accents, Punctuation And Word Order Are Ignored :
+	page := findDuplicates(testingT, server, "")
+	require.Len(testingT, page.Items, 1)
+	assert.Equal(testingT, int64(1), page.Total)
+	assert.Equal(testingT, 0.8, page.Items[0].Score)
+	assert.Equal(testingT, []string{constants.DuplicateReasonSimilarName, constants.DuplicateReasonSameBirth}, page.Items[0].Reasons)
+	assert.Equal(testingT, john.ID, page.Items[0].Users[0].ID)
+	assert.Equal(testingT, johnAgain.ID, page.Items[0].Users[1].ID)
+
+	// A Close Name On A Shared Mailbox Name Scores Lower :
+	page = findDuplicates(testingT, server, "?min_score=0.5")
+	require.Len(testingT, page.Items, 2)
+	assert.Equal(testingT, 0.64, page.Items[1].Score)
+	assert.Equal(testingT, []string{constants.DuplicateReasonSimilarName, constants.DuplicateReasonSameEmailLocal}, page.Items[1].Reasons)
+	assert.Equal(testingT, johnAgain.ID, page.Items[1].Users[0].ID)
+	assert.Equal(testingT, jon.ID, page.Items[1].Users[1].ID)
+
+	page = findDuplicates(testingT, server, "?min_score=0.5&page=2&page_size=1")
+	assert.Equal(testingT, int64(2), page.Total)
+	require.Len(testingT, page.Items, 1)
+	assert.Equal(testingT, 0.64, page.Items[0].Score)
+
+	for _, query := range []string{"?min_score=1.5", "?min_score=-0.1", "?min_score=high", "?min_score=NaN"} {
+
+		resp := serveWithToken(server, http.MethodGet, "/api/v1/users/duplicates"+query, "", "")
+		require.Equal(testingT, http.StatusBadRequest, resp.Code, query)
+		assert.Equal(testingT, "INVALID_MIN_SCORE", decodeProblem(testingT, resp).ErrorCode, query)
+	}
+}
+
+func TestMergeUsersFoldsTheDuplicate(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	server := newTestServer(testingT, env)
+	ctx := context.Background()
+
+	survivor, err := env.users.CreateUser(ctx, "John Doe", "jdoe@test.com", "1990-01-01")
+	require.NoError(testingT, err)
+
+	duplicate, err := env.users.CreateUser(ctx, "Doe, Jöhn", "john.doe@other.com", "1990-01-01")
+	require.NoError(testingT, err)
+	require.Equal(testingT, 2, groupMemberCount(testingT, env, "adult-1"))
+
+	body := `{"survivor_id":"` + survivor.ID.String() + `","duplicate_id":"` + duplicate.ID.String() + `"}`
+	resp := serveWithToken(server, http.MethodPost, "/api/v1/users/merge", "", body)
+	require.Equal(testingT, http.StatusOK, resp.Code, resp.Body.String())
+
+	var merged userModels.User
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &merged))
+	assert.Equal(testingT, survivor.ID, merged.ID)
+	assert.Equal(testingT, "John Doe", merged.Name)
+	assert.Equal(testingT, "adult-1", merged.Group)
+
+	// The Duplicate Is Gone And Its Seat Freed :
+	assert.Equal(testingT, http.StatusNotFound, serveWithToken(server, http.MethodGet, "/api/v1/users/"+duplicate.ID.String(), "", "").Code)
+	assert.Equal(testingT, 1, groupMemberCount(testingT, env, "adult-1"))
+	assert.Empty(testingT, findDuplicates(testingT, server, "").Items)
+
+	// Its Group History And Audit Trail Now Belong To The Survivor :
+	var memberships []userModels.GroupMembership
+	require.NoError(testingT, env.db.Where("user_id = ?", survivor.ID).Order("joined_at asc").Find(&memberships).Error)
+	require.Len(testingT, memberships, 2)
+	assert.Nil(testingT, memberships[0].LeftAt)
+	assert.NotNil(testingT, memberships[1].LeftAt)
+
+	page, err := env.audit.QueryEntries(ctx, auditModels.AuditFilter{TargetID: duplicate.ID.String()})
+	require.NoError(testingT, err)
+	assert.Zero(testingT, page.Total)
+
+	page, err = env.audit.QueryEntries(ctx, auditModels.AuditFilter{TargetID: survivor.ID.String()})
+	require.NoError(testingT, err)
+	require.Equal(testingT, int64(3), page.Total)
+	assert.Equal(testingT, constants.AuditActionMerge, page.Items[0].Action)
+	assert.Contains(testingT, page.Items[0].Changes, auditModels.FieldChange{Field: "id", Before: duplicate.ID.String(), After: survivor.ID.String()})
+
+	envelope := mustFirstEnvelope(testingT, env, constants.EventUserMerged)
+	var payload eventModels.UserEventPayload
+	require.NoError(testingT, json.Unmarshal(envelope.Payload, &payload))
+	assert.Equal(testingT, survivor.ID, payload.User.ID)
+	assert.Equal(testingT, duplicate.ID.String(), payload.MergedUserID)
+
+	// Merging Again Finds Nothing To Fold :
+	resp = serveWithToken(server, http.MethodPost, "/api/v1/users/merge", "", body)
+	require.Equal(testingT, http.StatusNotFound, resp.Code, resp.Body.String())
+	assert.Equal(testingT, "USER_NOT_FOUND", decodeProblem(testingT, resp).ErrorCode)
+}
+
+func TestMergeUsersRejectsBadRequests(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	server := newTestServer(testingT, env)
+
+	user, err := env.users.CreateUser(context.Background(), "Jane", "jane@test.com", "1990-01-01")
+	require.NoError(testingT, err)
+
+	resp := serveWithToken(server, http.MethodPost, "/api/v1/users/merge", "", `{"survivor_id":"`+user.ID.String()+`","duplicate_id":"`+user.ID.String()+`"}`)
+	require.Equal(testingT, http.StatusBadRequest, resp.Code, resp.Body.String())
+	assert.Equal(testingT, "USER_MERGE_SAME", decodeProblem(testingT, resp).ErrorCode)
+
+	resp = serveWithToken(server, http.MethodPost, "/api/v1/users/merge", "", `{"survivor_id":"`+user.ID.String()+`","duplicate_id":"not-a-uuid"}`)
+	require.Equal(testingT, http.StatusBadRequest, resp.Code, resp.Body.String())
+	assert.Equal(testingT, []userModels.FieldError{{Field: "duplicate_id", Rule: "uuid", Message: "duplicate_id must be a valid uuid"}}, decodeProblem(testingT, resp).Errors)
+
+	assert.Equal(testingT, []string{user.ID.String()}, listUserIDs(testingT, server, ""))
+}
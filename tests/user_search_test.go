@@ -0,0 +1,121 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"backend-task/internal/constants"
+	userModels "backend-task/internal/user/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func searchUsers(testingT *testing.T, server http.Handler, query string) ([]string, int64) {
+
+	testingT.Helper()
+
+	resp := serveWithToken(server, http.MethodGet, "/api/v1/users/search"+query, "", "")
+	require.Equal(testingT, http.StatusOK, resp.Code, resp.Body.String())
+
+	var page userModels.UserPage
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &page))
+
+	names := []string{}
+	for _, user := range page.Items {
+
+		names = append(names, user.Name)
+	}
+
+	return names, page.Total
+}
+
+func TestSearchUsersIsFuzzyAndRanked(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	server := newTestServer(testingT, env)
+
+	createInTenant(testingT, server, constants.DefaultTenantID, `{"name":"Jonathan Smith","email":"jsmith@test.com","date_of_birth":"1990-01-01"}`)
+	createInTenant(testingT, server, constants.DefaultTenantID, `{"name":"Jane Doe","email":"jane.doe@example.com","date_of_birth":"1990-01-01"}`)
+	createInTenant(testingT, server, constants.DefaultTenantID, `{"name":"Johnny Appleseed","email":"apple@test.com","date_of_birth":"1990-01-01"}`)
+
+	// Misspelled, Partial And Email Terms :
+	names, _ := searchUsers(testingT, server, "?q=jonathon")
+	assert.Equal(testingT, []string{"Jonathan Smith"}, names)
+
+	names, _ = searchUsers(testingT, server, "?q=smi")
+	assert.Equal(testingT, []string{"Jonathan Smith"}, names)
+
+	names, _ = searchUsers(testingT, server, "?q=exampel")
+	assert.Equal(testingT, []string{"Jane Doe"}, names)
+
+	// Rows Sharing More Of The Term Rank First :
+	names, total := searchUsers(testingT, server, "?q=Johnny%20Apple")
+	assert.Equal(testingT, int64(1), total)
+	assert.Equal(testingT, []string{"Johnny Appleseed"}, names)
+
+	names, total = searchUsers(testingT, server, "?q=jon%20test.com")
+	assert.Equal(testingT, int64(2), total)
+	assert.Equal(testingT, []string{"Jonathan Smith", "Johnny Appleseed"}, names)
+
+	names, _ = searchUsers(testingT, server, "?q=zzzz")
+	assert.Empty(testingT, names)
+
+	for _, query := range []string{"", "?q=jo", "?q=%20%20jo%20%20"} {
+
+		resp := serveWithToken(server, http.MethodGet, "/api/v1/users/search"+query, "", "")
+		require.Equal(testingT, http.StatusBadRequest, resp.Code, query)
+		assert.Equal(testingT, "SEARCH_QUERY_INVALID", decodeProblem(testingT, resp).ErrorCode, query)
+	}
+}
+
+func TestSearchUsersHonoursFiltersAndPagination(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	server := newTestServer(testingT, env)
+
+	// Capacity 3 Puts The Fourth Adult In adult-2 :
+	for _, body := range []string{
+		`{"name":"Alice Test","email":"alice@test.com","date_of_birth":"1990-01-01"}`,
+		`{"name":"Bob Test","email":"bob@test.com","date_of_birth":"1990-01-01"}`,
+		`{"name":"Carol Test","email":"carol@test.com","date_of_birth":"1990-01-01"}`,
+	} {
+
+		createInTenant(testingT, server, constants.DefaultTenantID, body)
+	}
+	dave := createInTenant(testingT, server, constants.DefaultTenantID, `{"name":"Dave Test","email":"dave@test.com","date_of_birth":"1990-01-01"}`)
+	require.Equal(testingT, "adult-2", dave.Group)
+
+	names, total := searchUsers(testingT, server, "?q=test.com&group=adult-2")
+	assert.Equal(testingT, int64(1), total)
+	assert.Equal(testingT, []string{"Dave Test"}, names)
+
+	first, total := searchUsers(testingT, server, "?q=test.com&page_size=3")
+	assert.Equal(testingT, int64(4), total)
+	require.Len(testingT, first, 3)
+
+	rest, total := searchUsers(testingT, server, "?q=test.com&page=2&page_size=3")
+	assert.Equal(testingT, int64(4), total)
+	require.Len(testingT, rest, 1)
+	assert.NotContains(testingT, first, rest[0])
+
+	// Suspended Users Are Only Found On Request, And Renamed Users By Their New Name :
+	status, _ := changeStatus(testingT, server, "", dave.ID.String(), "suspend", `{"reason":"spam"}`)
+	require.Equal(testingT, http.StatusOK, status)
+
+	names, _ = searchUsers(testingT, server, "?q=dave")
+	assert.Empty(testingT, names)
+
+	names, _ = searchUsers(testingT, server, "?q=dave&status=suspended")
+	assert.Equal(testingT, []string{"Dave Test"}, names)
+
+	resp := serveWithToken(server, http.MethodPatch, "/api/v1/users/"+dave.ID.String(), "", `{"name":"David Renamed"}`)
+	require.Equal(testingT, http.StatusOK, resp.Code, resp.Body.String())
+
+	names, _ = searchUsers(testingT, server, "?q=renamed&status=suspended")
+	assert.Equal(testingT, []string{"David Renamed"}, names)
+
+	resp = serveWithToken(server, http.MethodGet, "/api/v1/users/search?q=test&status=banned", "", "")
+	assert.Equal(testingT, http.StatusBadRequest, resp.Code)
+}
@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 )
 
 func TestService(testingT *testing.T) {
+	currentContext := context.Background()
 	mockService := new(mocks.UserService)
 
 	user := &models.User{
@@ -22,26 +24,26 @@ func TestService(testingT *testing.T) {
 	}
 
 	// Create User :
-	mockService.On("CreateUser", user.Name, user.Email, user.DateOfBirth.String()).Return(user, nil)
-	createdUser, err := mockService.CreateUser(user.Name, user.Email, user.DateOfBirth.String())
+	mockService.On("CreateUser", currentContext, user.Name, user.Email, user.DateOfBirth.String()).Return(user, nil)
+	createdUser, err := mockService.CreateUser(currentContext, user.Name, user.Email, user.DateOfBirth.String())
 	assert.NoError(testingT, err)
 
 	// Update User :
 	name := "Updated Name"
 	email := "updated@test.com"
-	mockService.On("UpdateUser", createdUser.ID.String(), &name, &email).Return(user, nil)
-	_, err = mockService.UpdateUser(createdUser.ID.String(), &name, &email)
+	mockService.On("UpdateUser", currentContext, createdUser.ID.String(), &name, &email).Return(user, nil)
+	_, err = mockService.UpdateUser(currentContext, createdUser.ID.String(), &name, &email)
 	assert.NoError(testingT, err)
 
 	// Get User By ID :
-	mockService.On("GetUserByID", createdUser.ID.String()).Return(user, nil)
-	result, err := mockService.GetUserByID(createdUser.ID.String())
+	mockService.On("GetUserByID", currentContext, createdUser.ID.String()).Return(user, nil)
+	result, err := mockService.GetUserByID(currentContext, createdUser.ID.String())
 	assert.NoError(testingT, err)
 	assert.Equal(testingT, user, result)
 
 	// List Users By Filter :
-	mockService.On("ListUsersByFilter", "adult-1").Return([]*models.User{user}, nil)
-	users, err := mockService.ListUsersByFilter("adult-1")
+	mockService.On("ListUsersByFilter", currentContext, "adult-1").Return([]*models.User{user}, nil)
+	users, err := mockService.ListUsersByFilter(currentContext, "adult-1")
 	assert.NoError(testingT, err)
 	assert.Len(testingT, users, 1)
 	assert.Equal(testingT, user, users[0])
@@ -52,6 +54,7 @@ func TestService(testingT *testing.T) {
 
 func TestListUsersByFilterService(t *testing.T) {
 
+	currentContext := context.Background()
 	mockService := new(mocks.UserService)
 
 	users := []*models.User{
@@ -59,9 +62,9 @@ func TestListUsersByFilterService(t *testing.T) {
 		{ID: uuid.New(), Name: "Abudalou1", Email: "abudalou1@test.com", DateOfBirth: time.Date(2000, 1, 4, 0, 0, 0, 0, time.UTC)},
 	}
 
-	mockService.On("ListUsersByFilter", "adult-1").Return(users, nil)
+	mockService.On("ListUsersByFilter", currentContext, "adult-1").Return(users, nil)
 
-	result, err := mockService.ListUsersByFilter("adult-1")
+	result, err := mockService.ListUsersByFilter(currentContext, "adult-1")
 	assert.NoError(t, err)
 
 	assert.Len(t, result, len(users))
@@ -0,0 +1,232 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"backend-task/internal/constants"
+	userModels "backend-task/internal/user/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func changeStatus(testingT *testing.T, server http.Handler, token, id, action, body string) (int, userModels.User) {
+
+	testingT.Helper()
+
+	resp := serveWithToken(server, http.MethodPost, "/api/v1/users/"+id+"/"+action, token, body)
+
+	var user userModels.User
+	if resp.Code == http.StatusOK {
+
+		require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &user))
+	}
+
+	return resp.Code, user
+}
+
+func listUserIDs(testingT *testing.T, server http.Handler, query string) []string {
+
+	testingT.Helper()
+
+	resp := serveWithToken(server, http.MethodGet, "/api/v1/users"+query, "", "")
+	require.Equal(testingT, http.StatusOK, resp.Code, resp.Body.String())
+
+	var users []userModels.User
+	require.NoError(testingT, json.Unmarshal(resp.Body.Bytes(), &users))
+
+	ids := []string{}
+	for _, user := range users {
+
+		ids = append(ids, user.ID.String())
+	}
+
+	return ids
+}
+
+func groupMemberCount(testingT *testing.T, env *testServices, name string) int {
+
+	testingT.Helper()
+
+	var group userModels.Group
+	require.NoError(testingT, env.db.First(&group, "name = ?", name).Error)
+
+	return group.MemberCount
+}
+
+func TestSuspensionKeepsOrReleasesTheSeat(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	server := newTestServer(testingT, env)
+	ctx := context.Background()
+
+	kept, err := env.users.CreateUser(ctx, "Kept", "kept@test.com", "1990-01-01")
+	require.NoError(testingT, err)
+
+	released, err := env.users.CreateUser(ctx, "Released", "released@test.com", "1990-01-01")
+	require.NoError(testingT, err)
+
+	// Suspension Keeps The Seat By Default :
+	status, user := changeStatus(testingT, server, "", kept.ID.String(), "suspend", `{"reason":"spam reports"}`)
+	require.Equal(testingT, http.StatusOK, status)
+	assert.Equal(testingT, constants.UserStatusSuspended, user.Status)
+	assert.Equal(testingT, "spam reports", user.StatusReason)
+	assert.NotNil(testingT, user.StatusChangedAt)
+	assert.Equal(testingT, "adult-1", user.Group)
+	assert.Equal(testingT, 2, groupMemberCount(testingT, env, "adult-1"))
+
+	// Suspended Users Are Only Listed On Request :
+	assert.Equal(testingT, []string{released.ID.String()}, listUserIDs(testingT, server, ""))
+	assert.Equal(testingT, []string{kept.ID.String()}, listUserIDs(testingT, server, "?status=suspended"))
+	assert.Equal(testingT, http.StatusBadRequest, serveWithToken(server, http.MethodGet, "/api/v1/users?status=banned", "", "").Code)
+
+	// Or Gives It Up :
+	status, user = changeStatus(testingT, server, "", released.ID.String(), "suspend", `{"reason":"chargeback","release_seat":true}`)
+	require.Equal(testingT, http.StatusOK, status)
+	assert.Empty(testingT, user.Group)
+	assert.Equal(testingT, 1, groupMemberCount(testingT, env, "adult-1"))
+
+	var membership userModels.GroupMembership
+	require.NoError(testingT, env.db.First(&membership, "user_id = ?", released.ID).Error)
+	assert.NotNil(testingT, membership.LeftAt)
+
+	// Activation Restores The Kept Seat Or Allocates A New One :
+	status, user = changeStatus(testingT, server, "", kept.ID.String(), "activate", `{"reason":"appeal accepted"}`)
+	require.Equal(testingT, http.StatusOK, status)
+	assert.Equal(testingT, constants.UserStatusActive, user.Status)
+	assert.Equal(testingT, "adult-1", user.Group)
+
+	status, user = changeStatus(testingT, server, "", released.ID.String(), "activate", `{"reason":"appeal accepted"}`)
+	require.Equal(testingT, http.StatusOK, status)
+	assert.Equal(testingT, "adult-1", user.Group)
+	assert.Equal(testingT, 2, groupMemberCount(testingT, env, "adult-1"))
+}
+
+func TestStatusTransitionsAreEnforced(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	server := newTestServer(testingT, env)
+
+	user, err := env.users.CreateUser(context.Background(), "Jane", "jane@test.com", "1990-01-01")
+	require.NoError(testingT, err)
+	id := user.ID.String()
+
+	status, _ := changeStatus(testingT, server, "", id, "suspend", `{}`)
+	assert.Equal(testingT, http.StatusBadRequest, status)
+
+	status, _ = changeStatus(testingT, server, "", id, "activate", `{"reason":"already active"}`)
+	assert.Equal(testingT, http.StatusConflict, status)
+
+	status, _ = changeStatus(testingT, server, "", "not-a-uuid", "suspend", `{"reason":"spam"}`)
+	assert.Equal(testingT, http.StatusBadRequest, status)
+
+	// Deactivation Always Frees The Seat :
+	status, deactivated := changeStatus(testingT, server, "", id, "deactivate", `{"reason":"account closed"}`)
+	require.Equal(testingT, http.StatusOK, status)
+	assert.Equal(testingT, constants.UserStatusDeactivated, deactivated.Status)
+	assert.Empty(testingT, deactivated.Group)
+	assert.Zero(testingT, groupMemberCount(testingT, env, "adult-1"))
+	assert.Empty(testingT, listUserIDs(testingT, server, ""))
+
+	status, _ = changeStatus(testingT, server, "", id, "suspend", `{"reason":"spam"}`)
+	assert.Equal(testingT, http.StatusConflict, status)
+
+	status, reactivated := changeStatus(testingT, server, "", id, "activate", `{"reason":"reopened"}`)
+	require.Equal(testingT, http.StatusOK, status)
+	assert.Equal(testingT, "adult-1", reactivated.Group)
+}
+
+func TestActivatingAMinorNeedsConsent(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	server := newTestServer(testingT, env)
+
+	kid, err := env.users.CreateUserWithGuardian(context.Background(), "Kid", "kid@test.com", "2015-01-01", &userModels.GuardianReq{Name: "Parent", Email: "parent@test.com"})
+	require.NoError(testingT, err)
+	id := kid.ID.String()
+
+	// pending_consent Only Becomes Active Through Consent :
+	status, _ := changeStatus(testingT, server, "", id, "activate", `{"reason":"skip consent"}`)
+	assert.Equal(testingT, http.StatusConflict, status)
+
+	status, _ = changeStatus(testingT, server, "", id, "deactivate", `{"reason":"duplicate signup"}`)
+	require.Equal(testingT, http.StatusOK, status)
+
+	status, _ = changeStatus(testingT, server, "", id, "activate", `{"reason":"reopened"}`)
+	assert.Equal(testingT, http.StatusConflict, status)
+
+	// Consent Given Meanwhile Is Recorded, Allocation Waits For Activation :
+	granted := grantConsent(testingT, env, "parent@test.com")
+	assert.Equal(testingT, constants.UserStatusDeactivated, granted.Status)
+	assert.Empty(testingT, granted.Group)
+
+	status, activated := changeStatus(testingT, server, "", id, "activate", `{"reason":"reopened"}`)
+	require.Equal(testingT, http.StatusOK, status)
+	assert.Equal(testingT, "child-1", activated.Group)
+	assert.Equal(testingT, 1, groupMemberCount(testingT, env, "child-1"))
+}
+
+func TestSuspendedUsersCannotSignIn(testingT *testing.T) {
+
+	_, server := newAuthTestServer(testingT)
+
+	user := registerTestAccount(testingT, server, "member@test.com")
+	status, tokens := loginTestAccount(testingT, server, "member@test.com", testPassword)
+	require.Equal(testingT, http.StatusOK, status)
+	require.Equal(testingT, http.StatusOK, serveWithToken(server, http.MethodGet, "/api/v1/users/"+user.ID.String(), tokens.AccessToken, "").Code)
+
+	// Editors May Not Change Status, MFA-Verified Admins May :
+	editor := signHS256(testingT, newTestClaims("editor-1", constants.RoleEditor))
+	status, _ = changeStatus(testingT, server, editor, user.ID.String(), "suspend", `{"reason":"spam"}`)
+	assert.Equal(testingT, http.StatusForbidden, status)
+
+	admin := signHS256(testingT, withMFA(newTestClaims("admin-1", constants.RoleAdmin)))
+	status, suspended := changeStatus(testingT, server, admin, user.ID.String(), "suspend", `{"reason":"spam"}`)
+	require.Equal(testingT, http.StatusOK, status)
+	assert.Equal(testingT, "admin-1", suspended.StatusChangedBy)
+
+	// The Access Token Issued Before The Suspension Dies With Its Session :
+	resp := serveWithToken(server, http.MethodGet, "/api/v1/users/"+user.ID.String(), tokens.AccessToken, "")
+	assert.Equal(testingT, http.StatusUnauthorized, resp.Code)
+	assert.Equal(testingT, "AUTH_SESSION_REVOKED", decodeProblem(testingT, resp).ErrorCode)
+
+	// So Does Its Refresh Token, And New Logins Are Refused :
+	status, _ = refreshTestTokens(server, tokens.RefreshToken)
+	assert.Equal(testingT, http.StatusUnauthorized, status)
+
+	status, _ = loginTestAccount(testingT, server, "member@test.com", testPassword)
+	assert.Equal(testingT, http.StatusForbidden, status)
+
+	// Wrong Passwords Still Look Like Any Other Failure :
+	status, _ = loginTestAccount(testingT, server, "member@test.com", "wrong-password")
+	assert.Equal(testingT, http.StatusUnauthorized, status)
+}
+
+func TestExportAndDuplicatesLeaveOutInactiveUsers(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	server := newTestServer(testingT, env)
+
+	john := createInTenant(testingT, server, constants.DefaultTenantID, `{"name":"John Doe","email":"jdoe@test.com","date_of_birth":"1990-01-01"}`)
+	johnAgain := createInTenant(testingT, server, constants.DefaultTenantID, `{"name":"Doe, John","email":"john.doe@other.com","date_of_birth":"1990-01-01"}`)
+	require.Len(testingT, findDuplicates(testingT, server, "").Items, 1)
+
+	status, _ := changeStatus(testingT, server, "", johnAgain.ID.String(), "deactivate", `{"reason":"left"}`)
+	require.Equal(testingT, http.StatusOK, status)
+
+	// Like GET /users, Neither Lists A Deactivated User :
+	assert.Empty(testingT, findDuplicates(testingT, server, "").Items)
+
+	var buffer bytes.Buffer
+	require.NoError(testingT, env.users.ExportUsers(context.Background(), &buffer, userModels.ExportOptions{Format: constants.ExportFormatCSV, BatchSize: 1}))
+
+	records, err := csv.NewReader(&buffer).ReadAll()
+	require.NoError(testingT, err)
+	require.Len(testingT, records, 2)
+	assert.Equal(testingT, john.ID.String(), records[1][0])
+}
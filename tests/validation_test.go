@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"backend-task/internal/constants"
+	userModels "backend-task/internal/user/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindingReportsEveryInvalidField(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	server := newTestServer(testingT, env)
+
+	future := time.Now().AddDate(1, 0, 0).Format("2006-01-02")
+	body := `[{"name":"Jane","email":"jane@test.com","date_of_birth":"1990-01-01"},` +
+		`{"name":"   ","email":"not-an-email","date_of_birth":"` + future + `"},` +
+		`{"email":"kid@test.com","date_of_birth":"01/01/2015","guardian":{"name":"Parent","email":"parent"}}]`
+
+	resp := serveWithToken(server, http.MethodPost, "/api/v1/users", "", body)
+	require.Equal(testingT, http.StatusBadRequest, resp.Code, resp.Body.String())
+
+	problem := decodeProblem(testingT, resp)
+	assert.Equal(testingT, "VALIDATION_FAILED", problem.ErrorCode)
+	assert.Equal(testingT, []userModels.FieldError{
+
+		{Field: "[1].name", Rule: "notblank", Message: "name is required"},
+		{Field: "[1].email", Rule: "email", Message: "email must be a valid email address"},
+		{Field: "[1].date_of_birth", Rule: "pastdate", Message: "date_of_birth cannot be in the future"},
+		{Field: "[2].name", Rule: "required", Message: "name is required"},
+		{Field: "[2].date_of_birth", Rule: "datetime", Param: "2006-01-02", Message: "date_of_birth must be yyyy-mm-dd"},
+		{Field: "[2].guardian.email", Rule: "email", Message: "guardian.email must be a valid email address"},
+	}, problem.Errors)
+
+	// Nothing Was Created :
+	assert.Empty(testingT, listUserIDs(testingT, server, ""))
+
+	// Wrong JSON Types Name The Field :
+	resp = serveWithToken(server, http.MethodPost, "/api/v1/webhooks", "", `{"url":"https://example.com","event_types":"user.created","secret":"s"}`)
+	assert.Equal(testingT, http.StatusBadRequest, resp.Code)
+	assert.Equal(testingT, []userModels.FieldError{{Field: "event_types", Rule: "type", Param: "array", Message: "event_types must be of type array, not string"}}, decodeProblem(testingT, resp).Errors)
+}
+
+func TestServiceRulesAreReportedPerElement(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	server := newTestServer(testingT, env)
+
+	taken := createInTenant(testingT, server, constants.DefaultTenantID, `{"name":"Taken","email":"taken@test.com","date_of_birth":"1990-01-01"}`)
+
+	// Rules Only The Service Knows ( A Minor Needs A Guardian, Taken Or Repeated Emails ) Are Listed For Every Element :
+	body := `[{"name":"Jane","email":"jane@test.com","date_of_birth":"1990-01-01"},` +
+		`{"name":"Kid","email":"kid@test.com","date_of_birth":"2015-01-01"},` +
+		`{"name":"Again","email":"Taken@test.com","date_of_birth":"1990-01-01"},` +
+		`{"name":"Jane Again","email":"jane@test.com","date_of_birth":"1990-01-01"},` +
+		`{"name":"Other Kid","email":"other-kid@test.com","date_of_birth":"2016-01-01"}]`
+
+	resp := serveWithToken(server, http.MethodPost, "/api/v1/users", "", body)
+	require.Equal(testingT, http.StatusBadRequest, resp.Code, resp.Body.String())
+
+	problem := decodeProblem(testingT, resp)
+	assert.Equal(testingT, "VALIDATION_FAILED", problem.ErrorCode)
+	assert.Equal(testingT, []userModels.FieldError{
+
+		{Field: "[1].guardian", Rule: "required", Message: "guardian name and email are required for users under 18"},
+		{Field: "[2].email", Rule: "unique", Message: "email already exists"},
+		{Field: "[3].email", Rule: "unique", Message: "email already exists"},
+		{Field: "[4].guardian", Rule: "required", Message: "guardian name and email are required for users under 18"},
+	}, problem.Errors)
+
+	// A Rejected Batch Stores Nothing, Not Even Its Valid Elements :
+	assert.Equal(testingT, []string{taken.ID.String()}, listUserIDs(testingT, server, ""))
+
+	// Taken Emails Alone Are A Conflict, Still Without A Partial Write :
+	resp = serveWithToken(server, http.MethodPost, "/api/v1/users", "", `[{"name":"Jane","email":"jane@test.com","date_of_birth":"1990-01-01"},{"name":"Again","email":"taken@test.com","date_of_birth":"1990-01-01"}]`)
+	require.Equal(testingT, http.StatusConflict, resp.Code, resp.Body.String())
+	assert.Equal(testingT, []string{taken.ID.String()}, listUserIDs(testingT, server, ""))
+
+	// Updates Report Both Fields At Once :
+	id := taken.ID.String()
+	problem = decodeProblem(testingT, serveWithToken(server, http.MethodPatch, "/api/v1/users/"+id, "", `{"name":" ","email":"nope"}`))
+	assert.Equal(testingT, []userModels.FieldError{
+
+		{Field: "name", Rule: "notblank", Message: "name cannot be empty"},
+		{Field: "email", Rule: "email", Message: "invalid email format"},
+	}, problem.Errors)
+}
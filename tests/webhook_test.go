@@ -0,0 +1,210 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"backend-task/internal/constants"
+	eventModels "backend-task/internal/events/models"
+	"backend-task/internal/events/publisher"
+	eventServices "backend-task/internal/events/services"
+	webhookModels "backend-task/internal/webhook/models"
+	webhookRepository "backend-task/internal/webhook/repository"
+	webhookServices "backend-task/internal/webhook/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testWebhookSecret = "0123456789abcdef-secret"
+
+// webhookReceiver Is A Partner Endpoint That Fails The First N Calls :
+type webhookReceiver struct {
+	mutex    sync.Mutex
+	failures int
+	bodies   [][]byte
+	verified []bool
+}
+
+func (receiver *webhookReceiver) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+
+	body, _ := io.ReadAll(request.Body)
+
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+
+	receiver.bodies = append(receiver.bodies, body)
+	receiver.verified = append(receiver.verified, webhookServices.VerifySignature(
+		testWebhookSecret, request.Header.Get(constants.HeaderWebhookSignature), body, time.Now(), 0))
+
+	if receiver.failures > 0 {
+
+		receiver.failures--
+		writer.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func TestWebhookDeliveryEndToEnd(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	ctx := context.Background()
+
+	receiver := &webhookReceiver{failures: 1}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	webhookRepo := webhookRepository.NewWebhookRepository(env.db)
+	webhookService := webhookServices.NewWebhookService(env.db, webhookRepo, env.audit)
+
+	subscription, err := webhookService.CreateSubscription(ctx, webhookModels.CreateSubscriptionReq{
+		URL:        server.URL,
+		EventTypes: []string{constants.EventUserCreated},
+		Secret:     testWebhookSecret,
+	})
+	require.NoError(testingT, err)
+
+	_, err = env.users.CreateUser(ctx, "Abudalou", "abudalou@test.com", "2000-01-04")
+	require.NoError(testingT, err)
+
+	// Relay The Outbox Into The Dispatcher ( Twice, To Prove Fan-Out Is Idempotent ) :
+	dispatcher := webhookServices.NewDispatcher(webhookRepo, nil)
+	_, err = eventServices.NewRelay(env.outbox, dispatcher, time.Second, 100).RelayOnce(ctx)
+	require.NoError(testingT, err)
+	require.NoError(testingT, dispatcher.Publish(ctx, mustFirstEnvelope(testingT, env, constants.EventUserCreated)))
+
+	deliveries, err := webhookService.ListDeliveries(ctx, subscription.ID.String())
+	require.NoError(testingT, err)
+	require.Len(testingT, deliveries, 1)
+
+	// Injectable Clock Drives The Backoff :
+	now := time.Now()
+	worker := webhookServices.NewWorker(webhookRepo, webhookServices.WorkerConfig{
+		MaxAttempts: 3,
+		BaseBackoff: time.Minute,
+		MaxBackoff:  time.Hour,
+		Now:         func() time.Time { return now },
+	})
+
+	// 1st Attempt Fails ( 503 ) And Is Rescheduled A Minute Later :
+	attempted, err := worker.DeliverDue(ctx)
+	require.NoError(testingT, err)
+	assert.Equal(testingT, 1, attempted)
+
+	attempted, err = worker.DeliverDue(ctx)
+	require.NoError(testingT, err)
+	assert.Equal(testingT, 0, attempted, "retry must wait for the backoff")
+
+	// 2nd Attempt Succeeds :
+	now = now.Add(time.Minute)
+	attempted, err = worker.DeliverDue(ctx)
+	require.NoError(testingT, err)
+	assert.Equal(testingT, 1, attempted)
+
+	deliveries, err = webhookService.ListDeliveries(ctx, subscription.ID.String())
+	require.NoError(testingT, err)
+	assert.Equal(testingT, constants.WebhookDeliverySucceeded, deliveries[0].Status)
+
+	attempts, err := webhookService.ListAttempts(ctx, deliveries[0].ID.String())
+	require.NoError(testingT, err)
+	require.Len(testingT, attempts, 2)
+	assert.Equal(testingT, http.StatusServiceUnavailable, attempts[0].StatusCode)
+	assert.Equal(testingT, http.StatusNoContent, attempts[1].StatusCode)
+
+	// Every Call Was Signed And Carried The Envelope :
+	require.Len(testingT, receiver.bodies, 2)
+	assert.Equal(testingT, []bool{true, true}, receiver.verified)
+
+	var envelope eventModels.Envelope
+	require.NoError(testingT, json.Unmarshal(receiver.bodies[1], &envelope))
+	assert.Equal(testingT, constants.EventUserCreated, envelope.Type)
+}
+
+func TestWebhookDeliveryIsDeadLetteredAfterMaxAttempts(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	ctx := context.Background()
+
+	receiver := &webhookReceiver{failures: 100}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	webhookRepo := webhookRepository.NewWebhookRepository(env.db)
+	webhookService := webhookServices.NewWebhookService(env.db, webhookRepo, env.audit)
+
+	subscription, err := webhookService.CreateSubscription(ctx, webhookModels.CreateSubscriptionReq{
+		URL:        server.URL,
+		EventTypes: []string{constants.WebhookAllEvents},
+		Secret:     testWebhookSecret,
+	})
+	require.NoError(testingT, err)
+
+	require.NoError(testingT, webhookServices.NewDispatcher(webhookRepo, nil).Publish(ctx, eventModels.Envelope{
+		ID: subscription.ID, Type: constants.EventGroupFull, Version: 1, OccurredAt: time.Now(), Payload: json.RawMessage(`{}`),
+	}))
+
+	now := time.Now()
+	worker := webhookServices.NewWorker(webhookRepo, webhookServices.WorkerConfig{
+		MaxAttempts: 2, BaseBackoff: time.Second, MaxBackoff: time.Second, Now: func() time.Time { return now },
+	})
+
+	for i := 0; i < 3; i++ {
+
+		_, err := worker.DeliverDue(ctx)
+		require.NoError(testingT, err)
+		now = now.Add(time.Second)
+	}
+
+	deliveries, err := webhookService.ListDeliveries(ctx, subscription.ID.String())
+	require.NoError(testingT, err)
+	require.Len(testingT, deliveries, 1)
+	assert.Equal(testingT, constants.WebhookDeliveryDead, deliveries[0].Status)
+	assert.Equal(testingT, 2, deliveries[0].Attempts)
+
+	// Manual Redelivery Revives It :
+	require.NoError(testingT, webhookService.Redeliver(ctx, deliveries[0].ID.String()))
+	deliveries, _ = webhookService.ListDeliveries(ctx, subscription.ID.String())
+	assert.Equal(testingT, constants.WebhookDeliveryPending, deliveries[0].Status)
+}
+
+func TestWebhookSubscriptionValidation(testingT *testing.T) {
+
+	env := newTestServices(testingT)
+	webhookService := webhookServices.NewWebhookService(env.db, webhookRepository.NewWebhookRepository(env.db), env.audit)
+
+	_, err := webhookService.CreateSubscription(context.Background(), webhookModels.CreateSubscriptionReq{
+		URL: "ftp://partner", EventTypes: []string{constants.EventUserCreated}, Secret: testWebhookSecret,
+	})
+	assert.Error(testingT, err)
+
+	_, err = webhookService.CreateSubscription(context.Background(), webhookModels.CreateSubscriptionReq{
+		URL: "https://partner.example.com", EventTypes: []string{"user.exploded"}, Secret: testWebhookSecret,
+	})
+	assert.Error(testingT, err)
+
+	_, err = webhookService.CreateSubscription(context.Background(), webhookModels.CreateSubscriptionReq{
+		URL: "https://partner.example.com", EventTypes: []string{constants.EventUserCreated}, Secret: "short",
+	})
+	assert.Error(testingT, err)
+}
+
+// mustFirstEnvelope Rebuilds The First Outbox Envelope Of A Type ( Already Relayed ) :
+func mustFirstEnvelope(testingT *testing.T, env *testServices, eventType string) eventModels.Envelope {
+
+	testingT.Helper()
+
+	var event eventModels.OutboxEvent
+	require.NoError(testingT, env.db.Where("type = ?", eventType).Order("occurred_at asc").First(&event).Error)
+
+	return event.Envelope()
+}
+
+var _ publisher.Publisher = (*webhookServices.Dispatcher)(nil)